@@ -0,0 +1,37 @@
+terraform {
+  required_providers {
+    aap = {
+      source = "ansible/aap"
+    }
+  }
+}
+
+provider "aap" {
+  host     = "https://localhost:8043"
+  username = "ansible"
+  password = "test123!"
+  insecure_skip_verify = true
+}
+
+variable "state_file" {
+  type = string
+  description = "Path to the Terraform state holding the ansible_host resources"
+}
+
+resource "aap_state_inventory" "sample" {
+  name         = "Terraform managed hosts"
+  organization = 1
+  state_file   = var.state_file
+}
+
+output "inventory_id" {
+  value = aap_state_inventory.sample.id
+}
+
+output "inventory_hosts" {
+  value = aap_state_inventory.sample.hosts
+}
+
+output "inventory_groups" {
+  value = aap_state_inventory.sample.groups
+}
@@ -0,0 +1,53 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// apiStats counts the requests sent to AAP and the time spent waiting for their responses.
+// Requests sent concurrently are all counted, so the time may exceed the duration of the operation.
+type apiStats struct {
+	calls    atomic.Int64
+	duration atomic.Int64
+}
+
+func (s *apiStats) record(duration time.Duration) {
+	s.calls.Add(1)
+	s.duration.Add(int64(duration))
+}
+
+// withStats returns a copy of the client counting its requests, for a resource to report them at the end of an operation.
+func (c *AAPClient) withStats() *AAPClient {
+	client := *c
+	client.stats = &apiStats{}
+	return &client
+}
+
+// reportStats logs the requests counted since withStats for an operation, e.g. "create", of a resource type;
+// with ReportAPIUsage they are also reported as a warning.
+func (c *AAPClient) reportStats(ctx context.Context, resourceType string, operation string, diags *diag.Diagnostics) {
+	// resources planned before the provider is configured have no client
+	if c == nil || c.stats == nil {
+		return
+	}
+	calls := c.stats.calls.Load()
+	duration := time.Duration(c.stats.duration.Load()).Round(time.Millisecond)
+
+	tflog.Info(ctx, "AAP API usage", map[string]any{
+		"resource_type": resourceType,
+		"operation":     operation,
+		"calls":         calls,
+		"duration":      duration.String(),
+	})
+	if c.ReportAPIUsage {
+		diags.AddWarning("AAP API usage",
+			fmt.Sprintf("The %s operation of %s sent %d requests to AAP and waited %s for their responses.",
+				operation, resourceType, calls, duration))
+	}
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestReportAPIUsage(t *testing.T) {
+	mock := newMockAAP(t)
+	mock.addObject("api/v2/host_metrics", map[string]any{"hostname": "old.example.com", "last_automation": "2020-01-01T00:00:00Z", "deleted": false})
+
+	p := newTestProvider(t, mock, map[string]any{"report_api_usage": true})
+	cleanup := p.resource("aap_host_metrics_cleanup")
+	cleanup.apply(map[string]any{"stale_days": 30})
+
+	var reported []string
+	for _, warning := range cleanup.warnings {
+		if strings.Contains(warning, "aap_host_metrics_cleanup sent") {
+			reported = append(reported, warning)
+		}
+	}
+	if len(reported) == 0 || !strings.Contains(reported[len(reported)-1], "The create operation of aap_host_metrics_cleanup sent 2 requests") {
+		t.Errorf("warnings = %v, expected the requests of the create operation to be reported", cleanup.warnings)
+	}
+
+	p.readDataSource("aap_host_metrics", map[string]any{"include_deleted": true})
+	if !slices.ContainsFunc(p.dataSourceWarnings, func(warning string) bool {
+		return strings.Contains(warning, "The read operation of aap_host_metrics sent 1 requests")
+	}) {
+		t.Errorf("warnings = %v, expected the requests of reading the data source to be reported", p.dataSourceWarnings)
+	}
+}
@@ -0,0 +1,230 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+)
+
+// awxExportEncrypted is the value AAP returns in place of secret credential inputs.
+const awxExportEncrypted = "$encrypted$"
+
+// awxExportType describes how objects of a type are exported.
+type awxExportType struct {
+	kind       string
+	collection string
+	// fields copied as they are
+	fields []string
+	// fields holding the id of another object, exported as its natural key, with the type of that object
+	references map[string]string
+	// related collections exported as the natural keys of their objects, with the type of those objects
+	related map[string]string
+}
+
+// awxExportTypes are the types of objects that can be exported, by the key they are listed under in an export.
+var awxExportTypes = map[string]awxExportType{
+	"projects": {
+		kind:       "project",
+		collection: "api/v2/projects/",
+		fields: []string{
+			"name", "description", "local_path", "scm_type", "scm_url", "scm_branch", "scm_refspec", "scm_clean",
+			"scm_track_submodules", "scm_delete_on_update", "scm_update_on_launch", "scm_update_cache_timeout",
+			"allow_override", "timeout",
+		},
+		references: map[string]string{
+			"organization":                    "organization",
+			"credential":                      "credential",
+			"default_environment":             "execution_environment",
+			"signature_validation_credential": "credential",
+		},
+	},
+	"job_templates": {
+		kind:       "job_template",
+		collection: "api/v2/job_templates/",
+		fields: []string{
+			"name", "description", "job_type", "playbook", "scm_branch", "forks", "limit", "verbosity", "extra_vars",
+			"job_tags", "force_handlers", "skip_tags", "start_at_task", "timeout", "use_fact_cache", "host_config_key",
+			"ask_scm_branch_on_launch", "ask_diff_mode_on_launch", "ask_variables_on_launch", "ask_limit_on_launch",
+			"ask_tags_on_launch", "ask_skip_tags_on_launch", "ask_job_type_on_launch", "ask_verbosity_on_launch",
+			"ask_inventory_on_launch", "ask_credential_on_launch", "ask_execution_environment_on_launch",
+			"ask_labels_on_launch", "ask_forks_on_launch", "ask_job_slice_count_on_launch", "ask_timeout_on_launch",
+			"ask_instance_groups_on_launch", "survey_enabled", "become_enabled", "diff_mode", "allow_simultaneous",
+			"job_slice_count", "webhook_service", "prevent_instance_group_fallback",
+		},
+		references: map[string]string{
+			"organization":          "organization",
+			"inventory":             "inventory",
+			"project":               "project",
+			"execution_environment": "execution_environment",
+			"webhook_credential":    "credential",
+		},
+		related: map[string]string{
+			"credentials":     "credential",
+			"labels":          "label",
+			"instance_groups": "instance_group",
+		},
+	},
+	"credentials": {
+		kind:       "credential",
+		collection: "api/v2/credentials/",
+		fields:     []string{"name", "description", "inputs"},
+		references: map[string]string{
+			"organization":    "organization",
+			"credential_type": "credential_type",
+		},
+	},
+}
+
+// awxNaturalKeyTypes describe the natural keys of the objects exports refer to: their collection, and the fields
+// besides the name identifying them with the type of the object these fields refer to, if any.
+var awxNaturalKeyTypes = map[string]struct {
+	collection string
+	fields     map[string]string
+}{
+	"organization":          {"api/v2/organizations/", nil},
+	"inventory":             {"api/v2/inventories/", map[string]string{"organization": "organization"}},
+	"project":               {"api/v2/projects/", map[string]string{"organization": "organization"}},
+	"job_template":          {"api/v2/job_templates/", map[string]string{"organization": "organization"}},
+	"execution_environment": {"api/v2/execution_environments/", nil},
+	"credential_type":       {"api/v2/credential_types/", map[string]string{"kind": ""}},
+	"credential":            {"api/v2/credentials/", map[string]string{"organization": "organization", "credential_type": "credential_type"}},
+	"label":                 {"api/v2/labels/", map[string]string{"organization": "organization"}},
+	"instance_group":        {"api/v2/instance_groups/", nil},
+}
+
+// awxExporter exports objects in the format of awx export, looking up the natural keys of the objects they refer to.
+type awxExporter struct {
+	client *AAPClient
+	keys   map[string]map[string]any
+}
+
+func newAWXExporter(client *AAPClient) *awxExporter {
+	return &awxExporter{client: client, keys: make(map[string]map[string]any)}
+}
+
+// rawId returns the id held in a decoded field, or false when the field is null.
+func rawId(value any) (int64, bool) {
+	number, ok := value.(float64)
+	return int64(number), ok
+}
+
+// naturalKey returns the natural key of the object of the given type, e.g. {"name": "Default", "type": "organization"}.
+func (e *awxExporter) naturalKey(kind string, id int64) (map[string]any, error) {
+	cacheKey := fmt.Sprintf("%s/%d", kind, id)
+	if key, ok := e.keys[cacheKey]; ok {
+		return key, nil
+	}
+
+	keyType, ok := awxNaturalKeyTypes[kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %s", kind)
+	}
+	object, err := e.client.GetRawObject(keyType.collection, id)
+	if err != nil {
+		return nil, err
+	}
+	if object == nil {
+		return nil, fmt.Errorf("%s %d not found", kind, id)
+	}
+	key := map[string]any{"name": object["name"], "type": kind}
+	for field, reference := range keyType.fields {
+		if reference == "" {
+			key[field] = object[field]
+			continue
+		}
+		key[field] = nil
+		if referenceId, ok := rawId(object[field]); ok {
+			if key[field], err = e.naturalKey(reference, referenceId); err != nil {
+				return nil, err
+			}
+		}
+	}
+	e.keys[cacheKey] = key
+	return key, nil
+}
+
+// export returns the objects of the given export type with the given ids, ordered by id.
+func (e *awxExporter) export(exportKey string, ids []int64) ([]map[string]any, error) {
+	ids = append([]int64{}, ids...)
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	var objects []map[string]any
+	for _, id := range ids {
+		exported, err := e.exportObject(exportKey, id)
+		if err != nil {
+			return nil, err
+		}
+		if exported == nil {
+			return nil, fmt.Errorf("%s %d not found", exportKey, id)
+		}
+		objects = append(objects, exported)
+	}
+	return objects, nil
+}
+
+// exportObject returns the object of the given export type with the given id, or nil if it does not exist.
+func (e *awxExporter) exportObject(exportKey string, id int64) (map[string]any, error) {
+	exportType := awxExportTypes[exportKey]
+	object, err := e.client.GetRawObject(exportType.collection, id)
+	if err != nil || object == nil {
+		return nil, err
+	}
+
+	exported := make(map[string]any)
+	for _, field := range exportType.fields {
+		if value, ok := object[field]; ok {
+			exported[field] = value
+		}
+	}
+	for field, kind := range exportType.references {
+		if _, ok := object[field]; !ok {
+			continue
+		}
+		exported[field] = nil
+		if referenceId, ok := rawId(object[field]); ok {
+			if exported[field], err = e.naturalKey(kind, referenceId); err != nil {
+				return nil, err
+			}
+		}
+	}
+	// secrets are never returned by AAP and are left out rather than exported as placeholders
+	if inputs, ok := exported["inputs"].(map[string]any); ok {
+		for name, value := range inputs {
+			if value == awxExportEncrypted {
+				delete(inputs, name)
+			}
+		}
+	}
+
+	related := make(map[string]any)
+	for collection, kind := range exportType.related {
+		relatedObjects, err := e.client.GetRawRelated(exportType.collection, id, collection)
+		if err != nil {
+			return nil, err
+		}
+		keys := make([]map[string]any, 0, len(relatedObjects))
+		for _, relatedObject := range relatedObjects {
+			relatedId, _ := rawId(relatedObject["id"])
+			key, err := e.naturalKey(kind, relatedId)
+			if err != nil {
+				return nil, err
+			}
+			keys = append(keys, key)
+		}
+		related[collection] = keys
+	}
+	exported["related"] = related
+	exported["natural_key"] = awxObjectKey(exportType.kind, exported)
+	return exported, nil
+}
+
+// awxObjectKey returns the natural key of an exported object: its name, type, and organization and credential
+// type when it has them.
+func awxObjectKey(kind string, object map[string]any) map[string]any {
+	key := map[string]any{"name": object["name"], "type": kind}
+	for _, field := range []string{"organization", "credential_type"} {
+		if value, ok := object[field]; ok {
+			key[field] = value
+		}
+	}
+	return key
+}
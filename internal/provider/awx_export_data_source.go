@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource                   = &awxExportDataSource{}
+	_ datasource.DataSourceWithConfigure      = &awxExportDataSource{}
+	_ datasource.DataSourceWithValidateConfig = &awxExportDataSource{}
+)
+
+// NewAWXExportDataSource is a helper function to simplify the provider implementation.
+func NewAWXExportDataSource() datasource.DataSource {
+	return &awxExportDataSource{}
+}
+
+// awxExportDataSource exports controller objects in the format of awx export.
+type awxExportDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *awxExportDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_awx_export"
+}
+
+// Schema defines the schema for the data source.
+func (d *awxExportDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	idSet := func(description string) schema.SetAttribute {
+		return schema.SetAttribute{
+			ElementType: types.Int64Type,
+			Optional:    true,
+			Description: description,
+			Validators: []validator.Set{
+				setvalidator.ValueInt64sAre(idValidators()...),
+			},
+		}
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Exports automation controller objects in the JSON format of awx export, the objects they refer to " +
+			"being given by natural key, e.g. to archive controller content or compare it between Terraform runs. " +
+			"Secret credential inputs are never returned by AAP and are left out of the export.",
+		Attributes: map[string]schema.Attribute{
+			"project_ids":      idSet("Ids of the projects to export."),
+			"job_template_ids": idSet("Ids of the job templates to export, with their credentials, labels and instance groups."),
+			"credential_ids":   idSet("Ids of the credentials to export, without their secret inputs."),
+			"json": schema.StringAttribute{
+				Computed: true,
+				Description: "Export document, listing the objects of each type requested under projects, job_templates " +
+					"and credentials, ordered by id.",
+			},
+		},
+	}
+}
+
+// awxExportDataSourceModel maps the data source schema data.
+type awxExportDataSourceModel struct {
+	ProjectIds     []int64      `tfsdk:"project_ids"`
+	JobTemplateIds []int64      `tfsdk:"job_template_ids"`
+	CredentialIds  []int64      `tfsdk:"credential_ids"`
+	JSON           types.String `tfsdk:"json"`
+}
+
+// ValidateConfig requires at least one type of objects to export.
+func (d *awxExportDataSource) ValidateConfig(ctx context.Context, req datasource.ValidateConfigRequest, resp *datasource.ValidateConfigResponse) {
+	for _, attribute := range []string{"project_ids", "job_template_ids", "credential_ids"} {
+		var ids types.Set
+		resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root(attribute), &ids)...)
+		if resp.Diagnostics.HasError() || !ids.IsNull() {
+			return
+		}
+	}
+	resp.Diagnostics.AddError("Nothing to export", "At least one of project_ids, job_template_ids and credential_ids must be set.")
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *awxExportDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_awx_export", "read", &resp.Diagnostics)
+	var state awxExportDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exporter := newAWXExporter(d.client)
+	document := make(map[string]any)
+	for exportKey, ids := range map[string][]int64{
+		"projects":      state.ProjectIds,
+		"job_templates": state.JobTemplateIds,
+		"credentials":   state.CredentialIds,
+	} {
+		if ids == nil {
+			continue
+		}
+		objects, err := exporter.export(exportKey, ids)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to export "+exportKey, err.Error())
+			return
+		}
+		document[exportKey] = append([]map[string]any{}, objects...)
+	}
+
+	data, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to encode export", err.Error())
+		return
+	}
+	state.JSON = types.StringValue(string(data))
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *awxExportDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
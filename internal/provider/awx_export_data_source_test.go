@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+)
+
+func TestAWXExportDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	machine := mock.addObject("api/v2/credential_types", map[string]any{"name": "Machine", "kind": "ssh"})
+	credential := mock.addObject("api/v2/credentials", map[string]any{
+		"name": "Deploy key", "organization": organization, "credential_type": machine,
+		"inputs": map[string]any{"username": "deploy", "password": "$encrypted$", "ssh_key_data": "$encrypted$"},
+	})
+	project := mock.addObject("api/v2/projects", map[string]any{
+		"name": "Playbooks", "organization": organization, "scm_type": "git", "scm_url": "https://example.com/playbooks.git",
+		"credential": nil, "status": "successful",
+	})
+	label := mock.addObject("api/v2/labels", map[string]any{"name": "production", "organization": organization})
+	template := mock.addObject("api/v2/job_templates", map[string]any{
+		"name": "Deploy", "organization": organization, "project": project, "playbook": "deploy.yml", "inventory": nil,
+	})
+	mock.members[fmt.Sprintf("api/v2/job_templates/%d/credentials", template)] = []int64{credential}
+	mock.members[fmt.Sprintf("api/v2/job_templates/%d/labels", template)] = []int64{label}
+
+	p := newTestProvider(t, mock, nil)
+	state := p.readDataSource("aap_awx_export", map[string]any{
+		"project_ids": []any{project}, "job_template_ids": []any{template}, "credential_ids": []any{credential},
+	})
+	var document map[string][]map[string]any
+	if err := json.Unmarshal([]byte(state["json"].(string)), &document); err != nil {
+		t.Fatalf("json = %v: %s", state["json"], err)
+	}
+	if len(document["projects"]) != 1 || len(document["job_templates"]) != 1 || len(document["credentials"]) != 1 {
+		t.Fatalf("json = %s, expected the requested objects", state["json"])
+	}
+
+	defaultKey := map[string]any{"name": "Default", "type": "organization"}
+	machineKey := map[string]any{"name": "Machine", "kind": "ssh", "type": "credential_type"}
+	credentialKey := map[string]any{"name": "Deploy key", "organization": defaultKey, "credential_type": machineKey, "type": "credential"}
+
+	// secret inputs are left out rather than exported as placeholders
+	testExpect(t, document["credentials"][0], map[string]any{
+		"name": "Deploy key", "organization": defaultKey, "credential_type": machineKey,
+		"inputs":      map[string]any{"username": "deploy"},
+		"natural_key": credentialKey,
+		"related":     map[string]any{},
+	})
+	testExpect(t, document["projects"][0], map[string]any{
+		"name": "Playbooks", "organization": defaultKey, "scm_type": "git", "scm_url": "https://example.com/playbooks.git",
+		"credential":  nil,
+		"natural_key": map[string]any{"name": "Playbooks", "organization": defaultKey, "type": "project"},
+	})
+	if _, ok := document["projects"][0]["status"]; ok {
+		t.Errorf("project = %v, expected only the exported fields", document["projects"][0])
+	}
+	testExpect(t, document["job_templates"][0], map[string]any{
+		"name": "Deploy", "playbook": "deploy.yml", "inventory": nil,
+		"project": map[string]any{"name": "Playbooks", "organization": defaultKey, "type": "project"},
+		"related": map[string]any{
+			"credentials":     []any{credentialKey},
+			"labels":          []any{map[string]any{"name": "production", "organization": defaultKey, "type": "label"}},
+			"instance_groups": []any{},
+		},
+	})
+
+	// only the requested types are exported
+	state = p.readDataSource("aap_awx_export", map[string]any{"credential_ids": []any{credential}})
+	document = nil
+	if err := json.Unmarshal([]byte(state["json"].(string)), &document); err != nil || len(document) != 1 {
+		t.Errorf("json = %v, expected only credentials", state["json"])
+	}
+
+	if _, errors := p.tryReadDataSource("aap_awx_export", map[string]any{"project_ids": []any{project + 100}}); errors == "" {
+		t.Error("exporting an unknown project did not fail")
+	}
+	if _, errors := p.tryReadDataSource("aap_awx_export", map[string]any{}); errors == "" {
+		t.Error("exporting nothing did not fail")
+	}
+}
@@ -0,0 +1,318 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strings"
+)
+
+// awxImportOrder is the order objects are imported in, so that the objects others refer to exist first.
+var awxImportOrder = []string{"credentials", "projects", "job_templates"}
+
+// awxImportDocument is a parsed import document: the objects to import by export type.
+type awxImportDocument map[string][]map[string]any
+
+// parseAWXImportDocument parses a document in the JSON format of awx export, with objects of the types the
+// provider exports. Every object needs a name; the objects they refer to are given by natural key.
+func parseAWXImportDocument(document string) (awxImportDocument, error) {
+	var raw map[string]any
+	if err := json.Unmarshal([]byte(document), &raw); err != nil {
+		return nil, fmt.Errorf("the document must be a JSON object: %w", err)
+	}
+
+	parsed := make(awxImportDocument)
+	for _, exportKey := range sortedKeys(raw) {
+		if _, ok := awxExportTypes[exportKey]; !ok {
+			if emptySpecValue(raw[exportKey]) {
+				continue
+			}
+			return nil, fmt.Errorf("%s cannot be imported, expected %s", exportKey, strings.Join(awxImportOrder, ", "))
+		}
+		items, ok := raw[exportKey].([]any)
+		if !ok {
+			return nil, fmt.Errorf("%s must be a list", exportKey)
+		}
+		names := make(map[string]bool)
+		for i, item := range items {
+			object, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("%s[%d] is a %T, expected an object", exportKey, i, item)
+			}
+			name, ok := object["name"].(string)
+			if !ok || name == "" {
+				return nil, fmt.Errorf("%s[%d]: name must be a non-empty string", exportKey, i)
+			}
+			key := awxImportKey(exportKey, object)
+			if names[key] {
+				return nil, fmt.Errorf("%s[%d]: %s is imported several times", exportKey, i, key)
+			}
+			names[key] = true
+			parsed[exportKey] = append(parsed[exportKey], object)
+		}
+	}
+	return parsed, nil
+}
+
+// awxImportKey identifies an imported object in object_ids, e.g. job_templates/Default/Deploy.
+func awxImportKey(exportKey string, object map[string]any) string {
+	return exportKey + "/" + naturalKeyName(object["organization"]) + "/" + naturalKeyName(object["name"])
+}
+
+// awxImporter creates or updates the objects of an import document, looking up the objects they refer to by natural key.
+type awxImporter struct {
+	client *AAPClient
+	ids    map[string]int64
+}
+
+func newAWXImporter(client *AAPClient) *awxImporter {
+	return &awxImporter{client: client, ids: make(map[string]int64)}
+}
+
+// lookup returns the id of the object of the given type with the natural key, or nil if there is none.
+// A null organization in the key matches objects without one; a missing organization matches any.
+func (i *awxImporter) lookup(kind string, key map[string]any) (*int64, error) {
+	keyType, ok := awxNaturalKeyTypes[kind]
+	if !ok {
+		return nil, fmt.Errorf("unsupported type %s", kind)
+	}
+	name := naturalKeyName(key)
+	cacheKey := kind + "/" + name
+	query := url.Values{"name": {name}}
+	for field, reference := range keyType.fields {
+		value, ok := key[field]
+		switch {
+		case !ok:
+		case reference == "":
+			if s, ok := value.(string); ok {
+				query.Set(field, s)
+			}
+		case value == nil:
+			query.Set(field+"__isnull", "true")
+		default:
+			query.Set(field+"__name", naturalKeyName(value))
+		}
+		cacheKey += "/" + query.Get(field) + query.Get(field+"__isnull") + query.Get(field+"__name")
+	}
+	if id, ok := i.ids[cacheKey]; ok {
+		return &id, nil
+	}
+
+	object, err := findByQuery(i.client, keyType.collection, query, func(o AAPObjectRef) bool { return o.Name == name })
+	if err != nil || object == nil {
+		return nil, err
+	}
+	i.ids[cacheKey] = object.Id
+	return &object.Id, nil
+}
+
+// reference returns the id of the object of the given type a natural key refers to, nil for a null key.
+func (i *awxImporter) reference(kind string, value any) (*int64, error) {
+	if value == nil {
+		return nil, nil
+	}
+	key, ok := value.(map[string]any)
+	if !ok {
+		if name, isName := value.(string); isName {
+			key = map[string]any{"name": name}
+		} else {
+			return nil, fmt.Errorf("expected the natural key of a %s, got a %T", kind, value)
+		}
+	}
+	id, err := i.lookup(kind, key)
+	if err != nil {
+		return nil, err
+	}
+	if id == nil {
+		return nil, fmt.Errorf("%s %q not found", strings.ReplaceAll(kind, "_", " "), naturalKeyName(key))
+	}
+	return id, nil
+}
+
+// importObject creates the object of the given export type, or updates the one with the same natural key, and
+// returns its id.
+func (i *awxImporter) importObject(ctx context.Context, exportKey string, object map[string]any) (int64, error) {
+	exportType := awxExportTypes[exportKey]
+	fields := make(map[string]any)
+	for _, field := range exportType.fields {
+		if value, ok := object[field]; ok {
+			fields[field] = value
+		}
+	}
+	// placeholders of secrets keep the secret already set
+	if inputs, ok := fields["inputs"].(map[string]any); ok {
+		kept := make(map[string]any, len(inputs))
+		for name, value := range inputs {
+			if value != awxExportEncrypted {
+				kept[name] = value
+			}
+		}
+		fields["inputs"] = kept
+	}
+	for field, kind := range exportType.references {
+		value, ok := object[field]
+		if !ok {
+			continue
+		}
+		id, err := i.reference(kind, value)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", field, err)
+		}
+		fields[field] = id
+	}
+
+	existing, err := i.lookup(exportType.kind, awxObjectKey(exportType.kind, object))
+	if err != nil {
+		return 0, err
+	}
+	var id int64
+	if existing == nil {
+		created, err := i.client.CreateRawObject(exportType.collection, fields)
+		if err != nil {
+			return 0, err
+		}
+		id, _ = rawId(created["id"])
+	} else {
+		id = *existing
+		if _, err := i.client.UpdateRawObject(exportType.collection, id, fields); err != nil {
+			return 0, err
+		}
+	}
+
+	// job templates can only refer to playbooks of projects whose repository was updated
+	if exportKey == "projects" {
+		if err := i.client.WaitForProjectUpdate(ctx, id); err != nil {
+			return 0, err
+		}
+	}
+
+	related, _ := object["related"].(map[string]any)
+	for _, collection := range sortedKeys(related) {
+		kind, ok := exportType.related[collection]
+		if !ok {
+			if emptySpecValue(related[collection]) {
+				continue
+			}
+			return 0, fmt.Errorf("related %s cannot be imported", collection)
+		}
+		keys, ok := related[collection].([]any)
+		if !ok {
+			return 0, fmt.Errorf("related %s must be a list", collection)
+		}
+		relatedIds := make([]int64, len(keys))
+		for k, key := range keys {
+			relatedId, err := i.relatedReference(kind, key, fields["organization"])
+			if err != nil {
+				return 0, fmt.Errorf("related %s: %w", collection, err)
+			}
+			relatedIds[k] = relatedId
+		}
+		if err := i.client.setOrdered(objectEndpoint(exportType.collection, id, collection), relatedIds); err != nil {
+			return 0, err
+		}
+	}
+	return id, nil
+}
+
+// relatedReference returns the id of a related object, creating labels that do not exist yet in the organization
+// of the object they are related to.
+func (i *awxImporter) relatedReference(kind string, key any, organization any) (int64, error) {
+	if kind == "label" {
+		labelKey, _ := key.(map[string]any)
+		id, err := i.lookup(kind, labelKey)
+		if err != nil {
+			return 0, err
+		}
+		if id != nil {
+			return *id, nil
+		}
+		if organization == nil {
+			return 0, fmt.Errorf("label %q not found", naturalKeyName(key))
+		}
+		created, err := i.client.CreateRawObject("api/v2/labels/", map[string]any{"name": naturalKeyName(key), "organization": organization})
+		if err != nil {
+			return 0, err
+		}
+		labelId, _ := rawId(created["id"])
+		return labelId, nil
+	}
+
+	id, err := i.reference(kind, key)
+	if err != nil {
+		return 0, err
+	}
+	if id == nil {
+		return 0, fmt.Errorf("expected the natural key of a %s, got null", kind)
+	}
+	return *id, nil
+}
+
+// importDocument imports the objects of the document and returns their ids by import key.
+func (i *awxImporter) importDocument(ctx context.Context, document awxImportDocument) (map[string]int64, error) {
+	ids := make(map[string]int64)
+	for _, exportKey := range awxImportOrder {
+		for _, object := range document[exportKey] {
+			key := awxImportKey(exportKey, object)
+			id, err := i.importObject(ctx, exportKey, object)
+			if err != nil {
+				return ids, fmt.Errorf("%s: %w", key, err)
+			}
+			ids[key] = id
+		}
+	}
+	return ids, nil
+}
+
+// awxImportDrifted reports whether the exported object differs from the imported one in any of the fields the
+// import document sets. References are compared by name, and related objects by the names of the set.
+func awxImportDrifted(imported map[string]any, exported map[string]any, exportType awxExportType) bool {
+	for field, value := range imported {
+		switch {
+		case field == "natural_key" || field == "related":
+		case exportType.references[field] != "":
+			if naturalKeyName(value) != naturalKeyName(exported[field]) {
+				return true
+			}
+		case field == "inputs":
+			inputs, _ := value.(map[string]any)
+			current, _ := exported[field].(map[string]any)
+			// secrets are left out of exports, and cannot be compared
+			for name, input := range inputs {
+				if value, ok := current[name]; ok && !reflect.DeepEqual(input, value) {
+					return true
+				}
+			}
+		default:
+			if !reflect.DeepEqual(value, exported[field]) {
+				return true
+			}
+		}
+	}
+
+	related, _ := imported["related"].(map[string]any)
+	current, _ := exported["related"].(map[string]any)
+	for collection, keys := range related {
+		if !reflect.DeepEqual(naturalKeyNames(keys), naturalKeyNames(current[collection])) {
+			return true
+		}
+	}
+	return false
+}
+
+// naturalKeyNames returns the set of names of a list of natural keys.
+func naturalKeyNames(value any) map[string]bool {
+	names := make(map[string]bool)
+	switch keys := value.(type) {
+	case []any:
+		for _, key := range keys {
+			names[naturalKeyName(key)] = true
+		}
+	case []map[string]any:
+		for _, key := range keys {
+			names[naturalKeyName(key)] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &awxImportResource{}
+	_ resource.ResourceWithConfigure      = &awxImportResource{}
+	_ resource.ResourceWithValidateConfig = &awxImportResource{}
+	_ resource.ResourceWithModifyPlan     = &awxImportResource{}
+)
+
+// NewAWXImportResource is a helper function to simplify the provider implementation.
+func NewAWXImportResource() resource.Resource {
+	return &awxImportResource{}
+}
+
+// awxImportResource applies a document in the format of awx export to the controller.
+type awxImportResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *awxImportResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_awx_import"
+}
+
+// Schema defines the schema for the resource.
+func (r *awxImportResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Applies a JSON document in the format of awx export to automation controller, creating the objects it lists " +
+			"or updating the existing ones with the same natural key, e.g. to migrate a large set of objects before " +
+			"managing them with dedicated resources. Objects removed from the document, or when the resource is destroyed, " +
+			"are left in AAP.",
+		Attributes: map[string]schema.Attribute{
+			"document": schema.StringAttribute{
+				Required: true,
+				Description: "Import document, as returned by aap_awx_export or awx export: the objects to import listed under " +
+					"credentials, projects and job_templates, imported in that order. The objects they refer to, and the credentials, " +
+					"labels and instance_groups related to job templates, are given by natural key and must exist, except for labels " +
+					"which are created in the organization of the job template. Secret credential inputs left out or set to " +
+					"$encrypted$ keep their current value. Changes made outside of Terraform are detected on the fields the document sets.",
+			},
+			"object_ids": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Ids of the imported objects by type, organization and name, e.g. job_templates/Default/Deploy.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// awxImportResourceModel maps the resource schema data.
+type awxImportResourceModel struct {
+	Document  types.String `tfsdk:"document"`
+	ObjectIds types.Map    `tfsdk:"object_ids"`
+}
+
+// setObjectIds sets the ids of the imported objects by import key.
+func (m *awxImportResourceModel) setObjectIds(ctx context.Context, ids map[string]int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.ObjectIds, diags = types.MapValueFrom(ctx, types.Int64Type, ids)
+	return diags
+}
+
+// ValidateConfig ensures the document can be parsed.
+func (r *awxImportResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var document types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("document"), &document)...)
+	if resp.Diagnostics.HasError() || document.IsNull() || document.IsUnknown() {
+		return
+	}
+
+	if _, err := parseAWXImportDocument(document.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("document"), "Invalid import document", err.Error())
+	}
+}
+
+// ModifyPlan plans new object ids when the document changes, as objects may be added; the ids are kept otherwise.
+func (r *awxImportResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state awxImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.Document.Equal(state.Document) {
+		return
+	}
+
+	plan.ObjectIds = types.MapUnknown(types.Int64Type)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// apply imports the objects of the planned document.
+func (r *awxImportResource) apply(ctx context.Context, plan *awxImportResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	document, err := parseAWXImportDocument(plan.Document.ValueString())
+	if err != nil {
+		diags.AddError("Unable to import objects", err.Error())
+		return diags
+	}
+	ids, err := newAWXImporter(r.client).importDocument(ctx, document)
+	if err != nil {
+		diags.AddError("Unable to import objects", err.Error())
+		return diags
+	}
+	return plan.setObjectIds(ctx, ids)
+}
+
+// Create imports the objects of the document.
+func (r *awxImportResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_awx_import", "create", &resp.Diagnostics)
+	var plan awxImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the imported objects. The document is kept as written unless an object was removed or changed
+// in one of the fields the document sets, in which case it is replaced by the current objects so that the
+// difference shows in the plan.
+func (r *awxImportResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_awx_import", "read", &resp.Diagnostics)
+	var state awxImportResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	document, err := parseAWXImportDocument(state.Document.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import document in state", err.Error())
+		return
+	}
+
+	stateIds := make(map[string]int64)
+	resp.Diagnostics.Append(state.ObjectIds.ElementsAs(ctx, &stateIds, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	exporter := newAWXExporter(r.client)
+	current := make(map[string][]map[string]any)
+	ids := make(map[string]int64)
+	drifted := false
+	for _, exportKey := range awxImportOrder {
+		for _, object := range document[exportKey] {
+			key := awxImportKey(exportKey, object)
+			id, ok := stateIds[key]
+			if !ok {
+				drifted = true
+				continue
+			}
+			exported, err := exporter.exportObject(exportKey, id)
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to read "+key, err.Error())
+				return
+			}
+			if exported == nil {
+				drifted = true
+				continue
+			}
+			ids[key] = id
+			if awxImportDrifted(object, exported, awxExportTypes[exportKey]) {
+				drifted = true
+				object = exported
+			}
+			current[exportKey] = append(current[exportKey], object)
+		}
+	}
+	resp.Diagnostics.Append(state.setObjectIds(ctx, ids)...)
+	if drifted {
+		rendered, err := json.MarshalIndent(current, "", "  ")
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to encode import document", err.Error())
+			return
+		}
+		state.Document = types.StringValue(string(rendered))
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update imports the objects of the document again.
+func (r *awxImportResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_awx_import", "update", &resp.Diagnostics)
+	var plan awxImportResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.apply(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete only removes the resource from the Terraform state: the imported objects are left in AAP.
+func (r *awxImportResource) Delete(_ context.Context, _ resource.DeleteRequest, resp *resource.DeleteResponse) {
+	resp.Diagnostics.AddWarning(
+		"Imported objects left in AAP",
+		"The objects imported by aap_awx_import are not deleted with the resource and must be removed separately if no longer needed.",
+	)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *awxImportResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
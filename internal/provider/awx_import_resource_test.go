@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// testAWXImportDocument imports a credential, a project and a job template using both, in the Default organization.
+const testAWXImportDocument = `{
+  "credentials": [{
+    "name": "Deploy key",
+    "organization": {"name": "Default", "type": "organization"},
+    "credential_type": {"name": "Machine", "kind": "ssh", "type": "credential_type"},
+    "inputs": {"username": "deploy", "password": "$encrypted$"}
+  }],
+  "projects": [{
+    "name": "Playbooks",
+    "organization": {"name": "Default", "type": "organization"},
+    "scm_type": "git",
+    "scm_url": "https://example.com/playbooks.git"
+  }],
+  "job_templates": [{
+    "name": "Deploy",
+    "organization": {"name": "Default", "type": "organization"},
+    "project": {"name": "Playbooks", "organization": {"name": "Default", "type": "organization"}, "type": "project"},
+    "playbook": "deploy.yml",
+    "related": {
+      "credentials": [{"name": "Deploy key", "organization": {"name": "Default"}, "credential_type": {"name": "Machine", "kind": "ssh"}}],
+      "labels": [{"name": "production", "organization": {"name": "Default"}}]
+    }
+  }]
+}`
+
+func TestAWXImportResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	machine := mock.addObject("api/v2/credential_types", map[string]any{"name": "Machine", "kind": "ssh"})
+	existing := mock.addObject("api/v2/projects", map[string]any{"name": "Playbooks", "organization": organization, "scm_type": "git", "scm_url": "https://example.com/old.git"})
+
+	p := newTestProvider(t, mock, nil)
+	imports := p.resource("aap_awx_import")
+	state := imports.apply(map[string]any{"document": testAWXImportDocument})
+
+	ids, _ := state["object_ids"].(map[string]any)
+	if len(ids) != 3 || ids["projects/Default/Playbooks"] != existing {
+		t.Fatalf("object_ids = %v, expected the existing project to be updated", state["object_ids"])
+	}
+	credential := mock.object("api/v2/credentials", ids["credentials/Default/Deploy key"].(int64))
+	if credential == nil || mockId(credential["credential_type"]) != machine {
+		t.Errorf("credential = %v", credential)
+	}
+	if inputs, _ := credential["inputs"].(map[string]any); inputs["username"] != "deploy" || inputs["password"] != nil {
+		t.Errorf("credential inputs = %v, expected the encrypted placeholder to be left out", inputs)
+	}
+	if project := mock.object("api/v2/projects", existing); project["scm_url"] != "https://example.com/playbooks.git" {
+		t.Errorf("project = %v", project)
+	}
+	template := ids["job_templates/Default/Deploy"].(int64)
+	if credentials := mock.related("api/v2/job_templates", template, "credentials"); !slices.Equal(credentials, []int64{credential["id"].(int64)}) {
+		t.Errorf("job template credentials = %v", credentials)
+	}
+	if labels := mock.related("api/v2/job_templates", template, "labels"); len(labels) != 1 {
+		t.Errorf("job template labels = %v, expected the production label to be created", labels)
+	}
+
+	imports.read()
+	testExpect(t, imports.State(), map[string]any{"document": testAWXImportDocument, "object_ids": ids})
+	if changes := imports.planChanges(map[string]any{"document": testAWXImportDocument}); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	// changes made outside of Terraform show as a new document
+	mock.objects["api/v2/job_templates"][template]["playbook"] = "other.yml"
+	imports.read()
+	if document, _ := imports.State()["document"].(string); !strings.Contains(document, "other.yml") {
+		t.Errorf("document after drift = %s", document)
+	}
+	imports.apply(map[string]any{"document": testAWXImportDocument})
+	if playbook := mock.object("api/v2/job_templates", template)["playbook"]; playbook != "deploy.yml" {
+		t.Errorf("playbook = %v after applying the document again", playbook)
+	}
+
+	imports.destroy()
+	if len(imports.warnings) != 1 || mock.object("api/v2/job_templates", template) == nil {
+		t.Errorf("destroy warned %v and should leave the imported objects", imports.warnings)
+	}
+}
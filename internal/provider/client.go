@@ -1,32 +1,184 @@
 package provider
 
 import (
+	"bytes"
+	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"math/rand"
 	"net/http"
+	"net/url"
+	"slices"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
+// defaultParallelism is the number of concurrent requests sent when reading many related objects.
+const defaultParallelism = 4
+
+// defaultPollInterval is the delay between two reads of a running task.
+const defaultPollInterval = 2 * time.Second
+
+// pollJitter is the fraction of the poll interval randomly added or removed from each delay,
+// so that concurrent applies do not poll the API in lockstep.
+const pollJitter = 0.2
+
 // Client -
 type AAPClient struct {
-	HostURL            string
-	Username           *string
-	Password           *string
+	HostURL  string
+	Username *string
+	Password *string
+	// Token authenticates the requests instead of the username and password when set.
+	Token              *string
 	InsecureSkipVerify bool
+	Parallelism        int
+	PollInterval       time.Duration
+	// RootCAs verifies the TLS certificates of AAP and of the remote state sources instead of the system certificate pool when set.
+	RootCAs *x509.CertPool
+	// Proxy is the proxy requests, including those to remote state sources, are sent through, instead of the one set in the environment when set.
+	Proxy *url.URL
+	// CheckExistingNames makes resources look for an object with the same name before creating one.
+	CheckExistingNames bool
+	// ReportAPIUsage makes resources report their number of requests as a warning, not only in the logs.
+	ReportAPIUsage bool
+
+	// stats counts the requests of the client, set on the copies made by withStats
+	stats *apiStats
+	// transport holds the HTTP client shared by the requests, and by the copies made by withStats
+	transport *sharedTransport
+}
+
+// sharedTransport is the HTTP client of an AAPClient, built on the first request so that it keeps its
+// connections open across requests and honors the RootCAs and Proxy set after NewClient.
+type sharedTransport struct {
+	once   sync.Once
+	client *http.Client
 }
 
 // ansible host
 type AnsibleHost struct {
-	Name      string            `json:"name"`
-	Groups    []string          `json:"groups"`
-	Variables map[string]string `json:"variables"`
+	Name      string                 `json:"name"`
+	Groups    []string               `json:"groups"`
+	Variables map[string]interface{} `json:"variables"`
+}
+
+// ansible group
+type AnsibleGroup struct {
+	Name      string                 `json:"name"`
+	Children  []string               `json:"children"`
+	Variables map[string]interface{} `json:"variables"`
 }
 
-// ansible host list
+// ansible hosts and groups
 type AnsibleHostList struct {
-	Hosts []AnsibleHost `json:"hosts"`
+	Hosts  []AnsibleHost  `json:"hosts"`
+	Groups []AnsibleGroup `json:"groups"`
+}
+
+// AAP inventory
+type AAPInventory struct {
+	Id                      int64  `json:"id,omitempty"`
+	Name                    string `json:"name"`
+	Organization            int64  `json:"organization"`
+	Description             string `json:"description"`
+	Variables               string `json:"variables"`
+	TotalHosts              int64  `json:"total_hosts,omitempty"`
+	TotalGroups             int64  `json:"total_groups,omitempty"`
+	HostsWithActiveFailures int64  `json:"hosts_with_active_failures,omitempty"`
+	HasInventorySources     bool   `json:"has_inventory_sources,omitempty"`
+	Created                 string `json:"created,omitempty"`
+	Modified                string `json:"modified,omitempty"`
+
+	SummaryFields *aapAuditSummaryFields `json:"summary_fields,omitempty"`
+}
+
+// users AAP summarizes in object responses
+type aapAuditSummaryFields struct {
+	CreatedBy *AAPUserSummary `json:"created_by,omitempty"`
+}
+
+// AAP user as summarized in related objects
+type AAPUserSummary struct {
+	Id       int64  `json:"id"`
+	Username string `json:"username"`
+}
+
+// createdBy returns the username of the user who created the object, empty when AAP does not report one.
+func (f *aapAuditSummaryFields) createdBy() string {
+	if f == nil || f.CreatedBy == nil {
+		return ""
+	}
+	return f.CreatedBy.Username
+}
+
+// AAP inventory source
+type AAPInventorySource struct {
+	Id               int64   `json:"id"`
+	Name             string  `json:"name"`
+	Source           string  `json:"source"`
+	Status           string  `json:"status"`
+	LastUpdated      *string `json:"last_updated"`
+	LastUpdateFailed bool    `json:"last_update_failed"`
+	LastJobRun       *string `json:"last_job_run"`
+	LastJobFailed    bool    `json:"last_job_failed"`
+}
+
+// occurrences of a schedule rule previewed by AAP
+type AAPSchedulePreview struct {
+	Local []string `json:"local"`
+	UTC   []string `json:"utc"`
+}
+
+// AAP host
+type AAPHost struct {
+	Id            int64                 `json:"id,omitempty"`
+	Name          string                `json:"name"`
+	Inventory     int64                 `json:"inventory"`
+	Description   string                `json:"description"`
+	Variables     string                `json:"variables"`
+	SummaryFields *aapHostSummaryFields `json:"summary_fields,omitempty"`
+}
+
+// related objects AAP summarizes in host responses
+type aapHostSummaryFields struct {
+	Groups aapSummaryList `json:"groups"`
+}
+
+// summary of related objects, AAP only includes the first few results
+type aapSummaryList struct {
+	Count   int64      `json:"count"`
+	Results []AAPGroup `json:"results"`
+}
+
+// complete returns whether the summary lists every related object.
+func (l aapSummaryList) complete() bool {
+	return int64(len(l.Results)) >= l.Count
+}
+
+// AAP group
+type AAPGroup struct {
+	Id          int64  `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Inventory   int64  `json:"inventory"`
+	Description string `json:"description"`
+	Variables   string `json:"variables"`
+}
+
+// listPageSize is the page size requested from list endpoints returning many objects.
+const listPageSize = 200
+
+// page of results returned by AAP list endpoints
+type aapListResponse[T any] struct {
+	Count   int64   `json:"count"`
+	Next    *string `json:"next"`
+	Results []T     `json:"results"`
 }
 
 // NewClient -
@@ -36,39 +188,365 @@ func NewClient(host string, username *string, password *string, insecure_skip_ve
 		Username:           username,
 		Password:           password,
 		InsecureSkipVerify: insecure_skip_verify,
+		transport:          &sharedTransport{},
 	}
 
 	return &client, nil
 }
 
-func (c *AAPClient) GetHosts(stateId string) (*AnsibleHostList, error) {
+// NewCertPool returns the system certificate pool with the PEM encoded certificates added, e.g. of an internal CA.
+func NewCertPool(pemCerts []byte) (*x509.CertPool, error) {
+	pool, err := x509.SystemCertPool()
+	if err != nil {
+		pool = x509.NewCertPool()
+	}
+	if !pool.AppendCertsFromPEM(pemCerts) {
+		return nil, fmt.Errorf("no PEM encoded certificate found")
+	}
+	return pool, nil
+}
+
+// parallelism returns the number of requests that may be in flight at once.
+func (c *AAPClient) parallelism() int {
+	if c.Parallelism < 1 {
+		return defaultParallelism
+	}
+	return c.Parallelism
+}
+
+// pollDelay returns how long to wait before polling a running task again.
+func (c *AAPClient) pollDelay() time.Duration {
+	interval := c.PollInterval
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	jitter := (rand.Float64()*2 - 1) * pollJitter
+	return time.Duration(float64(interval) * (1 + jitter))
+}
+
+// MakeRequest sends a request to the AAP API and returns the response along with its body.
+// The endpoint is relative to the API host, e.g. "api/v2/inventories/".
+func (c *AAPClient) MakeRequest(method string, endpoint string, body io.Reader) (*http.Response, []byte, error) {
+	resp, err := c.send(method, endpoint, body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	defer resp.Body.Close()
 
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return resp, respBody, nil
+}
+
+// send sends a request to the AAP API and returns the response, whose body must be closed by the caller.
+func (c *AAPClient) send(method string, endpoint string, body io.Reader) (*http.Response, error) {
 	hostURL := c.HostURL
 	if !strings.HasSuffix(hostURL, "/") {
 		hostURL = hostURL + "/"
 	}
 
-	req, _ := http.NewRequest("GET", hostURL+"api/v2/state/"+stateId+"/", nil)
-	if c.Username != nil && c.Password != nil {
+	req, err := http.NewRequest(method, hostURL+strings.TrimPrefix(endpoint, "/"), body)
+	if err != nil {
+		return nil, err
+	}
+	if c.Token != nil {
+		req.Header.Set("Authorization", "Bearer "+*c.Token)
+	} else if c.Username != nil && c.Password != nil {
 		req.SetBasicAuth(*c.Username, *c.Password)
 	}
 
 	req.Header.Set("Accept", "application/json")
 	req.Header.Set("Content-Type", "application/json")
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: c.InsecureSkipVerify},
+	client := c.httpClient()
+	if c.stats == nil {
+		return client.Do(req)
 	}
-	client := &http.Client{Transport: tr}
+	start := time.Now()
 	resp, err := client.Do(req)
+	c.stats.record(time.Since(start))
+	return resp, err
+}
+
+// httpClient returns the HTTP client requests are sent with, building it on first use.
+// Clients not made by NewClient get a new one for each request.
+func (c *AAPClient) httpClient() *http.Client {
+	build := func() *http.Client {
+		return c.newHTTPClient(c.InsecureSkipVerify)
+	}
+	if c.transport == nil {
+		return build()
+	}
+	c.transport.once.Do(func() { c.transport.client = build() })
+	return c.transport.client
+}
+
+// externalHTTPClient returns an HTTP client for hosts other than AAP, e.g. the remote Terraform state
+// sources. It honors RootCAs and Proxy, but always verifies TLS certificates: InsecureSkipVerify only
+// applies to AAP, and credentials such as TFE tokens must not be sent over unverified connections.
+func (c *AAPClient) externalHTTPClient() *http.Client {
+	return c.newHTTPClient(false)
+}
+
+// newHTTPClient builds an HTTP client with the RootCAs and Proxy of the client.
+func (c *AAPClient) newHTTPClient(insecureSkipVerify bool) *http.Client {
+	tr := &http.Transport{
+		Proxy:           http.ProxyFromEnvironment,
+		TLSClientConfig: &tls.Config{InsecureSkipVerify: insecureSkipVerify, RootCAs: c.RootCAs},
+	}
+	if c.Proxy != nil {
+		tr.Proxy = http.ProxyURL(c.Proxy)
+	}
+	return &http.Client{Transport: tr}
+}
+
+// doJSON sends the JSON encoding of in (when not nil) and decodes the response into out (when not nil).
+// Any status code not listed in expected is returned as an error. The response is decoded as it is
+// received, so that large responses are never held in memory twice.
+func (c *AAPClient) doJSON(method string, endpoint string, in any, out any, expected ...int) (int, error) {
+	var reqBody io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return 0, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
 
+	resp, err := c.send(method, endpoint, reqBody)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if !slices.Contains(expected, resp.StatusCode) {
+		body, _ := io.ReadAll(resp.Body)
+		err := fmt.Errorf("%s %s returned status: %d, body: %s", method, endpoint, resp.StatusCode, body)
+		if resp.StatusCode == http.StatusBadRequest {
+			if fields := parseFieldErrors(body); len(fields) > 0 {
+				return resp.StatusCode, &ValidationError{Fields: fields, err: err}
+			}
+		}
+		return resp.StatusCode, err
+	}
+
+	if out != nil {
+		// an empty body, e.g. of a 204 response, leaves out untouched
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+			return resp.StatusCode, fmt.Errorf("%s %s returned an invalid body: %w", method, endpoint, err)
+		}
+	}
+	return resp.StatusCode, nil
+}
+
+// ValidationError is returned when AAP rejects a request body, with the messages for each invalid field.
+type ValidationError struct {
+	Fields map[string][]string
+	err    error
+}
+
+func (e *ValidationError) Error() string {
+	return e.err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
+
+// parseFieldErrors decodes a validation error body such as {"name": ["This field is required."]}.
+// Messages of nested fields, e.g. credential inputs, are prefixed with their key.
+func parseFieldErrors(body []byte) map[string][]string {
+	var raw map[string]interface{}
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil
+	}
+
+	fields := make(map[string][]string)
+	for field, value := range raw {
+		if messages := fieldMessages(value); len(messages) > 0 {
+			fields[field] = messages
+		}
+	}
+	return fields
+}
+
+func fieldMessages(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		var messages []string
+		for _, item := range v {
+			messages = append(messages, fieldMessages(item)...)
+		}
+		return messages
+	case map[string]interface{}:
+		var messages []string
+		for _, key := range sortedKeys(v) {
+			for _, message := range fieldMessages(v[key]) {
+				messages = append(messages, key+": "+message)
+			}
+		}
+		return messages
+	}
+	return nil
+}
+
+// forEach calls fn with every result of an AAP list endpoint, following the pagination links.
+// Only one page of results is held in memory at a time.
+func forEach[T any](c *AAPClient, endpoint string, fn func(T) error) error {
+	next := endpoint
+	for next != "" {
+		var page aapListResponse[T]
+		if _, err := c.doJSON(http.MethodGet, next, nil, &page, http.StatusOK); err != nil {
+			return err
+		}
+		for _, result := range page.Results {
+			if err := fn(result); err != nil {
+				return err
+			}
+		}
+		next = ""
+		if page.Next != nil {
+			next = *page.Next
+		}
+	}
+	return nil
+}
+
+// listAll follows the pagination links of an AAP list endpoint and returns every result.
+func listAll[T any](c *AAPClient, endpoint string) ([]T, error) {
+	var results []T
+	err := forEach(c, endpoint, func(result T) error {
+		results = append(results, result)
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
+	return results, nil
+}
 
-	defer resp.Body.Close()
+// errListLimit stops following the pagination links once enough results were collected.
+var errListLimit = errors.New("list limit reached")
 
-	body, err := io.ReadAll(resp.Body)
+// listUpTo follows the pagination links of an AAP list endpoint and returns at most limit results, every result when limit is 0.
+func listUpTo[T any](c *AAPClient, endpoint string, limit int) ([]T, error) {
+	var results []T
+	err := forEach(c, endpoint, func(result T) error {
+		results = append(results, result)
+		if len(results) == limit {
+			return errListLimit
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errListLimit) {
+		return nil, err
+	}
+	return results, nil
+}
+
+// getObject decodes the object at endpoint into a new T, or returns nil if it does not exist.
+func getObject[T any](c *AAPClient, endpoint string) (*T, error) {
+	var object T
+	status, err := c.doJSON(http.MethodGet, endpoint, nil, &object, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	return &object, nil
+}
+
+// createObject posts the object to the collection at endpoint and returns the created object.
+func createObject[T any](c *AAPClient, endpoint string, object T) (*T, error) {
+	var created T
+	if _, err := c.doJSON(http.MethodPost, endpoint, object, &created, http.StatusCreated, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// updateObject sends the object to endpoint with the given method (PUT or PATCH) and returns the updated object.
+func updateObject[T any](c *AAPClient, method string, endpoint string, object T) (*T, error) {
+	var updated T
+	if _, err := c.doJSON(method, endpoint, object, &updated, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// buildEndpoint returns the endpoint made of base, which ends with a slash, followed by the path segments
+// and the query. Segments are escaped, so that names may contain slashes or spaces, and each one is
+// followed by a slash as AAP expects.
+func buildEndpoint(base string, segments []string, query url.Values) string {
+	endpoint := url.URL{Path: base, RawPath: base}
+	for _, segment := range segments {
+		endpoint.Path += segment + "/"
+		endpoint.RawPath += url.PathEscape(segment) + "/"
+	}
+	endpoint.RawQuery = query.Encode()
+	return endpoint.String()
+}
+
+// objectEndpoint returns the endpoint of the object with the given id in the collection at endpoint,
+// followed by the path of a related collection if any, e.g. objectEndpoint("api/v2/groups/", 4, "children").
+func objectEndpoint(collection string, id int64, related ...string) string {
+	return buildEndpoint(collection, append([]string{strconv.FormatInt(id, 10)}, related...), nil)
+}
+
+// pageQuery returns the query of list endpoints returning many objects.
+func pageQuery() url.Values {
+	return url.Values{"page_size": {strconv.Itoa(listPageSize)}}
+}
+
+// deleteObject deletes the object at endpoint, ignoring objects that are already gone.
+func (c *AAPClient) deleteObject(endpoint string) error {
+	_, err := c.doJSON(http.MethodDelete, endpoint, nil, nil, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound)
+	return err
+}
+
+// state of the controller cluster reported by the ping endpoint
+type AAPPing struct {
+	Instances []AAPPingInstance `json:"instances"`
+}
+
+// AAPPingInstance is a node of the controller cluster.
+type AAPPingInstance struct {
+	Node     string `json:"node"`
+	NodeType string `json:"node_type"`
+	Capacity int64  `json:"capacity"`
+}
+
+// unavailableNodes returns the names of the nodes running jobs that report no capacity, e.g. because they are down.
+func (p AAPPing) unavailableNodes() []string {
+	var nodes []string
+	for _, instance := range p.Instances {
+		if (instance.NodeType == "execution" || instance.NodeType == "hybrid") && instance.Capacity == 0 {
+			nodes = append(nodes, instance.Node)
+		}
+	}
+	sort.Strings(nodes)
+	return nodes
+}
+
+// Ping returns the state of the controller cluster.
+func (c *AAPClient) Ping() (*AAPPing, error) {
+	var ping AAPPing
+	if _, err := c.doJSON(http.MethodGet, "api/v2/ping/", nil, &ping, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &ping, nil
+}
+
+// GetState returns the raw Terraform state document stored under the given id.
+func (c *AAPClient) GetState(stateId int64) ([]byte, error) {
+	resp, body, err := c.MakeRequest(http.MethodGet, objectEndpoint("api/v2/state/", stateId), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -77,48 +555,973 @@ func (c *AAPClient) GetHosts(stateId string) (*AnsibleHostList, error) {
 		return nil, fmt.Errorf("status: %d, body: %s", resp.StatusCode, body)
 	}
 
+	return body, nil
+}
+
+func (c *AAPClient) GetHosts(stateId int64) (*AnsibleHostList, error) {
+	body, err := c.GetState(stateId)
+	if err != nil {
+		return nil, err
+	}
+
 	return GetAnsibleHost(body)
 }
 
+// GetInventory returns the inventory with the given id, or nil if it does not exist.
+func (c *AAPClient) GetInventory(id int64) (*AAPInventory, error) {
+	var inventory AAPInventory
+	status, err := c.doJSON(http.MethodGet, objectEndpoint("api/v2/inventories/", id), nil, &inventory, http.StatusOK, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		return nil, nil
+	}
+	return &inventory, nil
+}
+
+func (c *AAPClient) CreateInventory(inventory AAPInventory) (*AAPInventory, error) {
+	var created AAPInventory
+	if _, err := c.doJSON(http.MethodPost, "api/v2/inventories/", inventory, &created, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *AAPClient) UpdateInventory(id int64, inventory AAPInventory) (*AAPInventory, error) {
+	var updated AAPInventory
+	if _, err := c.doJSON(http.MethodPut, objectEndpoint("api/v2/inventories/", id), inventory, &updated, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteInventory deletes the inventory; AAP removes its hosts and groups asynchronously.
+func (c *AAPClient) DeleteInventory(id int64) error {
+	_, err := c.doJSON(http.MethodDelete, objectEndpoint("api/v2/inventories/", id), nil, nil, http.StatusAccepted, http.StatusNoContent, http.StatusNotFound)
+	return err
+}
+
+// GetInventoryByName returns the inventory with the given name in the named organization, or nil if there is none.
+func (c *AAPClient) GetInventoryByName(organization string, name string) (*AAPInventory, error) {
+	query := url.Values{"name": {name}, "organization__name": {organization}}
+	return findByQuery(c, "api/v2/inventories/", query, func(i AAPInventory) bool { return i.Name == name })
+}
+
+// GetOrganizationInventoryByName returns the inventory with the given name in the organization, or nil if there is none.
+func (c *AAPClient) GetOrganizationInventoryByName(organizationId int64, name string) (*AAPInventory, error) {
+	query := url.Values{"name": {name}, "organization": {strconv.FormatInt(organizationId, 10)}}
+	return findByQuery(c, "api/v2/inventories/", query, func(i AAPInventory) bool { return i.Name == name })
+}
+
+func (c *AAPClient) GetInventoryHosts(inventoryId int64) ([]AAPHost, error) {
+	return listAll[AAPHost](c, buildEndpoint("api/v2/inventories/", []string{strconv.FormatInt(inventoryId, 10), "hosts"}, pageQuery()))
+}
+
+// ForEachInventoryHost calls fn with each host of the inventory as the pages of hosts are received.
+func (c *AAPClient) ForEachInventoryHost(inventoryId int64, fn func(AAPHost) error) error {
+	return forEach(c, buildEndpoint("api/v2/inventories/", []string{strconv.FormatInt(inventoryId, 10), "hosts"}, pageQuery()), fn)
+}
+
+func (c *AAPClient) GetInventoryGroups(inventoryId int64) ([]AAPGroup, error) {
+	return listAll[AAPGroup](c, buildEndpoint("api/v2/inventories/", []string{strconv.FormatInt(inventoryId, 10), "groups"}, pageQuery()))
+}
+
+// PreviewSchedule returns the next occurrences of a schedule rule.
+func (c *AAPClient) PreviewSchedule(rrule string) (*AAPSchedulePreview, error) {
+	var preview AAPSchedulePreview
+	if _, err := c.doJSON(http.MethodPost, "api/v2/schedules/preview/", map[string]string{"rrule": rrule}, &preview, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &preview, nil
+}
+
+// host filter checked by AAP, with the error message AAP returned when it does not parse
+type AAPHostFilterCheck struct {
+	Count  int64  `json:"count"`
+	Detail string `json:"detail"`
+	Error  string `json:"error"`
+}
+
+// CheckHostFilter lists the hosts matching a smart inventory host filter, in the inventories of the organization
+// when organizationId is not nil. AAP rejects filters that do not parse with a message, not with an error status.
+func (c *AAPClient) CheckHostFilter(filter string, organizationId *int64) (*AAPHostFilterCheck, error) {
+	query := url.Values{"host_filter": {filter}, "page_size": {"1"}}
+	if organizationId != nil {
+		query.Set("inventory__organization", strconv.FormatInt(*organizationId, 10))
+	}
+	var check AAPHostFilterCheck
+	status, err := c.doJSON(http.MethodGet, buildEndpoint("api/v2/hosts/", nil, query), nil, &check, http.StatusOK, http.StatusBadRequest)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusBadRequest && check.Detail == "" && check.Error == "" {
+		check.Error = "the host filter is invalid"
+	}
+	return &check, nil
+}
+
+// GetInventorySources returns the sources of the inventory.
+func (c *AAPClient) GetInventorySources(inventoryId int64) ([]AAPInventorySource, error) {
+	return listAll[AAPInventorySource](c, objectEndpoint("api/v2/inventories/", inventoryId, "inventory_sources"))
+}
+
+// GetHostGroups returns the groups the host is a direct member of.
+func (c *AAPClient) GetHostGroups(hostId int64) ([]AAPGroup, error) {
+	return listAll[AAPGroup](c, objectEndpoint("api/v2/hosts/", hostId, "groups"))
+}
+
+// GetGroupChildren returns the groups that are direct children of the group.
+func (c *AAPClient) GetGroupChildren(groupId int64) ([]AAPGroup, error) {
+	return listAll[AAPGroup](c, objectEndpoint("api/v2/groups/", groupId, "children"))
+}
+
+func (c *AAPClient) CreateHost(host AAPHost) (*AAPHost, error) {
+	var created AAPHost
+	if _, err := c.doJSON(http.MethodPost, "api/v2/hosts/", host, &created, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *AAPClient) UpdateHost(id int64, host AAPHost) (*AAPHost, error) {
+	var updated AAPHost
+	if _, err := c.doJSON(http.MethodPut, objectEndpoint("api/v2/hosts/", id), host, &updated, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *AAPClient) DeleteHost(id int64) error {
+	_, err := c.doJSON(http.MethodDelete, objectEndpoint("api/v2/hosts/", id), nil, nil, http.StatusNoContent, http.StatusNotFound)
+	return err
+}
+
+func (c *AAPClient) CreateGroup(group AAPGroup) (*AAPGroup, error) {
+	var created AAPGroup
+	if _, err := c.doJSON(http.MethodPost, "api/v2/groups/", group, &created, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+func (c *AAPClient) UpdateGroup(id int64, group AAPGroup) (*AAPGroup, error) {
+	var updated AAPGroup
+	if _, err := c.doJSON(http.MethodPut, objectEndpoint("api/v2/groups/", id), group, &updated, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *AAPClient) DeleteGroup(id int64) error {
+	_, err := c.doJSON(http.MethodDelete, objectEndpoint("api/v2/groups/", id), nil, nil, http.StatusNoContent, http.StatusNotFound)
+	return err
+}
+
+// associate adds (or, with disassociate set, removes) the object with the given id
+// to the related collection at endpoint.
+func (c *AAPClient) associate(endpoint string, id int64, disassociate bool) error {
+	payload := map[string]any{"id": id}
+	if disassociate {
+		payload["disassociate"] = true
+	}
+	_, err := c.doJSON(http.MethodPost, endpoint, payload, nil, http.StatusNoContent, http.StatusCreated)
+	return err
+}
+
+// setOrdered makes the objects related at endpoint match ids, in order. AAP appends
+// associated objects at the end, so the objects following the longest common prefix
+// are removed and the desired ones associated again in order.
+func (c *AAPClient) setOrdered(endpoint string, ids []int64) error {
+	current, err := listAll[AAPObjectRef](c, endpoint)
+	if err != nil {
+		return err
+	}
+
+	prefix := 0
+	for prefix < len(current) && prefix < len(ids) && current[prefix].Id == ids[prefix] {
+		prefix++
+	}
+	for _, object := range current[prefix:] {
+		if err := c.associate(endpoint, object.Id, true); err != nil {
+			return err
+		}
+	}
+	for _, id := range ids[prefix:] {
+		if err := c.associate(endpoint, id, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *AAPClient) AssociateGroupHost(groupId int64, hostId int64) error {
+	return c.associate(objectEndpoint("api/v2/groups/", groupId, "hosts"), hostId, false)
+}
+
+func (c *AAPClient) DisassociateGroupHost(groupId int64, hostId int64) error {
+	return c.associate(objectEndpoint("api/v2/groups/", groupId, "hosts"), hostId, true)
+}
+
+func (c *AAPClient) AssociateGroupChild(groupId int64, childId int64) error {
+	return c.associate(objectEndpoint("api/v2/groups/", groupId, "children"), childId, false)
+}
+
+func (c *AAPClient) DisassociateGroupChild(groupId int64, childId int64) error {
+	return c.associate(objectEndpoint("api/v2/groups/", groupId, "children"), childId, true)
+}
+
+// AAP instance group
+type AAPInstanceGroup struct {
+	Id                       int64    `json:"id,omitempty"`
+	Name                     string   `json:"name"`
+	PolicyInstancePercentage int64    `json:"policy_instance_percentage"`
+	PolicyInstanceMinimum    int64    `json:"policy_instance_minimum"`
+	PolicyInstanceList       []string `json:"policy_instance_list"`
+	IsContainerGroup         bool     `json:"is_container_group"`
+	Credential               *int64   `json:"credential"`
+	PodSpecOverride          string   `json:"pod_spec_override"`
+}
+
+func (c *AAPClient) GetInstanceGroup(id int64) (*AAPInstanceGroup, error) {
+	return getObject[AAPInstanceGroup](c, objectEndpoint("api/v2/instance_groups/", id))
+}
+
+func (c *AAPClient) CreateInstanceGroup(instanceGroup AAPInstanceGroup) (*AAPInstanceGroup, error) {
+	return createObject(c, "api/v2/instance_groups/", instanceGroup)
+}
+
+func (c *AAPClient) UpdateInstanceGroup(id int64, instanceGroup AAPInstanceGroup) (*AAPInstanceGroup, error) {
+	return updateObject(c, http.MethodPatch, objectEndpoint("api/v2/instance_groups/", id), instanceGroup)
+}
+
+func (c *AAPClient) DeleteInstanceGroup(id int64) error {
+	return c.deleteObject(objectEndpoint("api/v2/instance_groups/", id))
+}
+
+// GetInstanceGroupByName returns the instance group with the given name, or nil if there is none.
+func (c *AAPClient) GetInstanceGroupByName(name string) (*AAPInstanceGroup, error) {
+	return findByName(c, "api/v2/instance_groups/", name, func(g AAPInstanceGroup) string { return g.Name })
+}
+
+// AAP instance group capacity, as listed by the instance groups endpoint
+type AAPInstanceGroupCapacity struct {
+	Id                       int64   `json:"id"`
+	Name                     string  `json:"name"`
+	IsContainerGroup         bool    `json:"is_container_group"`
+	Instances                int64   `json:"instances"`
+	Capacity                 int64   `json:"capacity"`
+	ConsumedCapacity         float64 `json:"consumed_capacity"`
+	PercentCapacityRemaining float64 `json:"percent_capacity_remaining"`
+	JobsRunning              int64   `json:"jobs_running"`
+	JobsTotal                int64   `json:"jobs_total"`
+	MaxConcurrentJobs        int64   `json:"max_concurrent_jobs"`
+	MaxForks                 int64   `json:"max_forks"`
+}
+
+// GetInstanceGroupsCapacity returns the capacity of the instance groups matching the query, at most limit of them
+// when limit is positive.
+func (c *AAPClient) GetInstanceGroupsCapacity(query url.Values, limit int) ([]AAPInstanceGroupCapacity, error) {
+	return listUpTo[AAPInstanceGroupCapacity](c, buildEndpoint("api/v2/instance_groups/", nil, query), limit)
+}
+
+// mesh of the AAP instances, as drawn by the topology viewer
+type AAPMeshTopology struct {
+	Nodes []AAPMeshNode `json:"nodes"`
+	Links []AAPMeshLink `json:"links"`
+}
+
+// AAP instance in the mesh
+type AAPMeshNode struct {
+	Id        int64  `json:"id"`
+	Hostname  string `json:"hostname"`
+	NodeType  string `json:"node_type"`
+	NodeState string `json:"node_state"`
+	Enabled   bool   `json:"enabled"`
+}
+
+// receptor connection between two AAP instances, identified by hostname
+type AAPMeshLink struct {
+	Source    string `json:"source"`
+	Target    string `json:"target"`
+	LinkState string `json:"link_state"`
+}
+
+// GetMeshTopology returns the instances of the mesh and the links between them.
+func (c *AAPClient) GetMeshTopology() (*AAPMeshTopology, error) {
+	var topology AAPMeshTopology
+	if _, err := c.doJSON(http.MethodGet, "api/v2/mesh_visualizer/", nil, &topology, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &topology, nil
+}
+
+// AAP execution environment
+type AAPExecutionEnvironment struct {
+	Id           int64  `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Image        string `json:"image"`
+	Description  string `json:"description"`
+	Organization *int64 `json:"organization"`
+	Credential   *int64 `json:"credential"`
+	Pull         string `json:"pull"`
+}
+
+func (c *AAPClient) GetExecutionEnvironment(id int64) (*AAPExecutionEnvironment, error) {
+	return getObject[AAPExecutionEnvironment](c, objectEndpoint("api/v2/execution_environments/", id))
+}
+
+func (c *AAPClient) CreateExecutionEnvironment(environment AAPExecutionEnvironment) (*AAPExecutionEnvironment, error) {
+	return createObject(c, "api/v2/execution_environments/", environment)
+}
+
+func (c *AAPClient) UpdateExecutionEnvironment(id int64, environment AAPExecutionEnvironment) (*AAPExecutionEnvironment, error) {
+	return updateObject(c, http.MethodPatch, objectEndpoint("api/v2/execution_environments/", id), environment)
+}
+
+func (c *AAPClient) DeleteExecutionEnvironment(id int64) error {
+	return c.deleteObject(objectEndpoint("api/v2/execution_environments/", id))
+}
+
+// GetExecutionEnvironmentByName returns the execution environment with the given name, or nil if there is none.
+func (c *AAPClient) GetExecutionEnvironmentByName(name string) (*AAPExecutionEnvironment, error) {
+	return findByName(c, "api/v2/execution_environments/", name, func(e AAPExecutionEnvironment) string { return e.Name })
+}
+
+// AAP credential, as far as its kind is checked before referencing it
+type AAPCredential struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+}
+
+// GetCredential returns the credential, or nil if it does not exist.
+func (c *AAPClient) GetCredential(id int64) (*AAPCredential, error) {
+	return getObject[AAPCredential](c, objectEndpoint("api/v2/credentials/", id))
+}
+
+// AAPObjectRef is the id and name of an object related to another one, e.g. the labels of a job template.
+type AAPObjectRef struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// AAP project, as listed by the projects endpoint
+type AAPProject struct {
+	Id           int64  `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Organization *int64 `json:"organization"`
+	ScmType      string `json:"scm_type"`
+	ScmUrl       string `json:"scm_url"`
+	ScmBranch    string `json:"scm_branch"`
+	Status       string `json:"status"`
+}
+
+// GetProjects returns the projects matching the query, at most limit of them when limit is positive.
+func (c *AAPClient) GetProjects(query url.Values, limit int) ([]AAPProject, error) {
+	return listUpTo[AAPProject](c, buildEndpoint("api/v2/projects/", nil, query), limit)
+}
+
+// AAP job template, as listed by the job templates endpoint
+type AAPJobTemplate struct {
+	Id            int64   `json:"id"`
+	Name          string  `json:"name"`
+	Description   string  `json:"description"`
+	Organization  *int64  `json:"organization"`
+	Project       *int64  `json:"project"`
+	Inventory     *int64  `json:"inventory"`
+	Playbook      string  `json:"playbook"`
+	Status        string  `json:"status"`
+	LastJobRun    *string `json:"last_job_run"`
+	LastJobFailed bool    `json:"last_job_failed"`
+}
+
+// GetJobTemplates returns the job templates matching the query, at most limit of them when limit is positive.
+// Filtering on labels lists a template once per matching label, the duplicates are skipped while paging so that
+// they do not count towards the limit.
+func (c *AAPClient) GetJobTemplates(query url.Values, limit int) ([]AAPJobTemplate, error) {
+	var templates []AAPJobTemplate
+	seen := make(map[int64]bool)
+	err := forEach(c, buildEndpoint("api/v2/job_templates/", nil, query), func(template AAPJobTemplate) error {
+		if seen[template.Id] {
+			return nil
+		}
+		seen[template.Id] = true
+		templates = append(templates, template)
+		if len(templates) == limit {
+			return errListLimit
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errListLimit) {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// AAP user, as listed by the users endpoint
+type AAPUser struct {
+	Id          int64  `json:"id"`
+	Username    string `json:"username"`
+	FirstName   string `json:"first_name"`
+	LastName    string `json:"last_name"`
+	Email       string `json:"email"`
+	IsSuperuser bool   `json:"is_superuser"`
+	LdapDn      string `json:"ldap_dn"`
+}
+
+// GetUsers returns the users matching the query, at most limit of them when limit is positive.
+func (c *AAPClient) GetUsers(query url.Values, limit int) ([]AAPUser, error) {
+	return listUpTo[AAPUser](c, buildEndpoint("api/v2/users/", nil, query), limit)
+}
+
+// AAP team, as listed by the teams endpoint
+type AAPTeam struct {
+	Id           int64  `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Organization int64  `json:"organization"`
+}
+
+// GetTeams returns the teams matching the query, at most limit of them when limit is positive.
+func (c *AAPClient) GetTeams(query url.Values, limit int) ([]AAPTeam, error) {
+	return listUpTo[AAPTeam](c, buildEndpoint("api/v2/teams/", nil, query), limit)
+}
+
+// GetJobTemplate returns the job template, or nil if it does not exist.
+func (c *AAPClient) GetJobTemplate(id int64) (*AAPObjectRef, error) {
+	return getObject[AAPObjectRef](c, objectEndpoint("api/v2/job_templates/", id))
+}
+
+// GetJobTemplateInstanceGroups returns the instance groups of the job template in order of preference.
+func (c *AAPClient) GetJobTemplateInstanceGroups(jobTemplateId int64) ([]AAPObjectRef, error) {
+	return listAll[AAPObjectRef](c, objectEndpoint("api/v2/job_templates/", jobTemplateId, "instance_groups"))
+}
+
+// SetJobTemplateInstanceGroups sets the instance groups of the job template, the first one being preferred.
+func (c *AAPClient) SetJobTemplateInstanceGroups(jobTemplateId int64, instanceGroupIds []int64) error {
+	return c.setOrdered(objectEndpoint("api/v2/job_templates/", jobTemplateId, "instance_groups"), instanceGroupIds)
+}
+
+func (c *AAPClient) GetJobTemplateLabels(jobTemplateId int64) ([]AAPObjectRef, error) {
+	return listAll[AAPObjectRef](c, objectEndpoint("api/v2/job_templates/", jobTemplateId, "labels"))
+}
+
+func (c *AAPClient) AssociateJobTemplateLabel(jobTemplateId int64, labelId int64) error {
+	return c.associate(objectEndpoint("api/v2/job_templates/", jobTemplateId, "labels"), labelId, false)
+}
+
+func (c *AAPClient) DisassociateJobTemplateLabel(jobTemplateId int64, labelId int64) error {
+	return c.associate(objectEndpoint("api/v2/job_templates/", jobTemplateId, "labels"), labelId, true)
+}
+
+// aapKindCollections are the collections of the kinds of controller objects the provider refers to by kind.
+var aapKindCollections = map[string]string{
+	"organization":          "organizations",
+	"project":               "projects",
+	"inventory":             "inventories",
+	"inventory_source":      "inventory_sources",
+	"credential":            "credentials",
+	"job_template":          "job_templates",
+	"workflow_job_template": "workflow_job_templates",
+	"instance_group":        "instance_groups",
+}
+
+// kindEndpoint returns the endpoint of the collection of objects of a kind, e.g. api/v2/inventories/ for inventory.
+func kindEndpoint(kind string) string {
+	return buildEndpoint("api/v2/", []string{aapKindCollections[kind]}, nil)
+}
+
+// templateEndpoint returns the endpoint of a template, followed by the path of a related collection if
+// any; kind is job_template or workflow_job_template.
+func templateEndpoint(kind string, id int64, related ...string) string {
+	return objectEndpoint(kindEndpoint(kind), id, related...)
+}
+
+// settings of an AAP controller organization
+type AAPOrganizationSettings struct {
+	Id       int64 `json:"id,omitempty"`
+	MaxHosts int64 `json:"max_hosts,omitempty"`
+}
+
+// AAP controller organization
+type AAPOrganization struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// GetOrganizations returns the organizations visible to the client matching the query, at most limit of them when limit is positive.
+func (c *AAPClient) GetOrganizations(query url.Values, limit int) ([]AAPOrganization, error) {
+	return listUpTo[AAPOrganization](c, buildEndpoint("api/v2/organizations/", nil, query), limit)
+}
+
+// GetOrganizationSettings returns the execution settings of the organization, or nil if it does not exist.
+func (c *AAPClient) GetOrganizationSettings(id int64) (*AAPOrganizationSettings, error) {
+	return getObject[AAPOrganizationSettings](c, objectEndpoint("api/v2/organizations/", id))
+}
+
+// CountOrganizationHosts returns the number of hosts in the inventories of the organization.
+func (c *AAPClient) CountOrganizationHosts(id int64) (int64, error) {
+	query := url.Values{"inventory__organization": {strconv.FormatInt(id, 10)}, "page_size": {"1"}}
+	var page aapListResponse[AAPObjectRef]
+	_, err := c.doJSON(http.MethodGet, buildEndpoint("api/v2/hosts/", nil, query), nil, &page, http.StatusOK)
+	return page.Count, err
+}
+
+// AAP job template or workflow job template, as far as copies of templates are managed
+type AAPTemplate struct {
+	Id          int64   `json:"id,omitempty"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+}
+
+// GetTemplate returns the template, or nil if it does not exist.
+func (c *AAPClient) GetTemplate(kind string, id int64) (*AAPTemplate, error) {
+	return getObject[AAPTemplate](c, templateEndpoint(kind, id))
+}
+
+// CopyTemplate copies the template, along with its surveys, nodes and related objects, into a new template.
+func (c *AAPClient) CopyTemplate(kind string, id int64, name string) (*AAPTemplate, error) {
+	return createObject(c, templateEndpoint(kind, id, "copy"), AAPTemplate{Name: name})
+}
+
+func (c *AAPClient) UpdateTemplate(kind string, id int64, template AAPTemplate) (*AAPTemplate, error) {
+	return updateObject(c, http.MethodPatch, templateEndpoint(kind, id), template)
+}
+
+func (c *AAPClient) DeleteTemplate(kind string, id int64) error {
+	return c.deleteObject(templateEndpoint(kind, id))
+}
+
+// notificationsEndpoint returns the endpoint of the notification templates sent on event for an object;
+// kind is organization, project, inventory_source, job_template or workflow_job_template.
+func notificationsEndpoint(kind string, id int64, event string) string {
+	return objectEndpoint(kindEndpoint(kind), id, "notification_templates_"+event)
+}
+
+// GetNotifiable returns the object notifications are attached to, or nil if it does not exist.
+func (c *AAPClient) GetNotifiable(kind string, id int64) (*AAPObjectRef, error) {
+	return getObject[AAPObjectRef](c, objectEndpoint(kindEndpoint(kind), id))
+}
+
+// GetNotificationTemplates returns the notification templates sent on event for the object.
+func (c *AAPClient) GetNotificationTemplates(kind string, id int64, event string) ([]AAPObjectRef, error) {
+	return listAll[AAPObjectRef](c, notificationsEndpoint(kind, id, event))
+}
+
+func (c *AAPClient) AssociateNotificationTemplate(kind string, id int64, event string, notificationTemplateId int64) error {
+	return c.associate(notificationsEndpoint(kind, id, event), notificationTemplateId, false)
+}
+
+func (c *AAPClient) DisassociateNotificationTemplate(kind string, id int64, event string, notificationTemplateId int64) error {
+	return c.associate(notificationsEndpoint(kind, id, event), notificationTemplateId, true)
+}
+
+// AAP role of an object, e.g. the execute role of a job template
+type AAPRole struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// object along with the roles AAP summarizes in its response
+type aapObjectWithRoles struct {
+	SummaryFields struct {
+		ObjectRoles map[string]AAPRole `json:"object_roles"`
+	} `json:"summary_fields"`
+}
+
+// GetObjectRoles returns the roles of an object keyed by field name, e.g. execute_role, or nil if the object does not exist;
+// kind is organization, project, inventory, credential, job_template, workflow_job_template or instance_group.
+func (c *AAPClient) GetObjectRoles(kind string, id int64) (map[string]AAPRole, error) {
+	object, err := getObject[aapObjectWithRoles](c, objectEndpoint(kindEndpoint(kind), id))
+	if err != nil || object == nil {
+		return nil, err
+	}
+	if object.SummaryFields.ObjectRoles == nil {
+		return map[string]AAPRole{}, nil
+	}
+	return object.SummaryFields.ObjectRoles, nil
+}
+
+// GetTeam returns the controller team, or nil if it does not exist.
+func (c *AAPClient) GetTeam(id int64) (*AAPObjectRef, error) {
+	return getObject[AAPObjectRef](c, objectEndpoint("api/v2/teams/", id))
+}
+
+// GetTeamRoles returns the roles granted to the controller team.
+func (c *AAPClient) GetTeamRoles(teamId int64) ([]AAPRole, error) {
+	return listAll[AAPRole](c, buildEndpoint("api/v2/teams/", []string{strconv.FormatInt(teamId, 10), "roles"}, pageQuery()))
+}
+
+func (c *AAPClient) AssociateTeamRole(teamId int64, roleId int64) error {
+	return c.associate(objectEndpoint("api/v2/teams/", teamId, "roles"), roleId, false)
+}
+
+func (c *AAPClient) DisassociateTeamRole(teamId int64, roleId int64) error {
+	return c.associate(objectEndpoint("api/v2/teams/", teamId, "roles"), roleId, true)
+}
+
+// GetRawObject returns every field of the object with the given id in the collection, or nil if it does not exist.
+func (c *AAPClient) GetRawObject(collection string, id int64) (map[string]any, error) {
+	object, err := getObject[map[string]any](c, objectEndpoint(collection, id))
+	if err != nil || object == nil {
+		return nil, err
+	}
+	return *object, nil
+}
+
+// GetRawRelated returns every field of the objects of a related collection of the object with the given id.
+func (c *AAPClient) GetRawRelated(collection string, id int64, related string) ([]map[string]any, error) {
+	return listAll[map[string]any](c, buildEndpoint(collection, []string{strconv.FormatInt(id, 10), related}, pageQuery()))
+}
+
+// CreateRawObject posts the fields to the collection and returns every field of the created object.
+func (c *AAPClient) CreateRawObject(collection string, fields map[string]any) (map[string]any, error) {
+	var created map[string]any
+	if _, err := c.doJSON(http.MethodPost, collection, fields, &created, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// UpdateRawObject sets the fields of the object with the given id in the collection. The other fields are left untouched.
+func (c *AAPClient) UpdateRawObject(collection string, id int64, fields map[string]any) (map[string]any, error) {
+	var updated map[string]any
+	if _, err := c.doJSON(http.MethodPatch, objectEndpoint(collection, id), fields, &updated, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// projectUpdatePending are the statuses of a project whose repository is being updated.
+var projectUpdatePending = []string{"new", "pending", "waiting", "running"}
+
+// WaitForProjectUpdate waits until the running update of the project repository, if any, is over.
+func (c *AAPClient) WaitForProjectUpdate(ctx context.Context, id int64) error {
+	for {
+		project, err := getObject[AAPProject](c, objectEndpoint("api/v2/projects/", id))
+		if err != nil {
+			return err
+		}
+		if project == nil {
+			return fmt.Errorf("project %d not found", id)
+		}
+		if !slices.Contains(projectUpdatePending, project.Status) {
+			if project.Status == "failed" || project.Status == "error" {
+				return fmt.Errorf("update of project %d %s", id, project.Status)
+			}
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.pollDelay()):
+		}
+	}
+}
+
+// URL returns the absolute URL of an endpoint of the API.
+func (c *AAPClient) URL(endpoint string) string {
+	return strings.TrimSuffix(c.HostURL, "/") + "/" + strings.TrimPrefix(endpoint, "/")
+}
+
+// AAPWorkflowNode is a node of a workflow job template with the ids of the nodes it leads to.
+type AAPWorkflowNode struct {
+	Id                 int64   `json:"id"`
+	Identifier         string  `json:"identifier"`
+	UnifiedJobTemplate *int64  `json:"unified_job_template"`
+	SuccessNodes       []int64 `json:"success_nodes"`
+	FailureNodes       []int64 `json:"failure_nodes"`
+	AlwaysNodes        []int64 `json:"always_nodes"`
+}
+
+// GetWorkflowJobTemplate returns the workflow job template, or nil if it does not exist.
+func (c *AAPClient) GetWorkflowJobTemplate(id int64) (*AAPObjectRef, error) {
+	return getObject[AAPObjectRef](c, objectEndpoint("api/v2/workflow_job_templates/", id))
+}
+
+// GetWorkflowNodes returns the nodes of the workflow job template.
+func (c *AAPClient) GetWorkflowNodes(workflowId int64) ([]AAPWorkflowNode, error) {
+	return listAll[AAPWorkflowNode](c, buildEndpoint("api/v2/workflow_job_templates/", []string{strconv.FormatInt(workflowId, 10), "workflow_nodes"}, pageQuery()))
+}
+
+// CreateWorkflowNode adds a node with the given fields to the workflow job template.
+func (c *AAPClient) CreateWorkflowNode(workflowId int64, fields map[string]any) (*AAPWorkflowNode, error) {
+	var created AAPWorkflowNode
+	if _, err := c.doJSON(http.MethodPost, objectEndpoint("api/v2/workflow_job_templates/", workflowId, "workflow_nodes"), fields, &created, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateWorkflowNode sets the fields of the workflow node found in fields. The other fields are left untouched.
+func (c *AAPClient) UpdateWorkflowNode(id int64, fields map[string]any) (*AAPWorkflowNode, error) {
+	var updated AAPWorkflowNode
+	if _, err := c.doJSON(http.MethodPatch, objectEndpoint("api/v2/workflow_job_template_nodes/", id), fields, &updated, http.StatusOK); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+func (c *AAPClient) DeleteWorkflowNode(id int64) error {
+	return c.deleteObject(objectEndpoint("api/v2/workflow_job_template_nodes/", id))
+}
+
+// unifiedJobTemplateEndpoints maps the types of the templates a workflow node can run to their collection.
+var unifiedJobTemplateEndpoints = map[string]string{
+	"job_template":          "api/v2/job_templates/",
+	"workflow_job_template": "api/v2/workflow_job_templates/",
+	"project":               "api/v2/projects/",
+	"inventory_source":      "api/v2/inventory_sources/",
+	"system_job_template":   "api/v2/system_job_templates/",
+}
+
+// FindUnifiedJobTemplate returns the template of the given type with the given name, or nil if there is none.
+// The organization, when not empty, is the one of the template, or of its inventory for inventory sources.
+// The inventory, when not empty, is the name of the inventory of an inventory source.
+func (c *AAPClient) FindUnifiedJobTemplate(templateType string, name string, organization string, inventory string) (*AAPObjectRef, error) {
+	endpoint, ok := unifiedJobTemplateEndpoints[templateType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported template type %q", templateType)
+	}
+	query := url.Values{"name": {name}}
+	if organization != "" {
+		if templateType == "inventory_source" {
+			query.Set("inventory__organization__name", organization)
+		} else {
+			query.Set("organization__name", organization)
+		}
+	}
+	if inventory != "" {
+		query.Set("inventory__name", inventory)
+	}
+	return findByQuery(c, endpoint, query, func(t AAPObjectRef) bool { return t.Name == name })
+}
+
+// FindInventory returns the inventory with the given name, in the organization when not empty, or nil if there is none.
+func (c *AAPClient) FindInventory(name string, organization string) (*AAPObjectRef, error) {
+	query := url.Values{"name": {name}}
+	if organization != "" {
+		query.Set("organization__name", organization)
+	}
+	return findByQuery(c, "api/v2/inventories/", query, func(i AAPObjectRef) bool { return i.Name == name })
+}
+
+// AssociateWorkflowNode runs the child node after the parent one; linkType is success, failure or always.
+func (c *AAPClient) AssociateWorkflowNode(parentId int64, linkType string, childId int64) error {
+	return c.associate(objectEndpoint("api/v2/workflow_job_template_nodes/", parentId, linkType+"_nodes"), childId, false)
+}
+
+func (c *AAPClient) DisassociateWorkflowNode(parentId int64, linkType string, childId int64) error {
+	return c.associate(objectEndpoint("api/v2/workflow_job_template_nodes/", parentId, linkType+"_nodes"), childId, true)
+}
+
+// AAPHostMetric records how often a host was automated, which AAP uses to count hosts against the subscription.
+type AAPHostMetric struct {
+	Id                int64   `json:"id"`
+	Hostname          string  `json:"hostname"`
+	FirstAutomation   string  `json:"first_automation"`
+	LastAutomation    string  `json:"last_automation"`
+	AutomatedCounter  int64   `json:"automated_counter"`
+	Deleted           bool    `json:"deleted"`
+	DeletedCounter    int64   `json:"deleted_counter"`
+	LastDeleted       *string `json:"last_deleted"`
+	UsedInInventories *int64  `json:"used_in_inventories"`
+}
+
+// GetHostMetrics returns the host metrics, optionally including soft-deleted ones and
+// restricted to hosts last automated before the given RFC 3339 time.
+func (c *AAPClient) GetHostMetrics(includeDeleted bool, lastAutomationBefore string) ([]AAPHostMetric, error) {
+	query := pageQuery()
+	if !includeDeleted {
+		query.Set("deleted", "false")
+	}
+	if lastAutomationBefore != "" {
+		query.Set("last_automation__lt", lastAutomationBefore)
+	}
+	return listAll[AAPHostMetric](c, buildEndpoint("api/v2/host_metrics/", nil, query))
+}
+
+// DeleteHostMetric soft-deletes the host metric so that the host is no longer counted.
+func (c *AAPClient) DeleteHostMetric(id int64) error {
+	return c.deleteObject(objectEndpoint("api/v2/host_metrics/", id))
+}
+
+// GetAnsibleHost extracts the ansible hosts and groups from a Terraform state document.
+// Malformed documents produce descriptive errors rather than panics, while optional
+// attributes (groups, children, variables) may be missing or null.
 func GetAnsibleHost(body []byte) (*AnsibleHostList, error) {
 
+	// decode numbers as json.Number so that variable values keep their exact representation
 	var result map[string]interface{}
-	err := json.Unmarshal(body, &result)
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	err := decoder.Decode(&result)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("invalid state document: %w", err)
+	}
+
+	if version, ok := result["version"].(json.Number); ok {
+		if v, err := version.Int64(); err == nil && v < 4 {
+			return nil, fmt.Errorf("unsupported state format version %d, state must be written by Terraform 0.12 or later", v)
+		}
 	}
 
 	var hosts AnsibleHostList
-	resources, ok := result["resources"].([]interface{})
-	if ok {
-		for _, resource := range resources {
-			resource_obj := resource.(map[string]interface{})
-			resource_type, ok := resource_obj["type"]
-			if ok && resource_type == "ansible_host" {
-				instances, ok := resource_obj["instances"].([]interface{})
-				if ok {
-					for _, instance := range instances {
-						attributes, ok := instance.(map[string]interface{})["attributes"].(map[string]interface{})
-						if ok {
-							name := attributes["name"].(string)
-							var groups []string
-							for _, group := range attributes["groups"].([]interface{}) {
-								groups = append(groups, group.(string))
-							}
-							variables := make(map[string]string)
-							for key, value := range attributes["variables"].(map[string]interface{}) {
-								variables[key] = value.(string)
-							}
-							hosts.Hosts = append(hosts.Hosts, AnsibleHost{
-								Name:      name,
-								Groups:    groups,
-								Variables: variables,
-							})
-						}
-					}
-				}
+	rawResources, ok := result["resources"]
+	if !ok || rawResources == nil {
+		return &hosts, nil
+	}
+	resources, ok := rawResources.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("invalid state document: resources is a %T, expected a list", rawResources)
+	}
+
+	for i, resource := range resources {
+		resource_obj, ok := resource.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid state document: resource %d is a %T, expected an object", i, resource)
+		}
+		resource_type, _ := resource_obj["type"].(string)
+		if resource_type != "ansible_host" && resource_type != "ansible_group" && resource_type != "ansible_playbook" {
+			continue
+		}
+		resource_name, _ := resource_obj["name"].(string)
+		address := resource_type + "." + resource_name
+
+		instances, err := optionalList(resource_obj, "instances")
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", address, err)
+		}
+		for j, instance := range instances {
+			instance_obj, ok := instance.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: instance %d is a %T, expected an object", address, j, instance)
+			}
+			attributes, ok := instance_obj["attributes"].(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("%s: instance %d has no attributes", address, j)
+			}
+			if err := hosts.addResource(resource_type, attributes); err != nil {
+				return nil, fmt.Errorf("%s: instance %d: %w", address, j, err)
 			}
 		}
 	}
 	return &hosts, nil
 }
+
+// addResource adds the host or group described by the attributes of an ansible resource instance.
+func (l *AnsibleHostList) addResource(resourceType string, attributes map[string]interface{}) error {
+	name, ok := attributes["name"].(string)
+	if !ok || name == "" {
+		return fmt.Errorf("missing or invalid name attribute")
+	}
+
+	switch resourceType {
+	case "ansible_host":
+		groups, err := optionalStrings(attributes, "groups")
+		if err != nil {
+			return err
+		}
+		variables, err := optionalObject(attributes, "variables")
+		if err != nil {
+			return err
+		}
+		host := l.host(name)
+		host.addGroups(groups)
+		host.Variables = variables
+	case "ansible_playbook":
+		// a playbook run targets a single host, optionally placed in groups, and never sets its variables
+		groups, err := optionalStrings(attributes, "groups")
+		if err != nil {
+			return err
+		}
+		l.host(name).addGroups(groups)
+	case "ansible_group":
+		children, err := optionalStrings(attributes, "children")
+		if err != nil {
+			return err
+		}
+		variables, err := optionalObject(attributes, "variables")
+		if err != nil {
+			return err
+		}
+		l.Groups = append(l.Groups, AnsibleGroup{
+			Name:      name,
+			Children:  children,
+			Variables: variables,
+		})
+	}
+	return nil
+}
+
+// host returns the host with the given name, adding it without groups or variables when it is not listed yet.
+// Hosts declared by several resources, e.g. an ansible_host and the ansible_playbook targeting it, are listed once.
+func (l *AnsibleHostList) host(name string) *AnsibleHost {
+	for i := range l.Hosts {
+		if l.Hosts[i].Name == name {
+			return &l.Hosts[i]
+		}
+	}
+	l.Hosts = append(l.Hosts, AnsibleHost{Name: name, Variables: make(map[string]interface{})})
+	return &l.Hosts[len(l.Hosts)-1]
+}
+
+// addGroups places the host in the groups it is not a member of yet.
+func (h *AnsibleHost) addGroups(groups []string) {
+	for _, group := range groups {
+		if !slices.Contains(h.Groups, group) {
+			h.Groups = append(h.Groups, group)
+		}
+	}
+}
+
+// optionalList returns the list stored under key, or nil when it is missing or null.
+func optionalList(obj map[string]interface{}, key string) ([]interface{}, error) {
+	raw, ok := obj[key]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	list, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is a %T, expected a list", key, raw)
+	}
+	return list, nil
+}
+
+// optionalStrings returns the list of strings stored under key, or nil when it is missing or null.
+func optionalStrings(obj map[string]interface{}, key string) ([]string, error) {
+	list, err := optionalList(obj, key)
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	for i, item := range list {
+		value, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s[%d] is a %T, expected a string", key, i, item)
+		}
+		values = append(values, value)
+	}
+	return values, nil
+}
+
+// optionalObject returns a copy of the object stored under key, or an empty map when it is missing or null.
+func optionalObject(obj map[string]interface{}, key string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	raw, ok := obj[key]
+	if !ok || raw == nil {
+		return values, nil
+	}
+	object, ok := raw.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%s is a %T, expected an object", key, raw)
+	}
+	for k, v := range object {
+		values[k] = v
+	}
+	return values, nil
+}
@@ -0,0 +1,133 @@
+package provider
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestClientSharesTransport(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.URL.String())
+		writeJSON(w, http.StatusOK, map[string]any{})
+	}))
+	defer proxy.Close()
+
+	client, err := NewClient("http://aap.example.com", nil, nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client.Proxy, _ = url.Parse(proxy.URL)
+	withStats := client.withStats()
+	for _, c := range []*AAPClient{client, withStats} {
+		if _, err := c.doJSON(http.MethodGet, "api/v2/ping/", nil, nil, http.StatusOK); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if client.httpClient() != withStats.httpClient() {
+		t.Error("the copy made by withStats does not share the HTTP client")
+	}
+	if len(proxied) != 2 || proxied[0] != "http://aap.example.com/api/v2/ping/" {
+		t.Errorf("the proxy received %v", proxied)
+	}
+}
+
+func TestGetAnsibleHost(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		expected AnsibleHostList
+		err      string
+	}{
+		{
+			name: "hosts, groups and playbooks",
+			document: `{"version": 4, "resources": [
+				{"type": "ansible_host", "name": "web", "instances": [
+					{"attributes": {"name": "web1", "groups": ["web"], "variables": {"http_port": 8080, "ratio": 1.50}}},
+					{"attributes": {"name": "web2", "groups": null, "variables": null}}
+				]},
+				{"type": "ansible_group", "name": "web", "instances": [{"attributes": {"name": "web", "children": ["app"], "variables": {"region": "eu"}}}]},
+				{"type": "ansible_playbook", "name": "deploy", "instances": [{"attributes": {"name": "db1", "groups": ["db"], "playbook": "deploy.yml"}}]},
+				{"type": "aws_instance", "name": "web", "instances": [{"attributes": {"id": "i-123"}}]}
+			]}`,
+			expected: AnsibleHostList{
+				Hosts: []AnsibleHost{
+					{Name: "web1", Groups: []string{"web"}, Variables: map[string]interface{}{"http_port": json.Number("8080"), "ratio": json.Number("1.50")}},
+					{Name: "web2", Variables: map[string]interface{}{}},
+					{Name: "db1", Groups: []string{"db"}, Variables: map[string]interface{}{}},
+				},
+				Groups: []AnsibleGroup{{Name: "web", Children: []string{"app"}, Variables: map[string]interface{}{"region": "eu"}}},
+			},
+		},
+		{
+			name: "playbook targeting a declared host",
+			document: `{"version": 4, "resources": [
+				{"type": "ansible_host", "name": "db", "instances": [{"attributes": {"name": "db1", "groups": ["db"], "variables": {"ansible_user": "postgres"}}}]},
+				{"type": "ansible_playbook", "name": "deploy", "instances": [{"attributes": {"name": "db1", "groups": ["deploy", "db"], "playbook": "deploy.yml"}}]}
+			]}`,
+			expected: AnsibleHostList{
+				Hosts: []AnsibleHost{{Name: "db1", Groups: []string{"db", "deploy"}, Variables: map[string]interface{}{"ansible_user": "postgres"}}},
+			},
+		},
+		{
+			name: "playbook before the host it targets",
+			document: `{"version": 4, "resources": [
+				{"type": "ansible_playbook", "name": "deploy", "instances": [{"attributes": {"name": "db1", "groups": ["deploy"], "playbook": "deploy.yml"}}]},
+				{"type": "ansible_host", "name": "db", "instances": [{"attributes": {"name": "db1", "groups": ["db"], "variables": {"ansible_user": "postgres"}}}]}
+			]}`,
+			expected: AnsibleHostList{
+				Hosts: []AnsibleHost{{Name: "db1", Groups: []string{"deploy", "db"}, Variables: map[string]interface{}{"ansible_user": "postgres"}}},
+			},
+		},
+		{name: "no resources", document: `{"version": 4}`},
+		{name: "null instances", document: `{"version": 4, "resources": [{"type": "ansible_host", "name": "web", "instances": null}]}`},
+		{name: "not json", document: `{`, err: "invalid state document"},
+		{name: "old version", document: `{"version": 3, "modules": []}`, err: "unsupported state format version 3"},
+		{name: "resources not a list", document: `{"resources": {}}`, err: "resources is a map[string]interface {}, expected a list"},
+		{name: "resource not an object", document: `{"resources": [1]}`, err: "resource 0 is a json.Number, expected an object"},
+		{
+			name:     "instance without attributes",
+			document: `{"resources": [{"type": "ansible_host", "name": "web", "instances": [{}]}]}`,
+			err:      "ansible_host.web: instance 0 has no attributes",
+		},
+		{
+			name:     "missing name",
+			document: `{"resources": [{"type": "ansible_group", "name": "web", "instances": [{"attributes": {"name": ""}}]}]}`,
+			err:      "ansible_group.web: instance 0: missing or invalid name attribute",
+		},
+		{
+			name:     "group that is not a string",
+			document: `{"resources": [{"type": "ansible_host", "name": "web", "instances": [{"attributes": {"name": "web1", "groups": [1]}}]}]}`,
+			err:      "groups[0] is a json.Number, expected a string",
+		},
+		{
+			name:     "variables that are not an object",
+			document: `{"resources": [{"type": "ansible_group", "name": "web", "instances": [{"attributes": {"name": "web", "variables": "{}"}}]}]}`,
+			err:      "variables is a string, expected an object",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			hosts, err := GetAnsibleHost([]byte(test.document))
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("error = %v, expected %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(*hosts, test.expected) {
+				t.Errorf("hosts = %+v, expected %+v", *hosts, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+// alreadyExistsDiagnostics reports that the object about to be created already exists, which
+// AAP would otherwise reject with a bare uniqueness error. importId is the id to import it with.
+func alreadyExistsDiagnostics(kind string, name string, importId string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	diags.AddAttributeError(
+		path.Root("name"),
+		fmt.Sprintf("%s already exists", kind),
+		fmt.Sprintf("A %s named %q already exists. Import it into this resource with the id %q, or choose another name.", kind, name, importId),
+	)
+	return diags
+}
+
+// apiErrorDiagnostics converts an error returned by the AAP API into diagnostics. Validation
+// messages of the API fields found in attributes are reported on the mapped attribute, so that
+// the offending line of the configuration is highlighted.
+func apiErrorDiagnostics(summary string, err error, attributes map[string]string) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		diags.AddError(summary, err.Error())
+		return diags
+	}
+
+	for _, field := range sortedKeys(validationErr.Fields) {
+		messages := strings.Join(validationErr.Fields[field], "\n")
+		attribute, ok := attributes[field]
+		switch {
+		case ok:
+			diags.AddAttributeError(path.Root(attribute), summary, messages)
+		case field == "detail" || field == "non_field_errors" || field == "__all__":
+			diags.AddError(summary, messages)
+		default:
+			diags.AddError(summary, fmt.Sprintf("%s: %s", field, messages))
+		}
+	}
+	return diags
+}
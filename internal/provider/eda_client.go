@@ -0,0 +1,268 @@
+package provider
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// edaAPIPath is the base path of the Event-Driven Ansible controller API.
+const edaAPIPath string = "api/eda/v1/"
+
+// summary of a related object as nested in EDA detail responses
+type edaRef struct {
+	Id   int64  `json:"id"`
+	Name string `json:"name"`
+}
+
+// EDA project
+type EDAProject struct {
+	Id              int64   `json:"id,omitempty"`
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	URL             string  `json:"url"`
+	OrganizationId  *int64  `json:"organization_id,omitempty"`
+	EDACredentialId *int64  `json:"eda_credential_id"`
+	ScmBranch       string  `json:"scm_branch"`
+	VerifySSL       bool    `json:"verify_ssl"`
+	GitHash         string  `json:"git_hash,omitempty"`
+	ImportState     string  `json:"import_state,omitempty"`
+	ImportError     string  `json:"import_error,omitempty"`
+	Organization    *edaRef `json:"organization,omitempty"`
+	EDACredential   *edaRef `json:"eda_credential,omitempty"`
+}
+
+// resolveRefs fills the id fields from the nested objects returned by detail endpoints.
+func (p *EDAProject) resolveRefs() *EDAProject {
+	if p == nil {
+		return nil
+	}
+	if p.Organization != nil {
+		p.OrganizationId = &p.Organization.Id
+	}
+	if p.EDACredential != nil {
+		p.EDACredentialId = &p.EDACredential.Id
+	}
+	p.Organization, p.EDACredential = nil, nil
+	return p
+}
+
+func (c *AAPClient) GetEDAProject(id int64) (*EDAProject, error) {
+	project, err := getObject[EDAProject](c, objectEndpoint(edaAPIPath+"projects/", id))
+	return project.resolveRefs(), err
+}
+
+func (c *AAPClient) CreateEDAProject(project EDAProject) (*EDAProject, error) {
+	created, err := createObject(c, edaAPIPath+"projects/", project)
+	return created.resolveRefs(), err
+}
+
+func (c *AAPClient) UpdateEDAProject(id int64, project EDAProject) (*EDAProject, error) {
+	updated, err := updateObject(c, http.MethodPatch, objectEndpoint(edaAPIPath+"projects/", id), project)
+	return updated.resolveRefs(), err
+}
+
+func (c *AAPClient) DeleteEDAProject(id int64) error {
+	return c.deleteObject(objectEndpoint(edaAPIPath+"projects/", id))
+}
+
+// GetEDAProjectByName returns the project with the given name, or nil if there is none.
+func (c *AAPClient) GetEDAProjectByName(name string) (*EDAProject, error) {
+	return findByName(c, edaAPIPath+"projects/", name, func(p EDAProject) string { return p.Name })
+}
+
+// EDA credential
+type EDACredential struct {
+	Id               int64                  `json:"id,omitempty"`
+	Name             string                 `json:"name"`
+	Description      string                 `json:"description"`
+	CredentialTypeId int64                  `json:"credential_type_id,omitempty"`
+	OrganizationId   *int64                 `json:"organization_id,omitempty"`
+	Inputs           map[string]interface{} `json:"inputs,omitempty"`
+	CredentialType   *edaRef                `json:"credential_type,omitempty"`
+	Organization     *edaRef                `json:"organization,omitempty"`
+}
+
+// resolveRefs fills the id fields from the nested objects returned by detail endpoints.
+func (c *EDACredential) resolveRefs() *EDACredential {
+	if c == nil {
+		return nil
+	}
+	if c.CredentialType != nil {
+		c.CredentialTypeId = c.CredentialType.Id
+	}
+	if c.Organization != nil {
+		c.OrganizationId = &c.Organization.Id
+	}
+	c.CredentialType, c.Organization = nil, nil
+	return c
+}
+
+// EDA credential type
+type EDACredentialType struct {
+	Id        int64  `json:"id"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	Kind      string `json:"kind"`
+}
+
+func (c *AAPClient) GetEDACredential(id int64) (*EDACredential, error) {
+	credential, err := getObject[EDACredential](c, objectEndpoint(edaAPIPath+"eda-credentials/", id))
+	return credential.resolveRefs(), err
+}
+
+func (c *AAPClient) CreateEDACredential(credential EDACredential) (*EDACredential, error) {
+	created, err := createObject(c, edaAPIPath+"eda-credentials/", credential)
+	return created.resolveRefs(), err
+}
+
+func (c *AAPClient) UpdateEDACredential(id int64, credential EDACredential) (*EDACredential, error) {
+	updated, err := updateObject(c, http.MethodPatch, objectEndpoint(edaAPIPath+"eda-credentials/", id), credential)
+	return updated.resolveRefs(), err
+}
+
+func (c *AAPClient) DeleteEDACredential(id int64) error {
+	return c.deleteObject(objectEndpoint(edaAPIPath+"eda-credentials/", id))
+}
+
+// GetEDACredentialByName returns the credential with the given name, or nil if there is none.
+func (c *AAPClient) GetEDACredentialByName(name string) (*EDACredential, error) {
+	return findByName(c, edaAPIPath+"eda-credentials/", name, func(e EDACredential) string { return e.Name })
+}
+
+// GetEDACredentialTypeByName returns the credential type with the given name, or nil if there is none.
+func (c *AAPClient) GetEDACredentialTypeByName(name string) (*EDACredentialType, error) {
+	return findByName(c, edaAPIPath+"credential-types/", name, func(t EDACredentialType) string { return t.Name })
+}
+
+// findByName lists the collection at endpoint filtered by name and returns the object
+// whose name matches exactly, or nil if there is none.
+func findByName[T any](c *AAPClient, endpoint string, name string, nameOf func(T) string) (*T, error) {
+	return findByQuery(c, endpoint, url.Values{"name": {name}}, func(object T) bool { return nameOf(object) == name })
+}
+
+// findByQuery lists the collection at endpoint filtered by query and returns the first object
+// for which matches returns true, or nil if there is none. API filters are not always exact,
+// e.g. they may ignore case, so matches checks the returned objects again.
+func findByQuery[T any](c *AAPClient, endpoint string, query url.Values, matches func(T) bool) (*T, error) {
+	objects, err := listAll[T](c, buildEndpoint(endpoint, nil, query))
+	if err != nil {
+		return nil, err
+	}
+	for _, object := range objects {
+		if matches(object) {
+			return &object, nil
+		}
+	}
+	return nil, nil
+}
+
+// EDA rulebook
+type EDARulebook struct {
+	Id          int64  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	ProjectId   *int64 `json:"project_id"`
+	Rulesets    string `json:"rulesets"`
+}
+
+// GetEDARulebooks returns the rulebooks with the given name, optionally restricted to a project.
+func (c *AAPClient) GetEDARulebooks(name string, projectId *int64) ([]EDARulebook, error) {
+	query := url.Values{}
+	query.Set("name", name)
+	if projectId != nil {
+		query.Set("project_id", strconv.FormatInt(*projectId, 10))
+	}
+	return listAll[EDARulebook](c, buildEndpoint(edaAPIPath+"rulebooks/", nil, query))
+}
+
+// EDA decision environment
+type EDADecisionEnvironment struct {
+	Id              int64   `json:"id"`
+	Name            string  `json:"name"`
+	Description     string  `json:"description"`
+	ImageURL        string  `json:"image_url"`
+	OrganizationId  *int64  `json:"organization_id"`
+	EDACredentialId *int64  `json:"eda_credential_id"`
+	Organization    *edaRef `json:"organization,omitempty"`
+	EDACredential   *edaRef `json:"eda_credential,omitempty"`
+}
+
+// GetEDADecisionEnvironmentByName returns the decision environment with the given name, or nil if there is none.
+func (c *AAPClient) GetEDADecisionEnvironmentByName(name string) (*EDADecisionEnvironment, error) {
+	environment, err := findByName(c, edaAPIPath+"decision-environments/", name, func(e EDADecisionEnvironment) string { return e.Name })
+	if err != nil || environment == nil {
+		return nil, err
+	}
+	// the list endpoint omits related objects, read the details
+	environment, err = getObject[EDADecisionEnvironment](c, objectEndpoint(edaAPIPath+"decision-environments/", environment.Id))
+	return environment.resolveRefs(), err
+}
+
+// resolveRefs fills the id fields from the nested objects returned by detail endpoints.
+func (e *EDADecisionEnvironment) resolveRefs() *EDADecisionEnvironment {
+	if e == nil {
+		return nil
+	}
+	if e.Organization != nil {
+		e.OrganizationId = &e.Organization.Id
+	}
+	if e.EDACredential != nil {
+		e.EDACredentialId = &e.EDACredential.Id
+	}
+	e.Organization, e.EDACredential = nil, nil
+	return e
+}
+
+// EDA event stream
+type EDAEventStream struct {
+	Id                    int64   `json:"id,omitempty"`
+	Name                  string  `json:"name"`
+	OrganizationId        int64   `json:"organization_id"`
+	EDACredentialId       int64   `json:"eda_credential_id"`
+	TestMode              bool    `json:"test_mode"`
+	AdditionalDataHeaders string  `json:"additional_data_headers"`
+	EventStreamType       string  `json:"event_stream_type,omitempty"`
+	URL                   string  `json:"url,omitempty"`
+	Organization          *edaRef `json:"organization,omitempty"`
+	EDACredential         *edaRef `json:"eda_credential,omitempty"`
+}
+
+// resolveRefs fills the id fields from the nested objects returned by detail endpoints.
+func (s *EDAEventStream) resolveRefs() *EDAEventStream {
+	if s == nil {
+		return nil
+	}
+	if s.Organization != nil {
+		s.OrganizationId = s.Organization.Id
+	}
+	if s.EDACredential != nil {
+		s.EDACredentialId = s.EDACredential.Id
+	}
+	s.Organization, s.EDACredential = nil, nil
+	return s
+}
+
+func (c *AAPClient) GetEDAEventStream(id int64) (*EDAEventStream, error) {
+	stream, err := getObject[EDAEventStream](c, objectEndpoint(edaAPIPath+"event-streams/", id))
+	return stream.resolveRefs(), err
+}
+
+func (c *AAPClient) CreateEDAEventStream(stream EDAEventStream) (*EDAEventStream, error) {
+	created, err := createObject(c, edaAPIPath+"event-streams/", stream)
+	return created.resolveRefs(), err
+}
+
+func (c *AAPClient) UpdateEDAEventStream(id int64, stream EDAEventStream) (*EDAEventStream, error) {
+	updated, err := updateObject(c, http.MethodPatch, objectEndpoint(edaAPIPath+"event-streams/", id), stream)
+	return updated.resolveRefs(), err
+}
+
+func (c *AAPClient) DeleteEDAEventStream(id int64) error {
+	return c.deleteObject(objectEndpoint(edaAPIPath+"event-streams/", id))
+}
+
+// GetEDAEventStreamByName returns the event stream with the given name, or nil if there is none.
+func (c *AAPClient) GetEDAEventStreamByName(name string) (*EDAEventStream, error) {
+	return findByName(c, edaAPIPath+"event-streams/", name, func(s EDAEventStream) string { return s.Name })
+}
@@ -0,0 +1,250 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &edaCredentialResource{}
+	_ resource.ResourceWithConfigure   = &edaCredentialResource{}
+	_ resource.ResourceWithImportState = &edaCredentialResource{}
+)
+
+// NewEDACredentialResource is a helper function to simplify the provider implementation.
+func NewEDACredentialResource() resource.Resource {
+	return &edaCredentialResource{}
+}
+
+// edaCredentialResource manages an Event-Driven Ansible credential.
+type edaCredentialResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *edaCredentialResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_eda_credential"
+}
+
+// Schema defines the schema for the resource.
+func (r *edaCredentialResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an Event-Driven Ansible credential, e.g. for source control, registries or event streams.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the EDA credential.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the EDA credential.",
+				Validators:  nameValidators(),
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Description of the EDA credential.",
+			},
+			"credential_type_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the EDA credential type. Changing it recreates the credential.",
+				Validators:  idValidators(),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"organization_id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the organization owning the credential (AAP 2.5 and later).",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: idValidators(),
+			},
+			"inputs": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "Inputs of the credential as a JSON document. AAP never returns secret inputs, so changes made outside Terraform are not detected.",
+			},
+		},
+	}
+}
+
+// edaCredentialResourceModel maps the resource schema data.
+type edaCredentialResourceModel struct {
+	Id               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+	CredentialTypeId types.Int64  `tfsdk:"credential_type_id"`
+	OrganizationId   types.Int64  `tfsdk:"organization_id"`
+	Inputs           types.String `tfsdk:"inputs"`
+}
+
+// edaCredentialAPIFields maps the fields of the EDA credential API to the attributes they are set from.
+var edaCredentialAPIFields = map[string]string{
+	"name":               "name",
+	"description":        "description",
+	"credential_type_id": "credential_type_id",
+	"organization_id":    "organization_id",
+	"inputs":             "inputs",
+}
+
+func (m *edaCredentialResourceModel) credential() (EDACredential, error) {
+	var inputs map[string]interface{}
+	if err := json.Unmarshal([]byte(m.Inputs.ValueString()), &inputs); err != nil {
+		return EDACredential{}, fmt.Errorf("inputs must be a JSON object: %w", err)
+	}
+	return EDACredential{
+		Name:             m.Name.ValueString(),
+		Description:      m.Description.ValueString(),
+		CredentialTypeId: m.CredentialTypeId.ValueInt64(),
+		OrganizationId:   m.OrganizationId.ValueInt64Pointer(),
+		Inputs:           inputs,
+	}, nil
+}
+
+// setCredential updates the model from the API response, leaving the write-only inputs untouched.
+func (m *edaCredentialResourceModel) setCredential(credential *EDACredential) {
+	m.Id = types.Int64Value(credential.Id)
+	m.Name = types.StringValue(credential.Name)
+	m.Description = types.StringValue(credential.Description)
+	m.CredentialTypeId = types.Int64Value(credential.CredentialTypeId)
+	m.OrganizationId = types.Int64PointerValue(credential.OrganizationId)
+}
+
+// Create creates the EDA credential.
+func (r *edaCredentialResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_credential", "create", &resp.Diagnostics)
+	var plan edaCredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, err := plan.credential()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("inputs"), "Invalid credential inputs", err.Error())
+		return
+	}
+
+	credential, err := r.client.CreateEDACredential(payload)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create EDA credential", err, edaCredentialAPIFields)...)
+		return
+	}
+	plan.setCredential(credential)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *edaCredentialResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_credential", "read", &resp.Diagnostics)
+	var state edaCredentialResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	credential, err := r.client.GetEDACredential(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read EDA credential", err.Error())
+		return
+	}
+	if credential == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setCredential(credential)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the EDA credential.
+func (r *edaCredentialResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_credential", "update", &resp.Diagnostics)
+	var plan edaCredentialResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	payload, err := plan.credential()
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("inputs"), "Invalid credential inputs", err.Error())
+		return
+	}
+
+	credential, err := r.client.UpdateEDACredential(plan.Id.ValueInt64(), payload)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update EDA credential", err, edaCredentialAPIFields)...)
+		return
+	}
+	plan.setCredential(credential)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the EDA credential.
+func (r *edaCredentialResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_credential", "delete", &resp.Diagnostics)
+	var state edaCredentialResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteEDACredential(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete EDA credential", err.Error())
+	}
+}
+
+// ImportState imports an EDA credential by id or name. Inputs have to be set in the configuration afterwards.
+func (r *edaCredentialResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(req.ID, "EDA credential", func(name string) (*int64, error) {
+		credential, err := r.client.GetEDACredentialByName(name)
+		if err != nil || credential == nil {
+			return nil, err
+		}
+		return &credential.Id, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *edaCredentialResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestEDACredentialResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/eda/v1/organizations", map[string]any{"name": "Default"})
+	scmType := mock.addObject("api/eda/v1/credential-types", map[string]any{"name": "Source Control", "kind": "scm"})
+
+	p := newTestProvider(t, mock, nil)
+	credential := p.resource("aap_eda_credential")
+	config := map[string]any{
+		"name": "git", "organization_id": organization, "credential_type_id": scmType,
+		"inputs": `{"username": "bot", "password": "secret"}`,
+	}
+	state := credential.apply(config)
+	testExpect(t, state, map[string]any{"credential_type_id": scmType, "organization_id": organization, "description": ""})
+	id := state["id"].(int64)
+	testExpect(t, mock.object("api/eda/v1/eda-credentials", id), map[string]any{"inputs": map[string]any{"username": "bot", "password": "secret"}})
+
+	credential.read()
+	if changes := credential.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["inputs"] = `{"username": "bot", "password": "rotated"}`
+	credential.apply(config)
+	testExpect(t, mock.object("api/eda/v1/eda-credentials", id), map[string]any{"inputs": map[string]any{"username": "bot", "password": "rotated"}})
+
+	imported := p.resource("aap_eda_credential")
+	testExpect(t, imported.importState("git"), map[string]any{"id": id, "credential_type_id": scmType, "inputs": nil})
+
+	credential.destroy()
+	if mock.object("api/eda/v1/eda-credentials", id) != nil {
+		t.Errorf("EDA credential %d still exists after destroy", id)
+	}
+}
@@ -0,0 +1,125 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &edaDecisionEnvironmentDataSource{}
+	_ datasource.DataSourceWithConfigure = &edaDecisionEnvironmentDataSource{}
+)
+
+// NewEDADecisionEnvironmentDataSource is a helper function to simplify the provider implementation.
+func NewEDADecisionEnvironmentDataSource() datasource.DataSource {
+	return &edaDecisionEnvironmentDataSource{}
+}
+
+// edaDecisionEnvironmentDataSource looks up an EDA decision environment.
+type edaDecisionEnvironmentDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *edaDecisionEnvironmentDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_eda_decision_environment"
+}
+
+// Schema defines the schema for the data source.
+func (d *edaDecisionEnvironmentDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an Event-Driven Ansible decision environment by name.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the decision environment.",
+				Validators:  nameValidators(),
+			},
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the decision environment.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "Description of the decision environment.",
+			},
+			"image_url": schema.StringAttribute{
+				Computed:    true,
+				Description: "Container image of the decision environment.",
+			},
+			"organization_id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the organization owning the decision environment.",
+			},
+			"credential_id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the EDA credential used to pull the image.",
+			},
+		},
+	}
+}
+
+// edaDecisionEnvironmentDataSourceModel maps the data source schema data.
+type edaDecisionEnvironmentDataSourceModel struct {
+	Name           types.String `tfsdk:"name"`
+	Id             types.Int64  `tfsdk:"id"`
+	Description    types.String `tfsdk:"description"`
+	ImageURL       types.String `tfsdk:"image_url"`
+	OrganizationId types.Int64  `tfsdk:"organization_id"`
+	CredentialId   types.Int64  `tfsdk:"credential_id"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *edaDecisionEnvironmentDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_eda_decision_environment", "read", &resp.Diagnostics)
+	var state edaDecisionEnvironmentDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, err := d.client.GetEDADecisionEnvironmentByName(state.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read EDA decision environments", err.Error())
+		return
+	}
+	if environment == nil {
+		resp.Diagnostics.AddError(
+			"Unable to find EDA decision environment",
+			fmt.Sprintf("No decision environment named %q exists.", state.Name.ValueString()),
+		)
+		return
+	}
+
+	state.Id = types.Int64Value(environment.Id)
+	state.Description = types.StringValue(environment.Description)
+	state.ImageURL = types.StringValue(environment.ImageURL)
+	state.OrganizationId = types.Int64PointerValue(environment.OrganizationId)
+	state.CredentialId = types.Int64PointerValue(environment.EDACredentialId)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *edaDecisionEnvironmentDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestEDADecisionEnvironmentDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject(edaAPIPath+"organizations", map[string]any{"name": "Default"})
+	credential := mock.addObject(edaAPIPath+"eda-credentials", map[string]any{"name": "Registry", "organization_id": organization})
+	environment := mock.addObject(edaAPIPath+"decision-environments", map[string]any{
+		"name": "Default DE", "description": "Upstream image", "image_url": "quay.io/ansible/ansible-rulebook:main",
+		"organization_id": organization, "eda_credential_id": credential,
+	})
+	public := mock.addObject(edaAPIPath+"decision-environments", map[string]any{
+		"name": "Public DE", "description": "", "image_url": "quay.io/ansible/ansible-rulebook:latest",
+		"organization_id": organization, "eda_credential_id": nil,
+	})
+
+	// the organization and credential are read from the objects the detail endpoint nests
+	p := newTestProvider(t, mock, nil)
+	testExpect(t, p.readDataSource("aap_eda_decision_environment", map[string]any{"name": "Default DE"}), map[string]any{
+		"id": environment, "name": "Default DE", "description": "Upstream image", "image_url": "quay.io/ansible/ansible-rulebook:main",
+		"organization_id": organization, "credential_id": credential,
+	})
+	testExpect(t, p.readDataSource("aap_eda_decision_environment", map[string]any{"name": "Public DE"}), map[string]any{
+		"id": public, "organization_id": organization, "credential_id": nil,
+	})
+
+	if _, errors := p.tryReadDataSource("aap_eda_decision_environment", map[string]any{"name": "Missing DE"}); errors == "" {
+		t.Error("reading an unknown decision environment did not fail")
+	}
+}
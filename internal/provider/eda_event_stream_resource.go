@@ -0,0 +1,444 @@
+package provider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &edaEventStreamResource{}
+	_ resource.ResourceWithConfigure   = &edaEventStreamResource{}
+	_ resource.ResourceWithImportState = &edaEventStreamResource{}
+	_ resource.ResourceWithModifyPlan  = &edaEventStreamResource{}
+)
+
+// tokenEventStreamCredentialType is the EDA credential type used for generated event stream secrets.
+const tokenEventStreamCredentialType string = "Token Event Stream"
+
+// NewEDAEventStreamResource is a helper function to simplify the provider implementation.
+func NewEDAEventStreamResource() resource.Resource {
+	return &edaEventStreamResource{}
+}
+
+// edaEventStreamResource manages an Event-Driven Ansible event stream (AAP 2.5 and later).
+type edaEventStreamResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *edaEventStreamResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_eda_event_stream"
+}
+
+// Schema defines the schema for the resource.
+func (r *edaEventStreamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an Event-Driven Ansible event stream receiving webhook events. " +
+			"Unless credential_id is set, a token credential is created for the stream and its secret is exposed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the event stream.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the event stream.",
+				Validators:  nameValidators(),
+			},
+			"organization_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the organization owning the event stream.",
+				Validators:  idValidators(),
+			},
+			"credential_id": schema.Int64Attribute{
+				Optional: true,
+				Computed: true,
+				Description: "Id of an existing event stream EDA credential. When omitted a token credential is generated, " +
+					"also when credential_id is removed from the configuration or the stream was imported.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: idValidators(),
+			},
+			"header_key": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("Authorization"),
+				Description: "HTTP header carrying the token of the generated credential.",
+			},
+			"secret": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "Token of the generated credential. A random token is generated when omitted.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"test_mode": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether received events are only recorded for inspection instead of being forwarded to activations.",
+			},
+			"generated_credential_id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the credential created for the event stream, if any.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"event_stream_type": schema.StringAttribute{
+				Computed:    true,
+				Description: "Type of the event stream, derived from its credential.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"url": schema.StringAttribute{
+				Computed:    true,
+				Description: "Webhook URL that senders post events to.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// edaEventStreamResourceModel maps the resource schema data.
+type edaEventStreamResourceModel struct {
+	Id                    types.Int64  `tfsdk:"id"`
+	Name                  types.String `tfsdk:"name"`
+	OrganizationId        types.Int64  `tfsdk:"organization_id"`
+	CredentialId          types.Int64  `tfsdk:"credential_id"`
+	HeaderKey             types.String `tfsdk:"header_key"`
+	Secret                types.String `tfsdk:"secret"`
+	TestMode              types.Bool   `tfsdk:"test_mode"`
+	GeneratedCredentialId types.Int64  `tfsdk:"generated_credential_id"`
+	EventStreamType       types.String `tfsdk:"event_stream_type"`
+	URL                   types.String `tfsdk:"url"`
+}
+
+// edaEventStreamAPIFields maps the fields of the EDA event stream API to the attributes they are set from.
+var edaEventStreamAPIFields = map[string]string{
+	"name":              "name",
+	"organization_id":   "organization_id",
+	"eda_credential_id": "credential_id",
+	"test_mode":         "test_mode",
+}
+
+func (m *edaEventStreamResourceModel) eventStream() EDAEventStream {
+	return EDAEventStream{
+		Name:            m.Name.ValueString(),
+		OrganizationId:  m.OrganizationId.ValueInt64(),
+		EDACredentialId: m.CredentialId.ValueInt64(),
+		TestMode:        m.TestMode.ValueBool(),
+	}
+}
+
+func (m *edaEventStreamResourceModel) setEventStream(stream *EDAEventStream) {
+	m.Id = types.Int64Value(stream.Id)
+	m.Name = types.StringValue(stream.Name)
+	m.OrganizationId = types.Int64Value(stream.OrganizationId)
+	m.CredentialId = types.Int64Value(stream.EDACredentialId)
+	m.TestMode = types.BoolValue(stream.TestMode)
+	m.EventStreamType = types.StringValue(stream.EventStreamType)
+	m.URL = types.StringValue(stream.URL)
+}
+
+// ModifyPlan plans the attributes following the credential of the event stream when it changes: its type, and the
+// generated credential and its secret, which are deleted once the stream uses another credential and generated
+// again once credential_id is removed from the configuration.
+func (r *edaEventStreamResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state edaEventStreamResourceModel
+	var secret types.String
+	var credentialId types.Int64
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secret"), &secret)...)
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("credential_id"), &credentialId)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// UseStateForUnknown keeps the credential the stream was given once credential_id is removed
+	if credentialId.IsNull() && state.GeneratedCredentialId.IsNull() {
+		plan.CredentialId = types.Int64Unknown()
+	}
+	if plan.CredentialId.Equal(state.CredentialId) {
+		return
+	}
+
+	plan.EventStreamType = types.StringUnknown()
+	if !state.GeneratedCredentialId.IsNull() {
+		plan.GeneratedCredentialId = types.Int64Null()
+		if plan.CredentialId.IsUnknown() {
+			plan.GeneratedCredentialId = types.Int64Unknown()
+		}
+		if secret.IsNull() {
+			plan.Secret = types.StringNull()
+			if plan.CredentialId.IsUnknown() {
+				plan.Secret = types.StringUnknown()
+			}
+		}
+	} else if credentialId.IsNull() {
+		plan.GeneratedCredentialId = types.Int64Unknown()
+		if secret.IsNull() {
+			plan.Secret = types.StringUnknown()
+		}
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// credentialInputs returns the inputs of the generated token credential.
+func (m *edaEventStreamResourceModel) credentialInputs() map[string]interface{} {
+	return map[string]interface{}{
+		"auth_type":       "token",
+		"token":           m.Secret.ValueString(),
+		"http_header_key": m.HeaderKey.ValueString(),
+	}
+}
+
+// generateSecret returns a random hex encoded token.
+func generateSecret() (string, error) {
+	data := make([]byte, 32)
+	if _, err := rand.Read(data); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(data), nil
+}
+
+// createCredential creates the token credential of the event stream.
+func (r *edaEventStreamResource) createCredential(plan *edaEventStreamResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if plan.Secret.IsUnknown() || plan.Secret.IsNull() {
+		secret, err := generateSecret()
+		if err != nil {
+			diags.AddError("Unable to generate event stream secret", err.Error())
+			return diags
+		}
+		plan.Secret = types.StringValue(secret)
+	}
+
+	credentialType, err := r.client.GetEDACredentialTypeByName(tokenEventStreamCredentialType)
+	if err != nil {
+		diags.AddError("Unable to read EDA credential types", err.Error())
+		return diags
+	}
+	if credentialType == nil {
+		diags.AddError(
+			"Missing EDA credential type",
+			fmt.Sprintf("The %q credential type does not exist, event streams require AAP 2.5 or later.", tokenEventStreamCredentialType),
+		)
+		return diags
+	}
+
+	organizationId := plan.OrganizationId.ValueInt64()
+	credential, err := r.client.CreateEDACredential(EDACredential{
+		Name:             plan.Name.ValueString() + " event stream",
+		CredentialTypeId: credentialType.Id,
+		OrganizationId:   &organizationId,
+		Inputs:           plan.credentialInputs(),
+	})
+	if err != nil {
+		diags.AddError("Unable to create event stream credential", err.Error())
+		return diags
+	}
+	plan.GeneratedCredentialId = types.Int64Value(credential.Id)
+	plan.CredentialId = types.Int64Value(credential.Id)
+	return diags
+}
+
+// Create creates the event stream, generating its credential when needed.
+func (r *edaEventStreamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_event_stream", "create", &resp.Diagnostics)
+	var plan edaEventStreamResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.GeneratedCredentialId = types.Int64Null()
+	if plan.CredentialId.IsUnknown() || plan.CredentialId.IsNull() {
+		resp.Diagnostics.Append(r.createCredential(&plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if plan.Secret.IsUnknown() {
+		plan.Secret = types.StringNull()
+	}
+
+	stream, err := r.client.CreateEDAEventStream(plan.eventStream())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create EDA event stream", err, edaEventStreamAPIFields)...)
+		if !plan.GeneratedCredentialId.IsNull() {
+			// do not leak the generated credential
+			_ = r.client.DeleteEDACredential(plan.GeneratedCredentialId.ValueInt64())
+		}
+		return
+	}
+	plan.setEventStream(stream)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *edaEventStreamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_event_stream", "read", &resp.Diagnostics)
+	var state edaEventStreamResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	stream, err := r.client.GetEDAEventStream(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read EDA event stream", err.Error())
+		return
+	}
+	if stream == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setEventStream(stream)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the event stream and the secret of its generated credential.
+func (r *edaEventStreamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_event_stream", "update", &resp.Diagnostics)
+	var plan, state edaEventStreamResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.CredentialId.IsUnknown() {
+		// credential_id was removed from the configuration
+		resp.Diagnostics.Append(r.createCredential(&plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else if !state.GeneratedCredentialId.IsNull() && plan.CredentialId.Equal(state.GeneratedCredentialId) {
+		if plan.Secret.IsUnknown() || plan.Secret.IsNull() {
+			plan.Secret = state.Secret
+		}
+		// the credential is named after the stream
+		if !plan.Secret.Equal(state.Secret) || !plan.HeaderKey.Equal(state.HeaderKey) || !plan.Name.Equal(state.Name) {
+			_, err := r.client.UpdateEDACredential(state.GeneratedCredentialId.ValueInt64(), EDACredential{
+				Name:   plan.Name.ValueString() + " event stream",
+				Inputs: plan.credentialInputs(),
+			})
+			if err != nil {
+				resp.Diagnostics.AddError("Unable to update event stream credential", err.Error())
+				return
+			}
+		}
+	}
+
+	stream, err := r.client.UpdateEDAEventStream(plan.Id.ValueInt64(), plan.eventStream())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update EDA event stream", err, edaEventStreamAPIFields)...)
+		if !plan.GeneratedCredentialId.IsNull() && !plan.GeneratedCredentialId.Equal(state.GeneratedCredentialId) {
+			// do not leak the generated credential
+			_ = r.client.DeleteEDACredential(plan.GeneratedCredentialId.ValueInt64())
+		}
+		return
+	}
+	plan.setEventStream(stream)
+
+	// the generated credential is no longer needed once the stream uses another one
+	if !state.GeneratedCredentialId.IsNull() && !plan.CredentialId.Equal(state.GeneratedCredentialId) {
+		if err := r.client.DeleteEDACredential(state.GeneratedCredentialId.ValueInt64()); err != nil {
+			resp.Diagnostics.AddError("Unable to delete event stream credential", err.Error())
+			return
+		}
+		plan.GeneratedCredentialId = types.Int64Null()
+		if plan.Secret.IsUnknown() {
+			plan.Secret = types.StringNull()
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the event stream and its generated credential.
+func (r *edaEventStreamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_event_stream", "delete", &resp.Diagnostics)
+	var state edaEventStreamResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteEDAEventStream(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete EDA event stream", err.Error())
+		return
+	}
+
+	if !state.GeneratedCredentialId.IsNull() {
+		if err := r.client.DeleteEDACredential(state.GeneratedCredentialId.ValueInt64()); err != nil {
+			resp.Diagnostics.AddError("Unable to delete event stream credential", err.Error())
+		}
+	}
+}
+
+// ImportState imports an event stream by id or name. The secret of an imported stream is not known.
+func (r *edaEventStreamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(req.ID, "event stream", func(name string) (*int64, error) {
+		stream, err := r.client.GetEDAEventStreamByName(name)
+		if err != nil || stream == nil {
+			return nil, err
+		}
+		return &stream.Id, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("header_key"), "Authorization")...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *edaEventStreamResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
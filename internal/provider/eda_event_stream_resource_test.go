@@ -0,0 +1,78 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestEDAEventStreamResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/eda/v1/organizations", map[string]any{"name": "Default"})
+	mock.addObject("api/eda/v1/credential-types", map[string]any{"name": "Token Event Stream", "kind": "token"})
+	hmacType := mock.addObject("api/eda/v1/credential-types", map[string]any{"name": "HMAC Event Stream", "kind": "hmac"})
+	hmac := mock.addObject("api/eda/v1/eda-credentials", map[string]any{"name": "github", "credential_type_id": hmacType, "organization_id": organization})
+
+	p := newTestProvider(t, mock, nil)
+	stream := p.resource("aap_eda_event_stream")
+	config := map[string]any{"name": "alerts", "organization_id": organization}
+	state := stream.apply(config)
+	id := state["id"].(int64)
+	generated, _ := state["generated_credential_id"].(int64)
+	testExpect(t, state, map[string]any{
+		"credential_id": generated, "header_key": "Authorization", "test_mode": false, "event_stream_type": "token",
+		"url": fmt.Sprintf("%s/eda-event-streams/api/eda/v1/external_event_stream/%d/post/", mock.URL(), id),
+	})
+	secret, _ := state["secret"].(string)
+	if len(secret) != 64 {
+		t.Errorf("generated secret %q is not 32 hex encoded bytes", secret)
+	}
+	testExpect(t, mock.object("api/eda/v1/eda-credentials", generated), map[string]any{
+		"name":   "alerts event stream",
+		"inputs": map[string]any{"auth_type": "token", "token": secret, "http_header_key": "Authorization"},
+	})
+
+	stream.read()
+	if changes := stream.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["header_key"] = "X-Token"
+	stream.apply(config)
+	testExpect(t, mock.object("api/eda/v1/eda-credentials", generated), map[string]any{
+		"inputs": map[string]any{"auth_type": "token", "token": secret, "http_header_key": "X-Token"},
+	})
+
+	config["name"] = "paging"
+	stream.apply(config)
+	testExpect(t, mock.object("api/eda/v1/eda-credentials", generated), map[string]any{"name": "paging event stream"})
+
+	// using an existing credential deletes the generated one
+	config["credential_id"] = hmac
+	state = stream.apply(config)
+	testExpect(t, state, map[string]any{"credential_id": hmac, "generated_credential_id": nil, "secret": nil, "event_stream_type": "hmac"})
+	if mock.object("api/eda/v1/eda-credentials", generated) != nil {
+		t.Error("the generated credential was not deleted")
+	}
+
+	// removing credential_id generates a credential again
+	delete(config, "credential_id")
+	state = stream.apply(config)
+	generated, _ = state["generated_credential_id"].(int64)
+	secret, _ = state["secret"].(string)
+	testExpect(t, state, map[string]any{"credential_id": generated, "event_stream_type": "token"})
+	if generated == 0 || generated == hmac || len(secret) != 64 {
+		t.Errorf("removing credential_id gives credential %d and secret %q, expected a generated credential", generated, secret)
+	}
+	testExpect(t, mock.object("api/eda/v1/eda-credentials", generated), map[string]any{
+		"name":   "paging event stream",
+		"inputs": map[string]any{"auth_type": "token", "token": secret, "http_header_key": "X-Token"},
+	})
+
+	stream.destroy()
+	if mock.object("api/eda/v1/event-streams", id) != nil {
+		t.Errorf("event stream %d still exists after destroy", id)
+	}
+	if mock.object("api/eda/v1/eda-credentials", hmac) == nil {
+		t.Error("destroying the event stream deleted the credential it was given")
+	}
+}
@@ -0,0 +1,276 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &edaProjectResource{}
+	_ resource.ResourceWithConfigure   = &edaProjectResource{}
+	_ resource.ResourceWithImportState = &edaProjectResource{}
+)
+
+// NewEDAProjectResource is a helper function to simplify the provider implementation.
+func NewEDAProjectResource() resource.Resource {
+	return &edaProjectResource{}
+}
+
+// edaProjectResource manages an Event-Driven Ansible project.
+type edaProjectResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *edaProjectResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_eda_project"
+}
+
+// Schema defines the schema for the resource.
+func (r *edaProjectResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an Event-Driven Ansible project, a git repository holding rulebooks.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the EDA project.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the EDA project.",
+				Validators:  nameValidators(),
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Description of the EDA project.",
+			},
+			"url": schema.StringAttribute{
+				Required:    true,
+				Description: "URL of the git repository.",
+				Validators:  scmURLValidators(),
+			},
+			"organization_id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the organization owning the project (AAP 2.5 and later).",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+				Validators: idValidators(),
+			},
+			"credential_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Id of the EDA credential used to access the repository.",
+				Validators:  idValidators(),
+			},
+			"scm_branch": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Branch, tag or commit to check out, defaults to the repository default branch.",
+			},
+			"verify_ssl": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether to verify the TLS certificate of the repository.",
+			},
+			"import_state": schema.StringAttribute{
+				Computed:    true,
+				Description: "State of the last import of the project.",
+			},
+			"git_hash": schema.StringAttribute{
+				Computed:    true,
+				Description: "Commit of the last import of the project.",
+			},
+		},
+	}
+}
+
+// edaProjectResourceModel maps the resource schema data.
+type edaProjectResourceModel struct {
+	Id             types.Int64  `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	URL            types.String `tfsdk:"url"`
+	OrganizationId types.Int64  `tfsdk:"organization_id"`
+	CredentialId   types.Int64  `tfsdk:"credential_id"`
+	ScmBranch      types.String `tfsdk:"scm_branch"`
+	VerifySSL      types.Bool   `tfsdk:"verify_ssl"`
+	ImportState    types.String `tfsdk:"import_state"`
+	GitHash        types.String `tfsdk:"git_hash"`
+}
+
+// edaProjectAPIFields maps the fields of the EDA project API to the attributes they are set from.
+var edaProjectAPIFields = map[string]string{
+	"name":              "name",
+	"description":       "description",
+	"url":               "url",
+	"organization_id":   "organization_id",
+	"eda_credential_id": "credential_id",
+	"scm_branch":        "scm_branch",
+	"verify_ssl":        "verify_ssl",
+}
+
+func (m *edaProjectResourceModel) project() EDAProject {
+	return EDAProject{
+		Name:            m.Name.ValueString(),
+		Description:     m.Description.ValueString(),
+		URL:             m.URL.ValueString(),
+		OrganizationId:  m.OrganizationId.ValueInt64Pointer(),
+		EDACredentialId: m.CredentialId.ValueInt64Pointer(),
+		ScmBranch:       m.ScmBranch.ValueString(),
+		VerifySSL:       m.VerifySSL.ValueBool(),
+	}
+}
+
+func (m *edaProjectResourceModel) setProject(project *EDAProject) {
+	m.Id = types.Int64Value(project.Id)
+	m.Name = types.StringValue(project.Name)
+	m.Description = types.StringValue(project.Description)
+	m.URL = types.StringValue(project.URL)
+	m.OrganizationId = types.Int64PointerValue(project.OrganizationId)
+	m.CredentialId = types.Int64PointerValue(project.EDACredentialId)
+	m.ScmBranch = types.StringValue(project.ScmBranch)
+	m.VerifySSL = types.BoolValue(project.VerifySSL)
+	m.ImportState = types.StringValue(project.ImportState)
+	m.GitHash = types.StringValue(project.GitHash)
+}
+
+// Create creates the EDA project.
+func (r *edaProjectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_project", "create", &resp.Diagnostics)
+	var plan edaProjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if r.client.CheckExistingNames {
+		existing, err := r.client.GetEDAProjectByName(plan.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read EDA projects", err.Error())
+			return
+		}
+		if existing != nil {
+			resp.Diagnostics.Append(alreadyExistsDiagnostics("EDA project", existing.Name, strconv.FormatInt(existing.Id, 10))...)
+			return
+		}
+	}
+
+	project, err := r.client.CreateEDAProject(plan.project())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create EDA project", err, edaProjectAPIFields)...)
+		return
+	}
+	plan.setProject(project)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *edaProjectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_project", "read", &resp.Diagnostics)
+	var state edaProjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, err := r.client.GetEDAProject(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read EDA project", err.Error())
+		return
+	}
+	if project == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setProject(project)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the EDA project.
+func (r *edaProjectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_project", "update", &resp.Diagnostics)
+	var plan edaProjectResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, err := r.client.UpdateEDAProject(plan.Id.ValueInt64(), plan.project())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update EDA project", err, edaProjectAPIFields)...)
+		return
+	}
+	plan.setProject(project)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the EDA project.
+func (r *edaProjectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_eda_project", "delete", &resp.Diagnostics)
+	var state edaProjectResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteEDAProject(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete EDA project", err.Error())
+	}
+}
+
+// ImportState imports an EDA project by id or name.
+func (r *edaProjectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(req.ID, "EDA project", func(name string) (*int64, error) {
+		project, err := r.client.GetEDAProjectByName(name)
+		if err != nil || project == nil {
+			return nil, err
+		}
+		return &project.Id, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *edaProjectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestEDAProjectResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/eda/v1/organizations", map[string]any{"name": "Default"})
+	scm := mock.addObject("api/eda/v1/eda-credentials", map[string]any{"name": "git", "organization_id": organization})
+
+	p := newTestProvider(t, mock, nil)
+	project := p.resource("aap_eda_project")
+	config := map[string]any{
+		"name": "rulebooks", "url": "https://github.com/example/rulebooks.git", "organization_id": organization,
+		"credential_id": scm,
+	}
+	state := project.apply(config)
+	testExpect(t, state, map[string]any{
+		"organization_id": organization, "credential_id": scm,
+		"scm_branch": "", "verify_ssl": true, "import_state": "completed",
+	})
+	id := state["id"].(int64)
+
+	project.read()
+	if changes := project.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["scm_branch"] = "release"
+	state = project.apply(config)
+	testExpect(t, state, map[string]any{"credential_id": scm, "scm_branch": "release"})
+	testExpect(t, mock.object("api/eda/v1/projects", id), map[string]any{"scm_branch": "release"})
+
+	imported := p.resource("aap_eda_project")
+	testExpect(t, imported.importState("rulebooks"), map[string]any{
+		"id": id, "url": "https://github.com/example/rulebooks.git", "organization_id": organization, "credential_id": scm,
+	})
+
+	project.destroy()
+	if mock.object("api/eda/v1/projects", id) != nil {
+		t.Errorf("EDA project %d still exists after destroy", id)
+	}
+}
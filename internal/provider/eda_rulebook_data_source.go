@@ -0,0 +1,129 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &edaRulebookDataSource{}
+	_ datasource.DataSourceWithConfigure = &edaRulebookDataSource{}
+)
+
+// NewEDARulebookDataSource is a helper function to simplify the provider implementation.
+func NewEDARulebookDataSource() datasource.DataSource {
+	return &edaRulebookDataSource{}
+}
+
+// edaRulebookDataSource looks up a rulebook imported from an EDA project.
+type edaRulebookDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *edaRulebookDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_eda_rulebook"
+}
+
+// Schema defines the schema for the data source.
+func (d *edaRulebookDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Looks up an Event-Driven Ansible rulebook by name.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "File name of the rulebook, e.g. webhook.yml.",
+				Validators:  nameValidators(),
+			},
+			"project_id": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Id of the EDA project the rulebook belongs to. Required when several projects hold a rulebook with that name.",
+				Validators:  idValidators(),
+			},
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the rulebook.",
+			},
+			"description": schema.StringAttribute{
+				Computed:    true,
+				Description: "Description of the rulebook.",
+			},
+			"rulesets": schema.StringAttribute{
+				Computed:    true,
+				Description: "Content of the rulebook.",
+			},
+		},
+	}
+}
+
+// edaRulebookDataSourceModel maps the data source schema data.
+type edaRulebookDataSourceModel struct {
+	Name        types.String `tfsdk:"name"`
+	ProjectId   types.Int64  `tfsdk:"project_id"`
+	Id          types.Int64  `tfsdk:"id"`
+	Description types.String `tfsdk:"description"`
+	Rulesets    types.String `tfsdk:"rulesets"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *edaRulebookDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_eda_rulebook", "read", &resp.Diagnostics)
+	var state edaRulebookDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	rulebooks, err := d.client.GetEDARulebooks(state.Name.ValueString(), state.ProjectId.ValueInt64Pointer())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read EDA rulebooks", err.Error())
+		return
+	}
+
+	var matches []EDARulebook
+	for _, rulebook := range rulebooks {
+		if rulebook.Name == state.Name.ValueString() {
+			matches = append(matches, rulebook)
+		}
+	}
+	if len(matches) != 1 {
+		resp.Diagnostics.AddError(
+			"Unable to find EDA rulebook",
+			fmt.Sprintf("Expected exactly one rulebook named %q, found %d. Set project_id to choose between projects.", state.Name.ValueString(), len(matches)),
+		)
+		return
+	}
+
+	rulebook := matches[0]
+	state.Id = types.Int64Value(rulebook.Id)
+	state.ProjectId = types.Int64PointerValue(rulebook.ProjectId)
+	state.Description = types.StringValue(rulebook.Description)
+	state.Rulesets = types.StringValue(rulebook.Rulesets)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *edaRulebookDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEDARulebookDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	webhooks := mock.addObject(edaAPIPath+"projects", map[string]any{"name": "Webhooks"})
+	alerts := mock.addObject(edaAPIPath+"projects", map[string]any{"name": "Alerts"})
+	rulebook := mock.addObject(edaAPIPath+"rulebooks", map[string]any{
+		"name": "webhook.yml", "description": "Listens for webhooks", "project_id": webhooks, "rulesets": "- name: Webhook\n",
+	})
+	mock.addObject(edaAPIPath+"rulebooks", map[string]any{"name": "alerts.yml", "description": "", "project_id": alerts, "rulesets": "- name: Alerts\n"})
+
+	p := newTestProvider(t, mock, nil)
+	testExpect(t, p.readDataSource("aap_eda_rulebook", map[string]any{"name": "webhook.yml"}), map[string]any{
+		"id": rulebook, "name": "webhook.yml", "project_id": webhooks, "description": "Listens for webhooks", "rulesets": "- name: Webhook\n",
+	})
+
+	// a rulebook name held by several projects needs the project to be given
+	copied := mock.addObject(edaAPIPath+"rulebooks", map[string]any{"name": "webhook.yml", "description": "", "project_id": alerts, "rulesets": "- name: Copy\n"})
+	if _, errors := p.tryReadDataSource("aap_eda_rulebook", map[string]any{"name": "webhook.yml"}); !strings.Contains(errors, "found 2") {
+		t.Errorf("errors = %q, expected the rulebook name to be ambiguous", errors)
+	}
+	testExpect(t, p.readDataSource("aap_eda_rulebook", map[string]any{"name": "webhook.yml", "project_id": alerts}), map[string]any{
+		"id": copied, "project_id": alerts, "rulesets": "- name: Copy\n",
+	})
+
+	if _, errors := p.tryReadDataSource("aap_eda_rulebook", map[string]any{"name": "alerts.yml", "project_id": webhooks}); !strings.Contains(errors, "found 0") {
+		t.Errorf("errors = %q, expected no rulebook to be found in the project", errors)
+	}
+}
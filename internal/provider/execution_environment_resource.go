@@ -0,0 +1,271 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &executionEnvironmentResource{}
+	_ resource.ResourceWithConfigure   = &executionEnvironmentResource{}
+	_ resource.ResourceWithImportState = &executionEnvironmentResource{}
+	_ resource.ResourceWithModifyPlan  = &executionEnvironmentResource{}
+)
+
+// executionEnvironmentPullPolicies are the policies of AAP for pulling the image of an execution environment before running a job.
+var executionEnvironmentPullPolicies = []string{"always", "missing", "never"}
+
+// NewExecutionEnvironmentResource is a helper function to simplify the provider implementation.
+func NewExecutionEnvironmentResource() resource.Resource {
+	return &executionEnvironmentResource{}
+}
+
+// executionEnvironmentResource manages an automation controller execution environment.
+type executionEnvironmentResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *executionEnvironmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_execution_environment"
+}
+
+// Schema defines the schema for the resource.
+func (r *executionEnvironmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an automation controller execution environment, the container image jobs run in.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the execution environment.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the execution environment.",
+				Validators:  nameValidators(),
+			},
+			"image": schema.StringAttribute{
+				Required:    true,
+				Description: "Full reference of the container image, e.g. quay.io/ansible/awx-ee:latest.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Description of the execution environment.",
+			},
+			"organization_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Id of the organization the execution environment is restricted to. Without it, every organization can use it.",
+				Validators:  idValidators(),
+			},
+			"credential_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Id of the container registry credential used to pull the image. Credentials of another kind are rejected when planning.",
+				Validators:  idValidators(),
+			},
+			"pull": schema.StringAttribute{
+				Optional: true,
+				Description: "When the image is pulled before running a job: always, missing (only when it is not present) or never. " +
+					"Without it, the default policy of the controller applies.",
+				Validators: []validator.String{stringvalidator.OneOf(executionEnvironmentPullPolicies...)},
+			},
+		},
+	}
+}
+
+// executionEnvironmentResourceModel maps the resource schema data.
+type executionEnvironmentResourceModel struct {
+	Id             types.Int64  `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Image          types.String `tfsdk:"image"`
+	Description    types.String `tfsdk:"description"`
+	OrganizationId types.Int64  `tfsdk:"organization_id"`
+	CredentialId   types.Int64  `tfsdk:"credential_id"`
+	Pull           types.String `tfsdk:"pull"`
+}
+
+// executionEnvironmentAPIFields maps the fields of the execution environment API to the attributes they are set from.
+var executionEnvironmentAPIFields = map[string]string{
+	"name":         "name",
+	"image":        "image",
+	"description":  "description",
+	"organization": "organization_id",
+	"credential":   "credential_id",
+	"pull":         "pull",
+}
+
+func (m *executionEnvironmentResourceModel) environment() AAPExecutionEnvironment {
+	return AAPExecutionEnvironment{
+		Name:         m.Name.ValueString(),
+		Image:        m.Image.ValueString(),
+		Description:  m.Description.ValueString(),
+		Organization: m.OrganizationId.ValueInt64Pointer(),
+		Credential:   m.CredentialId.ValueInt64Pointer(),
+		Pull:         m.Pull.ValueString(),
+	}
+}
+
+func (m *executionEnvironmentResourceModel) setEnvironment(environment *AAPExecutionEnvironment) {
+	m.Id = types.Int64Value(environment.Id)
+	m.Name = types.StringValue(environment.Name)
+	m.Image = types.StringValue(environment.Image)
+	m.Description = types.StringValue(environment.Description)
+	m.OrganizationId = types.Int64PointerValue(environment.Organization)
+	m.CredentialId = types.Int64PointerValue(environment.Credential)
+	m.Pull = optionalString(&environment.Pull, m.Pull)
+}
+
+// ModifyPlan rejects credentials that are not container registry credentials, which AAP only reports when pulling the image.
+func (r *executionEnvironmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	defer r.client.reportStats(ctx, "aap_execution_environment", "plan", &resp.Diagnostics)
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+
+	var credentialId types.Int64
+	resp.Diagnostics.Append(req.Plan.GetAttribute(ctx, path.Root("credential_id"), &credentialId)...)
+	if resp.Diagnostics.HasError() || credentialId.IsNull() || credentialId.IsUnknown() {
+		return
+	}
+
+	credential, err := r.client.GetCredential(credentialId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read credential", err.Error())
+		return
+	}
+	switch {
+	case credential == nil:
+		resp.Diagnostics.AddAttributeError(path.Root("credential_id"), "Invalid registry credential",
+			fmt.Sprintf("Credential %d does not exist.", credentialId.ValueInt64()))
+	case credential.Kind != "registry":
+		resp.Diagnostics.AddAttributeError(path.Root("credential_id"), "Invalid registry credential",
+			fmt.Sprintf("Credential %q is a %s credential, execution environments pull their image with a container registry credential.",
+				credential.Name, credential.Kind))
+	}
+}
+
+// Create creates the execution environment.
+func (r *executionEnvironmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_execution_environment", "create", &resp.Diagnostics)
+	var plan executionEnvironmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, err := r.client.CreateExecutionEnvironment(plan.environment())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create execution environment", err, executionEnvironmentAPIFields)...)
+		return
+	}
+	plan.setEnvironment(environment)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *executionEnvironmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_execution_environment", "read", &resp.Diagnostics)
+	var state executionEnvironmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, err := r.client.GetExecutionEnvironment(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read execution environment", err.Error())
+		return
+	}
+	if environment == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setEnvironment(environment)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the execution environment.
+func (r *executionEnvironmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_execution_environment", "update", &resp.Diagnostics)
+	var plan executionEnvironmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	environment, err := r.client.UpdateExecutionEnvironment(plan.Id.ValueInt64(), plan.environment())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update execution environment", err, executionEnvironmentAPIFields)...)
+		return
+	}
+	plan.setEnvironment(environment)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the execution environment.
+func (r *executionEnvironmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_execution_environment", "delete", &resp.Diagnostics)
+	var state executionEnvironmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteExecutionEnvironment(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete execution environment", err.Error())
+	}
+}
+
+// ImportState imports an execution environment by id or name.
+func (r *executionEnvironmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(req.ID, "execution environment", func(name string) (*int64, error) {
+		found, err := r.client.GetExecutionEnvironmentByName(name)
+		if err != nil || found == nil {
+			return nil, err
+		}
+		return &found.Id, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *executionEnvironmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExecutionEnvironmentResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	registry := mock.addObject("api/v2/credentials", map[string]any{"name": "quay", "kind": "registry"})
+	machine := mock.addObject("api/v2/credentials", map[string]any{"name": "ssh", "kind": "ssh"})
+
+	p := newTestProvider(t, mock, nil)
+	environment := p.resource("aap_execution_environment")
+	config := map[string]any{"name": "ee", "image": "quay.io/ansible/awx-ee:latest", "organization_id": organization, "credential_id": machine}
+	if message := environment.tryApply(config); !strings.Contains(message, `Credential "ssh" is a ssh credential`) {
+		t.Errorf("applying with a machine credential gives %q, expected it to be rejected", message)
+	}
+
+	config["credential_id"] = registry
+	state := environment.apply(config)
+	testExpect(t, state, map[string]any{"description": "", "pull": nil})
+	id := state["id"].(int64)
+	testExpect(t, mock.object("api/v2/execution_environments", id), map[string]any{
+		"name": "ee", "image": "quay.io/ansible/awx-ee:latest", "organization": float64(organization), "credential": float64(registry),
+	})
+
+	environment.read()
+	if changes := environment.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["pull"] = "missing"
+	environment.apply(config)
+	testExpect(t, mock.object("api/v2/execution_environments", id), map[string]any{"pull": "missing"})
+
+	imported := p.resource("aap_execution_environment")
+	testExpect(t, imported.importState("ee"), map[string]any{"id": id, "pull": "missing", "credential_id": registry})
+
+	environment.destroy()
+	if mock.object("api/v2/execution_environments", id) != nil {
+		t.Errorf("execution environment %d still exists after destroy", id)
+	}
+}
@@ -0,0 +1,206 @@
+package provider
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// gatewayAPIPath is the base path of the platform gateway API introduced in AAP 2.5.
+const gatewayAPIPath string = "api/gateway/v1/"
+
+// gateway organization
+type GatewayOrganization struct {
+	Id          int64  `json:"id,omitempty"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// gateway team
+type GatewayTeam struct {
+	Id           int64  `json:"id,omitempty"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Organization int64  `json:"organization"`
+}
+
+// gateway user
+type GatewayUser struct {
+	Id          int64   `json:"id,omitempty"`
+	Username    string  `json:"username"`
+	Email       string  `json:"email"`
+	FirstName   string  `json:"first_name"`
+	LastName    string  `json:"last_name"`
+	IsSuperuser bool    `json:"is_superuser"`
+	Password    *string `json:"password,omitempty"`
+}
+
+// gateway OAuth2 token, the token value is only returned on creation
+type GatewayToken struct {
+	Id          int64  `json:"id,omitempty"`
+	Description string `json:"description"`
+	Scope       string `json:"scope"`
+	Token       string `json:"token,omitempty"`
+	Expires     string `json:"expires,omitempty"`
+	Application *int64 `json:"application,omitempty"`
+	Created     string `json:"created,omitempty"`
+}
+
+// gateway role definition, content_type is null for roles granted across the whole platform
+type GatewayRoleDefinition struct {
+	Id          int64    `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	ContentType *string  `json:"content_type"`
+	Permissions []string `json:"permissions"`
+	Managed     bool     `json:"managed,omitempty"`
+}
+
+// gateway role assignment to a user or a team, object_id is null for roles granted across the whole platform
+type GatewayRoleAssignment struct {
+	Id             int64   `json:"id,omitempty"`
+	RoleDefinition int64   `json:"role_definition"`
+	User           *int64  `json:"user,omitempty"`
+	Team           *int64  `json:"team,omitempty"`
+	ObjectId       *string `json:"object_id,omitempty"`
+}
+
+func (c *AAPClient) GetGatewayOrganization(id int64) (*GatewayOrganization, error) {
+	return getObject[GatewayOrganization](c, objectEndpoint(gatewayAPIPath+"organizations/", id))
+}
+
+func (c *AAPClient) CreateGatewayOrganization(organization GatewayOrganization) (*GatewayOrganization, error) {
+	return createObject(c, gatewayAPIPath+"organizations/", organization)
+}
+
+func (c *AAPClient) UpdateGatewayOrganization(id int64, organization GatewayOrganization) (*GatewayOrganization, error) {
+	return updateObject(c, http.MethodPatch, objectEndpoint(gatewayAPIPath+"organizations/", id), organization)
+}
+
+func (c *AAPClient) DeleteGatewayOrganization(id int64) error {
+	return c.deleteObject(objectEndpoint(gatewayAPIPath+"organizations/", id))
+}
+
+// GetGatewayOrganizationByName returns the organization with the given name, or nil if there is none.
+func (c *AAPClient) GetGatewayOrganizationByName(name string) (*GatewayOrganization, error) {
+	return findByName(c, gatewayAPIPath+"organizations/", name, func(o GatewayOrganization) string { return o.Name })
+}
+
+func (c *AAPClient) GetGatewayTeam(id int64) (*GatewayTeam, error) {
+	return getObject[GatewayTeam](c, objectEndpoint(gatewayAPIPath+"teams/", id))
+}
+
+func (c *AAPClient) CreateGatewayTeam(team GatewayTeam) (*GatewayTeam, error) {
+	return createObject(c, gatewayAPIPath+"teams/", team)
+}
+
+func (c *AAPClient) UpdateGatewayTeam(id int64, team GatewayTeam) (*GatewayTeam, error) {
+	return updateObject(c, http.MethodPatch, objectEndpoint(gatewayAPIPath+"teams/", id), team)
+}
+
+func (c *AAPClient) DeleteGatewayTeam(id int64) error {
+	return c.deleteObject(objectEndpoint(gatewayAPIPath+"teams/", id))
+}
+
+// GetGatewayTeamByName returns the team with the given name in the named organization, or nil if there is none.
+func (c *AAPClient) GetGatewayTeamByName(organization string, name string) (*GatewayTeam, error) {
+	query := url.Values{"name": {name}, "organization__name": {organization}}
+	return findByQuery(c, gatewayAPIPath+"teams/", query, func(t GatewayTeam) bool { return t.Name == name })
+}
+
+func (c *AAPClient) GetGatewayUser(id int64) (*GatewayUser, error) {
+	return getObject[GatewayUser](c, objectEndpoint(gatewayAPIPath+"users/", id))
+}
+
+// GetGatewayUserByUsername returns the user with the given username, or nil if there is none.
+func (c *AAPClient) GetGatewayUserByUsername(username string) (*GatewayUser, error) {
+	query := url.Values{"username": {username}}
+	return findByQuery(c, gatewayAPIPath+"users/", query, func(u GatewayUser) bool { return u.Username == username })
+}
+
+func (c *AAPClient) CreateGatewayUser(user GatewayUser) (*GatewayUser, error) {
+	return createObject(c, gatewayAPIPath+"users/", user)
+}
+
+func (c *AAPClient) UpdateGatewayUser(id int64, user GatewayUser) (*GatewayUser, error) {
+	return updateObject(c, http.MethodPatch, objectEndpoint(gatewayAPIPath+"users/", id), user)
+}
+
+func (c *AAPClient) DeleteGatewayUser(id int64) error {
+	return c.deleteObject(objectEndpoint(gatewayAPIPath+"users/", id))
+}
+
+// CreateGatewayToken creates a token owned by the user the client authenticates as.
+func (c *AAPClient) CreateGatewayToken(token GatewayToken) (*GatewayToken, error) {
+	return createObject(c, gatewayAPIPath+"tokens/", token)
+}
+
+func (c *AAPClient) DeleteGatewayToken(id int64) error {
+	return c.deleteObject(objectEndpoint(gatewayAPIPath+"tokens/", id))
+}
+
+// GetGatewayCurrentUser returns the user the client authenticates as.
+func (c *AAPClient) GetGatewayCurrentUser() (*GatewayUser, error) {
+	// the me endpoint lists the current user as its only result
+	users, err := listAll[GatewayUser](c, gatewayAPIPath+"me/")
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("%sme/ returned no user", gatewayAPIPath)
+	}
+	return &users[0], nil
+}
+
+// GetGatewayUserTokens returns the tokens owned by the user; token values are never returned.
+func (c *AAPClient) GetGatewayUserTokens(userId int64) ([]GatewayToken, error) {
+	return listAll[GatewayToken](c, objectEndpoint(gatewayAPIPath+"users/", userId, "tokens"))
+}
+
+func (c *AAPClient) GetGatewayRoleDefinition(id int64) (*GatewayRoleDefinition, error) {
+	return getObject[GatewayRoleDefinition](c, objectEndpoint(gatewayAPIPath+"role_definitions/", id))
+}
+
+func (c *AAPClient) CreateGatewayRoleDefinition(definition GatewayRoleDefinition) (*GatewayRoleDefinition, error) {
+	return createObject(c, gatewayAPIPath+"role_definitions/", definition)
+}
+
+func (c *AAPClient) UpdateGatewayRoleDefinition(id int64, definition GatewayRoleDefinition) (*GatewayRoleDefinition, error) {
+	return updateObject(c, http.MethodPatch, objectEndpoint(gatewayAPIPath+"role_definitions/", id), definition)
+}
+
+func (c *AAPClient) DeleteGatewayRoleDefinition(id int64) error {
+	return c.deleteObject(objectEndpoint(gatewayAPIPath+"role_definitions/", id))
+}
+
+// GetGatewayRoleDefinitionByName returns the role definition with the given name, or nil if there is none.
+func (c *AAPClient) GetGatewayRoleDefinitionByName(name string) (*GatewayRoleDefinition, error) {
+	return findByName(c, gatewayAPIPath+"role_definitions/", name, func(d GatewayRoleDefinition) string { return d.Name })
+}
+
+// gatewayRoleAssignmentsPath returns the path of the role assignments of an actor, user or team.
+func gatewayRoleAssignmentsPath(actor string) string {
+	return gatewayAPIPath + "role_" + actor + "_assignments/"
+}
+
+func (c *AAPClient) GetGatewayRoleAssignment(actor string, id int64) (*GatewayRoleAssignment, error) {
+	return getObject[GatewayRoleAssignment](c, objectEndpoint(gatewayRoleAssignmentsPath(actor), id))
+}
+
+func (c *AAPClient) CreateGatewayRoleAssignment(actor string, assignment GatewayRoleAssignment) (*GatewayRoleAssignment, error) {
+	return createObject(c, gatewayRoleAssignmentsPath(actor), assignment)
+}
+
+func (c *AAPClient) DeleteGatewayRoleAssignment(actor string, id int64) error {
+	return c.deleteObject(objectEndpoint(gatewayRoleAssignmentsPath(actor), id))
+}
+
+// WithBasicAuth returns a copy of the client authenticating as another user.
+func (c *AAPClient) WithBasicAuth(username string, password string) *AAPClient {
+	client := *c
+	// the token of the provider would take precedence over the credentials of the user
+	client.Token = nil
+	client.Username = &username
+	client.Password = &password
+	return &client
+}
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &gatewayOrganizationResource{}
+	_ resource.ResourceWithConfigure   = &gatewayOrganizationResource{}
+	_ resource.ResourceWithImportState = &gatewayOrganizationResource{}
+)
+
+// NewGatewayOrganizationResource is a helper function to simplify the provider implementation.
+func NewGatewayOrganizationResource() resource.Resource {
+	return &gatewayOrganizationResource{}
+}
+
+// gatewayOrganizationResource manages a platform gateway organization (AAP 2.5 and later).
+type gatewayOrganizationResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *gatewayOrganizationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway_organization"
+}
+
+// Schema defines the schema for the resource.
+func (r *gatewayOrganizationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an organization of the AAP 2.5 platform gateway, shared by controller, EDA and hub.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the organization.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the organization.",
+				Validators:  nameValidators(),
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Description of the organization.",
+			},
+		},
+	}
+}
+
+// gatewayOrganizationResourceModel maps the resource schema data.
+type gatewayOrganizationResourceModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// gatewayOrganizationAPIFields maps the fields of the gateway organization API to the attributes they are set from.
+var gatewayOrganizationAPIFields = map[string]string{
+	"name":        "name",
+	"description": "description",
+}
+
+func (m *gatewayOrganizationResourceModel) organization() GatewayOrganization {
+	return GatewayOrganization{
+		Name:        m.Name.ValueString(),
+		Description: m.Description.ValueString(),
+	}
+}
+
+func (m *gatewayOrganizationResourceModel) setOrganization(organization *GatewayOrganization) {
+	m.Id = types.Int64Value(organization.Id)
+	m.Name = types.StringValue(organization.Name)
+	m.Description = types.StringValue(organization.Description)
+}
+
+// Create creates the organization.
+func (r *gatewayOrganizationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_organization", "create", &resp.Diagnostics)
+	var plan gatewayOrganizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organization, err := r.client.CreateGatewayOrganization(plan.organization())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create gateway organization", err, gatewayOrganizationAPIFields)...)
+		return
+	}
+	plan.setOrganization(organization)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *gatewayOrganizationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_organization", "read", &resp.Diagnostics)
+	var state gatewayOrganizationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organization, err := r.client.GetGatewayOrganization(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read gateway organization", err.Error())
+		return
+	}
+	if organization == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setOrganization(organization)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the organization.
+func (r *gatewayOrganizationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_organization", "update", &resp.Diagnostics)
+	var plan gatewayOrganizationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organization, err := r.client.UpdateGatewayOrganization(plan.Id.ValueInt64(), plan.organization())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update gateway organization", err, gatewayOrganizationAPIFields)...)
+		return
+	}
+	plan.setOrganization(organization)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the organization.
+func (r *gatewayOrganizationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_organization", "delete", &resp.Diagnostics)
+	var state gatewayOrganizationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteGatewayOrganization(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete gateway organization", err.Error())
+	}
+}
+
+// ImportState imports an organization by id or name.
+func (r *gatewayOrganizationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(req.ID, "organization", func(name string) (*int64, error) {
+		organization, err := r.client.GetGatewayOrganizationByName(name)
+		if err != nil || organization == nil {
+			return nil, err
+		}
+		return &organization.Id, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *gatewayOrganizationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
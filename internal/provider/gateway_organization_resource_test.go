@@ -0,0 +1,32 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestGatewayOrganizationResource(t *testing.T) {
+	mock := newMockAAP(t)
+	p := newTestProvider(t, mock, nil)
+	organization := p.resource("aap_gateway_organization")
+	config := map[string]any{"name": "Engineering"}
+	state := organization.apply(config)
+	testExpect(t, state, map[string]any{"description": ""})
+	id := state["id"].(int64)
+
+	organization.read()
+	if changes := organization.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["description"] = "Platform engineering"
+	organization.apply(config)
+	testExpect(t, mock.object(gatewayAPIPath+"organizations", id), map[string]any{"name": "Engineering", "description": "Platform engineering"})
+
+	imported := p.resource("aap_gateway_organization")
+	testExpect(t, imported.importState("Engineering"), map[string]any{"id": id, "description": "Platform engineering"})
+
+	organization.destroy()
+	if mock.object(gatewayAPIPath+"organizations", id) != nil {
+		t.Errorf("gateway organization %d still exists after destroy", id)
+	}
+}
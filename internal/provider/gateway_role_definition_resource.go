@@ -0,0 +1,233 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &gatewayRoleDefinitionResource{}
+	_ resource.ResourceWithConfigure   = &gatewayRoleDefinitionResource{}
+	_ resource.ResourceWithImportState = &gatewayRoleDefinitionResource{}
+)
+
+// NewGatewayRoleDefinitionResource is a helper function to simplify the provider implementation.
+func NewGatewayRoleDefinitionResource() resource.Resource {
+	return &gatewayRoleDefinitionResource{}
+}
+
+// gatewayRoleDefinitionResource manages a custom role of the platform gateway (AAP 2.5 and later).
+type gatewayRoleDefinitionResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *gatewayRoleDefinitionResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway_role_definition"
+}
+
+// Schema defines the schema for the resource.
+func (r *gatewayRoleDefinitionResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a custom role definition of the AAP 2.5 platform gateway, the set of permissions granted together by " +
+			"aap_gateway_role_user_assignment and aap_gateway_role_team_assignment. The role definitions AAP manages itself cannot be changed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the role definition.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the role definition.",
+				Validators:  nameValidators(),
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Description of the role definition.",
+			},
+			"content_type": schema.StringAttribute{
+				Optional: true,
+				Description: "Type of the objects the role is granted on, e.g. shared.organization or awx.jobtemplate. " +
+					"Without it, the role is granted across the whole platform.",
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"permissions": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Permissions granted by the role, e.g. shared.view_organization or awx.execute_jobtemplate.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+		},
+	}
+}
+
+// gatewayRoleDefinitionResourceModel maps the resource schema data.
+type gatewayRoleDefinitionResourceModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+	ContentType types.String `tfsdk:"content_type"`
+	Permissions []string     `tfsdk:"permissions"`
+}
+
+// gatewayRoleDefinitionAPIFields maps the fields of the gateway role definition API to the attributes they are set from.
+var gatewayRoleDefinitionAPIFields = map[string]string{
+	"name":         "name",
+	"description":  "description",
+	"content_type": "content_type",
+	"permissions":  "permissions",
+}
+
+func (m *gatewayRoleDefinitionResourceModel) definition() GatewayRoleDefinition {
+	return GatewayRoleDefinition{
+		Name:        m.Name.ValueString(),
+		Description: m.Description.ValueString(),
+		ContentType: m.ContentType.ValueStringPointer(),
+		Permissions: m.Permissions,
+	}
+}
+
+func (m *gatewayRoleDefinitionResourceModel) setDefinition(definition *GatewayRoleDefinition) {
+	m.Id = types.Int64Value(definition.Id)
+	m.Name = types.StringValue(definition.Name)
+	m.Description = types.StringValue(definition.Description)
+	m.ContentType = types.StringPointerValue(definition.ContentType)
+	m.Permissions = definition.Permissions
+}
+
+// Create creates the role definition.
+func (r *gatewayRoleDefinitionResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_role_definition", "create", &resp.Diagnostics)
+	var plan gatewayRoleDefinitionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definition, err := r.client.CreateGatewayRoleDefinition(plan.definition())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create gateway role definition", err, gatewayRoleDefinitionAPIFields)...)
+		return
+	}
+	plan.setDefinition(definition)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *gatewayRoleDefinitionResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_role_definition", "read", &resp.Diagnostics)
+	var state gatewayRoleDefinitionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definition, err := r.client.GetGatewayRoleDefinition(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read gateway role definition", err.Error())
+		return
+	}
+	if definition == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setDefinition(definition)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the role definition.
+func (r *gatewayRoleDefinitionResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_role_definition", "update", &resp.Diagnostics)
+	var plan gatewayRoleDefinitionResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	definition, err := r.client.UpdateGatewayRoleDefinition(plan.Id.ValueInt64(), plan.definition())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update gateway role definition", err, gatewayRoleDefinitionAPIFields)...)
+		return
+	}
+	plan.setDefinition(definition)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the role definition, which revokes it from everyone it is assigned to.
+func (r *gatewayRoleDefinitionResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_role_definition", "delete", &resp.Diagnostics)
+	var state gatewayRoleDefinitionResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteGatewayRoleDefinition(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete gateway role definition", err.Error())
+	}
+}
+
+// ImportState imports a role definition by id or name.
+func (r *gatewayRoleDefinitionResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(req.ID, "role definition", func(name string) (*int64, error) {
+		found, err := r.client.GetGatewayRoleDefinitionByName(name)
+		if err != nil || found == nil {
+			return nil, err
+		}
+		if found.Managed {
+			return nil, fmt.Errorf("role definition %q is managed by AAP and cannot be imported", name)
+		}
+		return &found.Id, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *gatewayRoleDefinitionResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
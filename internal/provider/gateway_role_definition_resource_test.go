@@ -0,0 +1,51 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGatewayRoleDefinitionResource(t *testing.T) {
+	mock := newMockAAP(t)
+	mock.addObject(gatewayAPIPath+"role_definitions", map[string]any{"name": "Organization Admin", "content_type": "shared.organization",
+		"permissions": []any{"shared.change_organization"}, "managed": true})
+
+	p := newTestProvider(t, mock, nil)
+	definition := p.resource("aap_gateway_role_definition")
+	config := map[string]any{"name": "Job runner", "content_type": "awx.jobtemplate", "permissions": []any{"awx.execute_jobtemplate"}}
+	state := definition.apply(config)
+	testExpect(t, state, map[string]any{"description": "", "content_type": "awx.jobtemplate"})
+	id := state["id"].(int64)
+
+	definition.read()
+	if changes := definition.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["permissions"] = []any{"awx.execute_jobtemplate", "awx.view_jobtemplate"}
+	definition.apply(config)
+	testExpect(t, mock.object(gatewayAPIPath+"role_definitions", id), map[string]any{
+		"permissions": []any{"awx.execute_jobtemplate", "awx.view_jobtemplate"},
+	})
+
+	// changing the content type replaces the role definition
+	config["content_type"] = "awx.inventory"
+	config["permissions"] = []any{"awx.use_inventory"}
+	state = definition.apply(config)
+	if state["id"] == id || mock.object(gatewayAPIPath+"role_definitions", id) != nil {
+		t.Errorf("role definition %d was not replaced when its content type changed", id)
+	}
+	id = state["id"].(int64)
+
+	imported := p.resource("aap_gateway_role_definition")
+	testExpect(t, imported.importState("Job runner"), map[string]any{"id": id, "content_type": "awx.inventory"})
+	managed := p.resource("aap_gateway_role_definition")
+	if message := managed.tryImportState("Organization Admin"); !strings.Contains(message, "is managed by AAP") {
+		t.Errorf("importing a managed role definition gives %q", message)
+	}
+
+	definition.destroy()
+	if mock.object(gatewayAPIPath+"role_definitions", id) != nil {
+		t.Errorf("role definition %d still exists after destroy", id)
+	}
+}
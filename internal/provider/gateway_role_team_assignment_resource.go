@@ -0,0 +1,180 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &gatewayRoleTeamAssignmentResource{}
+	_ resource.ResourceWithConfigure = &gatewayRoleTeamAssignmentResource{}
+)
+
+// NewGatewayRoleTeamAssignmentResource is a helper function to simplify the provider implementation.
+func NewGatewayRoleTeamAssignmentResource() resource.Resource {
+	return &gatewayRoleTeamAssignmentResource{}
+}
+
+// gatewayRoleTeamAssignmentResource grants a role of the platform gateway to a team (AAP 2.5 and later).
+type gatewayRoleTeamAssignmentResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *gatewayRoleTeamAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway_role_team_assignment"
+}
+
+// Schema defines the schema for the resource.
+func (r *gatewayRoleTeamAssignmentResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.Int64{
+		int64planmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Grants a role definition of the AAP 2.5 platform gateway to a team, on one object or across the whole platform.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the role assignment.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"role_definition_id": schema.Int64Attribute{
+				Required:      true,
+				Description:   "Id of the role definition, see aap_gateway_role_definition.",
+				Validators:    idValidators(),
+				PlanModifiers: replace,
+			},
+			"team_id": schema.Int64Attribute{
+				Required:      true,
+				Description:   "Id of the gateway team.",
+				Validators:    idValidators(),
+				PlanModifiers: replace,
+			},
+			"object_id": schema.StringAttribute{
+				Optional: true,
+				Description: "Id of the object the role is granted on, of the content type of the role definition. " +
+					"Must be omitted for role definitions without a content type.",
+				Validators: []validator.String{stringvalidator.LengthAtLeast(1)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+		},
+	}
+}
+
+// gatewayRoleTeamAssignmentResourceModel maps the resource schema data.
+type gatewayRoleTeamAssignmentResourceModel struct {
+	Id               types.Int64  `tfsdk:"id"`
+	RoleDefinitionId types.Int64  `tfsdk:"role_definition_id"`
+	TeamId           types.Int64  `tfsdk:"team_id"`
+	ObjectId         types.String `tfsdk:"object_id"`
+}
+
+// gatewayRoleTeamAssignmentAPIFields maps the fields of the gateway role assignment API to the attributes they are set from.
+var gatewayRoleTeamAssignmentAPIFields = map[string]string{
+	"role_definition": "role_definition_id",
+	"team":            "team_id",
+	"object_id":       "object_id",
+}
+
+// Create grants the role.
+func (r *gatewayRoleTeamAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_role_team_assignment", "create", &resp.Diagnostics)
+	var plan gatewayRoleTeamAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assignment, err := r.client.CreateGatewayRoleAssignment("team", GatewayRoleAssignment{
+		RoleDefinition: plan.RoleDefinitionId.ValueInt64(),
+		Team:           plan.TeamId.ValueInt64Pointer(),
+		ObjectId:       plan.ObjectId.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to grant gateway role", err, gatewayRoleTeamAssignmentAPIFields)...)
+		return
+	}
+	plan.Id = types.Int64Value(assignment.Id)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read removes the resource from the state when the role is no longer granted.
+func (r *gatewayRoleTeamAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_role_team_assignment", "read", &resp.Diagnostics)
+	var state gatewayRoleTeamAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	assignment, err := r.client.GetGatewayRoleAssignment("team", state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read gateway role assignment", err.Error())
+		return
+	}
+	if assignment == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.RoleDefinitionId = types.Int64Value(assignment.RoleDefinition)
+	state.TeamId = types.Int64PointerValue(assignment.Team)
+	state.ObjectId = types.StringPointerValue(assignment.ObjectId)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called as every attribute requires replacement.
+func (r *gatewayRoleTeamAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_role_team_assignment", "update", &resp.Diagnostics)
+	resp.Diagnostics.AddError("Unexpected update",
+		"Gateway role assignments cannot be updated in place. Please report this issue to the provider developers.")
+}
+
+// Delete revokes the role.
+func (r *gatewayRoleTeamAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_role_team_assignment", "delete", &resp.Diagnostics)
+	var state gatewayRoleTeamAssignmentResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteGatewayRoleAssignment("team", state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to revoke gateway role", err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *gatewayRoleTeamAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
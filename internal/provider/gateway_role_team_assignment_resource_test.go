@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGatewayRoleTeamAssignmentResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject(gatewayAPIPath+"organizations", map[string]any{"name": "Engineering"})
+	team := mock.addObject(gatewayAPIPath+"teams", map[string]any{"name": "sre", "organization": organization})
+	member := mock.addObject(gatewayAPIPath+"role_definitions", map[string]any{"name": "Organization Member", "content_type": "shared.organization"})
+	auditor := mock.addObject(gatewayAPIPath+"role_definitions", map[string]any{"name": "Platform Auditor", "content_type": nil})
+	assignments := gatewayAPIPath + "role_team_assignments"
+
+	p := newTestProvider(t, mock, nil)
+	assignment := p.resource("aap_gateway_role_team_assignment")
+	config := map[string]any{"role_definition_id": member, "team_id": team, "object_id": fmt.Sprint(organization)}
+	state := assignment.apply(config)
+	id := state["id"].(int64)
+	testExpect(t, mock.object(assignments, id), map[string]any{
+		"role_definition": float64(member), "team": float64(team), "object_id": fmt.Sprint(organization),
+	})
+
+	assignment.read()
+	if changes := assignment.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	// granting another role replaces the assignment
+	config = map[string]any{"role_definition_id": auditor, "team_id": team}
+	state = assignment.apply(config)
+	if mock.object(assignments, id) != nil {
+		t.Errorf("role assignment %d still exists after replacing it", id)
+	}
+	id = state["id"].(int64)
+	testExpect(t, mock.object(assignments, id), map[string]any{"role_definition": float64(auditor), "object_id": nil})
+
+	assignment.destroy()
+	if mock.object(assignments, id) != nil {
+		t.Errorf("role assignment %d still exists after destroy", id)
+	}
+}
@@ -0,0 +1,251 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource               = &gatewayServiceAccountResource{}
+	_ resource.ResourceWithConfigure  = &gatewayServiceAccountResource{}
+	_ resource.ResourceWithModifyPlan = &gatewayServiceAccountResource{}
+)
+
+// NewGatewayServiceAccountResource is a helper function to simplify the provider implementation.
+func NewGatewayServiceAccountResource() resource.Resource {
+	return &gatewayServiceAccountResource{}
+}
+
+// gatewayServiceAccountResource manages a non-interactive gateway user together with an API token.
+type gatewayServiceAccountResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *gatewayServiceAccountResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway_service_account"
+}
+
+// Schema defines the schema for the resource.
+func (r *gatewayServiceAccountResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Manages a service account of the AAP 2.5 platform gateway: a user with a generated password " +
+			"and an OAuth2 token that automation can authenticate with. Grant it permissions with role assignments.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the service account user.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Required:      true,
+				Description:   "Username of the service account.",
+				PlanModifiers: replace,
+				Validators:    usernameValidators(),
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Description of the service account, stored as the token description.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"scope": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString("write"),
+				Description:   "Scope of the token, read or write.",
+				PlanModifiers: replace,
+				Validators:    []validator.String{stringvalidator.OneOf("read", "write")},
+			},
+			"token_id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the token.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"token": schema.StringAttribute{
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The OAuth2 token.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// gatewayServiceAccountResourceModel maps the resource schema data.
+type gatewayServiceAccountResourceModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	Username    types.String `tfsdk:"username"`
+	Description types.String `tfsdk:"description"`
+	Scope       types.String `tfsdk:"scope"`
+	TokenId     types.Int64  `tfsdk:"token_id"`
+	Token       types.String `tfsdk:"token"`
+}
+
+// gatewayServiceAccountAPIFields maps the fields of the service account user and token API to the attributes they are set from.
+var gatewayServiceAccountAPIFields = map[string]string{
+	"username":    "username",
+	"description": "description",
+	"scope":       "scope",
+}
+
+// Create creates the user, then a token authenticated as that user so that it owns the token.
+func (r *gatewayServiceAccountResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_service_account", "create", &resp.Diagnostics)
+	var plan gatewayServiceAccountResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	password, err := generateSecret()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to generate service account password", err.Error())
+		return
+	}
+
+	user, err := r.client.CreateGatewayUser(GatewayUser{
+		Username: plan.Username.ValueString(),
+		Password: &password,
+	})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create service account user", err, gatewayServiceAccountAPIFields)...)
+		return
+	}
+	plan.Id = types.Int64Value(user.Id)
+
+	token, err := r.client.WithBasicAuth(user.Username, password).CreateGatewayToken(GatewayToken{
+		Description: plan.Description.ValueString(),
+		Scope:       plan.Scope.ValueString(),
+	})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create service account token", err, gatewayServiceAccountAPIFields)...)
+		// do not leak the user
+		_ = r.client.DeleteGatewayUser(plan.Id.ValueInt64())
+		return
+	}
+	plan.TokenId = types.Int64Value(token.Id)
+	plan.Token = types.StringValue(token.Token)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read removes the resource from the state when the user no longer exists.
+func (r *gatewayServiceAccountResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_service_account", "read", &resp.Diagnostics)
+	var state gatewayServiceAccountResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.GetGatewayUser(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read service account user", err.Error())
+		return
+	}
+	if user == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Username = types.StringValue(user.Username)
+
+	token, err := getObject[GatewayToken](r.client, objectEndpoint(gatewayAPIPath+"tokens/", state.TokenId.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read service account token", err.Error())
+		return
+	}
+	if token == nil {
+		// the token was revoked, the plan replaces the service account to issue a new one
+		state.TokenId = types.Int64Null()
+		state.Token = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// ModifyPlan replaces the service account when its token was revoked, as the user already exists.
+func (r *gatewayServiceAccountResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var tokenId types.Int64
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("token_id"), &tokenId)...)
+	if resp.Diagnostics.HasError() || !tokenId.IsNull() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token_id"), types.Int64Unknown())...)
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("token"), types.StringUnknown())...)
+	resp.RequiresReplace = append(resp.RequiresReplace, path.Root("token_id"))
+}
+
+// Update is never called as every configurable attribute requires replacement.
+func (r *gatewayServiceAccountResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	resp.Diagnostics.AddError("Unexpected update", "Service accounts cannot be updated in place. Please report this issue to the provider developers.")
+}
+
+// Delete revokes the token and deletes the user.
+func (r *gatewayServiceAccountResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_service_account", "delete", &resp.Diagnostics)
+	var state gatewayServiceAccountResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.TokenId.IsNull() {
+		if err := r.client.DeleteGatewayToken(state.TokenId.ValueInt64()); err != nil {
+			resp.Diagnostics.AddError("Unable to revoke service account token", err.Error())
+			return
+		}
+	}
+	if err := r.client.DeleteGatewayUser(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete service account user", err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *gatewayServiceAccountResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGatewayServiceAccountResource(t *testing.T) {
+	mock := newMockAAP(t)
+	// the token must be owned by the service account even when the provider authenticates with a token
+	p := newTestProvider(t, mock, map[string]any{"token": "secret"})
+	account := p.resource("aap_gateway_service_account")
+	config := map[string]any{"username": "ci", "description": "CI pipeline"}
+	state := account.apply(config)
+	id := state["id"].(int64)
+	tokenId := state["token_id"].(int64)
+	testExpect(t, state, map[string]any{"scope": "write", "token": fmt.Sprintf("token-%d", tokenId)})
+	testExpect(t, mock.object(gatewayAPIPath+"tokens", tokenId), map[string]any{"user": id, "description": "CI pipeline", "scope": "write"})
+
+	account.read()
+	if changes := account.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	// a revoked token replaces the service account
+	delete(mock.objects[gatewayAPIPath+"tokens"], tokenId)
+	testExpect(t, account.read(), map[string]any{"id": id, "token_id": nil, "token": nil})
+	state = account.apply(config)
+	if mock.object(gatewayAPIPath+"users", id) != nil {
+		t.Errorf("service account user %d still exists after replacing the revoked token", id)
+	}
+	id = state["id"].(int64)
+	tokenId = state["token_id"].(int64)
+	testExpect(t, mock.object(gatewayAPIPath+"tokens", tokenId), map[string]any{"user": id})
+
+	account.destroy()
+	if mock.object(gatewayAPIPath+"users", id) != nil || mock.object(gatewayAPIPath+"tokens", tokenId) != nil {
+		t.Errorf("service account user %d or token %d still exists after destroy", id, tokenId)
+	}
+}
@@ -0,0 +1,212 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &gatewayTeamResource{}
+	_ resource.ResourceWithConfigure   = &gatewayTeamResource{}
+	_ resource.ResourceWithImportState = &gatewayTeamResource{}
+)
+
+// NewGatewayTeamResource is a helper function to simplify the provider implementation.
+func NewGatewayTeamResource() resource.Resource {
+	return &gatewayTeamResource{}
+}
+
+// gatewayTeamResource manages a platform gateway team (AAP 2.5 and later).
+type gatewayTeamResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *gatewayTeamResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway_team"
+}
+
+// Schema defines the schema for the resource.
+func (r *gatewayTeamResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a team of the AAP 2.5 platform gateway.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the team.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the team.",
+				Validators:  nameValidators(),
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Description of the team.",
+			},
+			"organization": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the gateway organization the team belongs to.",
+				Validators:  idValidators(),
+			},
+		},
+	}
+}
+
+// gatewayTeamResourceModel maps the resource schema data.
+type gatewayTeamResourceModel struct {
+	Id           types.Int64  `tfsdk:"id"`
+	Name         types.String `tfsdk:"name"`
+	Description  types.String `tfsdk:"description"`
+	Organization types.Int64  `tfsdk:"organization"`
+}
+
+// gatewayTeamAPIFields maps the fields of the gateway team API to the attributes they are set from.
+var gatewayTeamAPIFields = map[string]string{
+	"name":         "name",
+	"description":  "description",
+	"organization": "organization",
+}
+
+func (m *gatewayTeamResourceModel) team() GatewayTeam {
+	return GatewayTeam{
+		Name:         m.Name.ValueString(),
+		Description:  m.Description.ValueString(),
+		Organization: m.Organization.ValueInt64(),
+	}
+}
+
+func (m *gatewayTeamResourceModel) setTeam(team *GatewayTeam) {
+	m.Id = types.Int64Value(team.Id)
+	m.Name = types.StringValue(team.Name)
+	m.Description = types.StringValue(team.Description)
+	m.Organization = types.Int64Value(team.Organization)
+}
+
+// Create creates the team.
+func (r *gatewayTeamResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_team", "create", &resp.Diagnostics)
+	var plan gatewayTeamResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := r.client.CreateGatewayTeam(plan.team())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create gateway team", err, gatewayTeamAPIFields)...)
+		return
+	}
+	plan.setTeam(team)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *gatewayTeamResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_team", "read", &resp.Diagnostics)
+	var state gatewayTeamResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := r.client.GetGatewayTeam(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read gateway team", err.Error())
+		return
+	}
+	if team == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setTeam(team)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the team.
+func (r *gatewayTeamResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_team", "update", &resp.Diagnostics)
+	var plan gatewayTeamResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := r.client.UpdateGatewayTeam(plan.Id.ValueInt64(), plan.team())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update gateway team", err, gatewayTeamAPIFields)...)
+		return
+	}
+	plan.setTeam(team)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the team.
+func (r *gatewayTeamResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_team", "delete", &resp.Diagnostics)
+	var state gatewayTeamResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteGatewayTeam(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete gateway team", err.Error())
+	}
+}
+
+// ImportState imports a team by id or as Organization/Name.
+func (r *gatewayTeamResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(req.ID, "team", func(name string) (*int64, error) {
+		organization, team, err := splitScopedName(name, "team")
+		if err != nil {
+			return nil, err
+		}
+		found, err := r.client.GetGatewayTeamByName(organization, team)
+		if err != nil || found == nil {
+			return nil, err
+		}
+		return &found.Id, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *gatewayTeamResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
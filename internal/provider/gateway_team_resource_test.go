@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestGatewayTeamResource(t *testing.T) {
+	mock := newMockAAP(t)
+	engineering := mock.addObject(gatewayAPIPath+"organizations", map[string]any{"name": "Engineering"})
+	operations := mock.addObject(gatewayAPIPath+"organizations", map[string]any{"name": "Operations"})
+
+	p := newTestProvider(t, mock, nil)
+	team := p.resource("aap_gateway_team")
+	config := map[string]any{"name": "sre", "organization": engineering}
+	state := team.apply(config)
+	testExpect(t, state, map[string]any{"description": "", "organization": engineering})
+	id := state["id"].(int64)
+
+	team.read()
+	if changes := team.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["organization"] = operations
+	config["description"] = "Site reliability"
+	team.apply(config)
+	testExpect(t, mock.object(gatewayAPIPath+"teams", id), map[string]any{"organization": float64(operations), "description": "Site reliability"})
+
+	imported := p.resource("aap_gateway_team")
+	testExpect(t, imported.importState("Operations/sre"), map[string]any{"id": id, "organization": operations})
+
+	team.destroy()
+	if mock.object(gatewayAPIPath+"teams", id) != nil {
+		t.Errorf("gateway team %d still exists after destroy", id)
+	}
+}
@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &gatewayTokensDataSource{}
+	_ datasource.DataSourceWithConfigure = &gatewayTokensDataSource{}
+)
+
+// NewGatewayTokensDataSource is a helper function to simplify the provider implementation.
+func NewGatewayTokensDataSource() datasource.DataSource {
+	return &gatewayTokensDataSource{}
+}
+
+// gatewayTokensDataSource lists the tokens of the user the provider authenticates as.
+type gatewayTokensDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *gatewayTokensDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway_tokens"
+}
+
+// Schema defines the schema for the data source.
+func (d *gatewayTokensDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the platform gateway tokens of the user the provider authenticates as (AAP 2.5 and later), " +
+			"e.g. to find the tokens to rotate. Token values are never returned.",
+		Attributes: map[string]schema.Attribute{
+			"user_id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the user owning the tokens.",
+			},
+			"tokens": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Tokens of the user, ordered as returned by the gateway.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the token.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the token.",
+						},
+						"scope": schema.StringAttribute{
+							Computed:    true,
+							Description: "Scope of the token, read or write.",
+						},
+						"created": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the token was created.",
+						},
+						"expires": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the token expires.",
+						},
+						"application_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the OAuth2 application the token was issued for, null for personal access tokens.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// gatewayTokensDataSourceModel maps the data source schema data.
+type gatewayTokensDataSourceModel struct {
+	UserId types.Int64         `tfsdk:"user_id"`
+	Tokens []gatewayTokenModel `tfsdk:"tokens"`
+}
+
+// gatewayTokenModel maps a token.
+type gatewayTokenModel struct {
+	Id            types.Int64  `tfsdk:"id"`
+	Description   types.String `tfsdk:"description"`
+	Scope         types.String `tfsdk:"scope"`
+	Created       types.String `tfsdk:"created"`
+	Expires       types.String `tfsdk:"expires"`
+	ApplicationId types.Int64  `tfsdk:"application_id"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *gatewayTokensDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_gateway_tokens", "read", &resp.Diagnostics)
+	var state gatewayTokensDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := d.client.GetGatewayCurrentUser()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read current gateway user", err.Error())
+		return
+	}
+	tokens, err := d.client.GetGatewayUserTokens(user.Id)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read gateway tokens", err.Error())
+		return
+	}
+
+	state.UserId = types.Int64Value(user.Id)
+	state.Tokens = make([]gatewayTokenModel, len(tokens))
+	for i, token := range tokens {
+		state.Tokens[i] = gatewayTokenModel{
+			Id:            types.Int64Value(token.Id),
+			Description:   types.StringValue(token.Description),
+			Scope:         types.StringValue(token.Scope),
+			Created:       types.StringValue(token.Created),
+			Expires:       types.StringValue(token.Expires),
+			ApplicationId: types.Int64PointerValue(token.Application),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *gatewayTokensDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
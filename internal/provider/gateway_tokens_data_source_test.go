@@ -0,0 +1,44 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestGatewayTokensDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	admin := mock.addObject(gatewayAPIPath+"users", map[string]any{"username": mockUsername, "password": "password"})
+	other := mock.addObject(gatewayAPIPath+"users", map[string]any{"username": "alice", "password": "secret"})
+
+	p := newTestProvider(t, mock, nil)
+	testExpect(t, p.readDataSource("aap_gateway_tokens", map[string]any{}), map[string]any{"user_id": admin, "tokens": []any{}})
+
+	personal := mock.addObject(gatewayAPIPath+"tokens", map[string]any{
+		"user": admin, "description": "terraform", "scope": "write", "application": nil,
+		"expires": "2025-05-02T08:00:00.000000Z",
+	})
+	mock.addObject(gatewayAPIPath+"tokens", map[string]any{"user": other, "description": "alice", "scope": "read", "application": nil})
+	application := mock.addObject(gatewayAPIPath+"tokens", map[string]any{
+		"user": admin, "description": "", "scope": "read", "application": 7,
+		"expires": "2024-07-01T08:00:00.000000Z",
+	})
+
+	// only the tokens of the user the provider authenticates as are listed
+	testExpect(t, p.readDataSource("aap_gateway_tokens", map[string]any{}), map[string]any{
+		"user_id": admin,
+		"tokens": []any{
+			map[string]any{
+				"id": personal, "description": "terraform", "scope": "write", "application_id": nil,
+				"created": mock.object(gatewayAPIPath+"tokens", personal)["created"], "expires": "2025-05-02T08:00:00.000000Z",
+			},
+			map[string]any{
+				"id": application, "description": "", "scope": "read", "application_id": int64(7),
+				"created": mock.object(gatewayAPIPath+"tokens", application)["created"], "expires": "2024-07-01T08:00:00.000000Z",
+			},
+		},
+	})
+
+	delete(mock.objects[gatewayAPIPath+"users"], admin)
+	if _, errors := p.tryReadDataSource("aap_gateway_tokens", map[string]any{}); errors == "" {
+		t.Error("reading the tokens without a current user did not fail")
+	}
+}
@@ -0,0 +1,239 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &gatewayUserResource{}
+	_ resource.ResourceWithConfigure   = &gatewayUserResource{}
+	_ resource.ResourceWithImportState = &gatewayUserResource{}
+)
+
+// NewGatewayUserResource is a helper function to simplify the provider implementation.
+func NewGatewayUserResource() resource.Resource {
+	return &gatewayUserResource{}
+}
+
+// gatewayUserResource manages a platform gateway user (AAP 2.5 and later).
+type gatewayUserResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *gatewayUserResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_gateway_user"
+}
+
+// Schema defines the schema for the resource.
+func (r *gatewayUserResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages a user of the AAP 2.5 platform gateway.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the user.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"username": schema.StringAttribute{
+				Required:    true,
+				Description: "Username of the user.",
+				Validators:  usernameValidators(),
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password of the user. AAP never returns passwords, so changes made outside Terraform are not detected.",
+			},
+			"email": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Email address of the user.",
+			},
+			"first_name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "First name of the user.",
+			},
+			"last_name": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Last name of the user.",
+			},
+			"is_superuser": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the user has every permission on the platform.",
+			},
+		},
+	}
+}
+
+// gatewayUserResourceModel maps the resource schema data.
+type gatewayUserResourceModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	Username    types.String `tfsdk:"username"`
+	Password    types.String `tfsdk:"password"`
+	Email       types.String `tfsdk:"email"`
+	FirstName   types.String `tfsdk:"first_name"`
+	LastName    types.String `tfsdk:"last_name"`
+	IsSuperuser types.Bool   `tfsdk:"is_superuser"`
+}
+
+// gatewayUserAPIFields maps the fields of the gateway user API to the attributes they are set from.
+var gatewayUserAPIFields = map[string]string{
+	"username":     "username",
+	"password":     "password",
+	"email":        "email",
+	"first_name":   "first_name",
+	"last_name":    "last_name",
+	"is_superuser": "is_superuser",
+}
+
+func (m *gatewayUserResourceModel) user() GatewayUser {
+	return GatewayUser{
+		Username:    m.Username.ValueString(),
+		Email:       m.Email.ValueString(),
+		FirstName:   m.FirstName.ValueString(),
+		LastName:    m.LastName.ValueString(),
+		IsSuperuser: m.IsSuperuser.ValueBool(),
+		Password:    m.Password.ValueStringPointer(),
+	}
+}
+
+// setUser updates the model from the API response, leaving the write-only password untouched.
+func (m *gatewayUserResourceModel) setUser(user *GatewayUser) {
+	m.Id = types.Int64Value(user.Id)
+	m.Username = types.StringValue(user.Username)
+	m.Email = types.StringValue(user.Email)
+	m.FirstName = types.StringValue(user.FirstName)
+	m.LastName = types.StringValue(user.LastName)
+	m.IsSuperuser = types.BoolValue(user.IsSuperuser)
+}
+
+// Create creates the user.
+func (r *gatewayUserResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_user", "create", &resp.Diagnostics)
+	var plan gatewayUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.CreateGatewayUser(plan.user())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create gateway user", err, gatewayUserAPIFields)...)
+		return
+	}
+	plan.setUser(user)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *gatewayUserResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_user", "read", &resp.Diagnostics)
+	var state gatewayUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.GetGatewayUser(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read gateway user", err.Error())
+		return
+	}
+	if user == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setUser(user)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the user.
+func (r *gatewayUserResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_user", "update", &resp.Diagnostics)
+	var plan gatewayUserResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	user, err := r.client.UpdateGatewayUser(plan.Id.ValueInt64(), plan.user())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update gateway user", err, gatewayUserAPIFields)...)
+		return
+	}
+	plan.setUser(user)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the user.
+func (r *gatewayUserResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_gateway_user", "delete", &resp.Diagnostics)
+	var state gatewayUserResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteGatewayUser(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete gateway user", err.Error())
+	}
+}
+
+// ImportState imports a user by id or username.
+func (r *gatewayUserResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(req.ID, "user", func(name string) (*int64, error) {
+		user, err := r.client.GetGatewayUserByUsername(name)
+		if err != nil || user == nil {
+			return nil, err
+		}
+		return &user.Id, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *gatewayUserResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,34 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestGatewayUserResource(t *testing.T) {
+	mock := newMockAAP(t)
+	p := newTestProvider(t, mock, nil)
+	user := p.resource("aap_gateway_user")
+	config := map[string]any{"username": "jdoe", "password": "initial", "email": "jdoe@example.com"}
+	state := user.apply(config)
+	testExpect(t, state, map[string]any{"password": "initial", "first_name": "", "is_superuser": false})
+	id := state["id"].(int64)
+	testExpect(t, mock.objects[gatewayAPIPath+"users"][id], map[string]any{"username": "jdoe", "password": "initial"})
+
+	user.read()
+	if changes := user.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["first_name"] = "Jane"
+	config["password"] = "rotated"
+	user.apply(config)
+	testExpect(t, mock.objects[gatewayAPIPath+"users"][id], map[string]any{"first_name": "Jane", "password": "rotated"})
+
+	imported := p.resource("aap_gateway_user")
+	testExpect(t, imported.importState("jdoe"), map[string]any{"id": id, "first_name": "Jane", "password": nil})
+
+	user.destroy()
+	if mock.object(gatewayAPIPath+"users", id) != nil {
+		t.Errorf("gateway user %d still exists after destroy", id)
+	}
+}
@@ -0,0 +1,120 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &hostFilterDataSource{}
+	_ datasource.DataSourceWithConfigure = &hostFilterDataSource{}
+)
+
+// NewHostFilterDataSource is a helper function to simplify the provider implementation.
+func NewHostFilterDataSource() datasource.DataSource {
+	return &hostFilterDataSource{}
+}
+
+// hostFilterDataSource checks a smart inventory host filter against AAP.
+type hostFilterDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *hostFilterDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_filter"
+}
+
+// Schema defines the schema for the data source.
+func (d *hostFilterDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Checks a smart inventory host filter against AAP and counts the hosts it matches, " +
+			"e.g. to stop with a precondition before a smart inventory is created from a filter AAP rejects. " +
+			"Filters AAP rejects do not fail the read; valid is false and error tells why.",
+		Attributes: map[string]schema.Attribute{
+			"host_filter": schema.StringAttribute{
+				Required:    true,
+				Description: "Host filter, e.g. name__startswith=web or ansible_facts__ansible_distribution=RedHat.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"organization_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only count the hosts in the inventories of this organization, as a smart inventory of the organization would.",
+				Validators:  idValidators(),
+			},
+			"valid": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether AAP accepts the host filter.",
+			},
+			"error": schema.StringAttribute{
+				Computed:    true,
+				Description: "Reason AAP rejects the host filter, empty when it is valid.",
+			},
+			"host_count": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of hosts matching the host filter, 0 when it is not valid.",
+			},
+		},
+	}
+}
+
+// hostFilterDataSourceModel maps the data source schema data.
+type hostFilterDataSourceModel struct {
+	HostFilter     types.String `tfsdk:"host_filter"`
+	OrganizationId types.Int64  `tfsdk:"organization_id"`
+	Valid          types.Bool   `tfsdk:"valid"`
+	Error          types.String `tfsdk:"error"`
+	HostCount      types.Int64  `tfsdk:"host_count"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *hostFilterDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_host_filter", "read", &resp.Diagnostics)
+	var state hostFilterDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	check, err := d.client.CheckHostFilter(state.HostFilter.ValueString(), state.OrganizationId.ValueInt64Pointer())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to check host filter", err.Error())
+		return
+	}
+
+	message := check.Detail
+	if message == "" {
+		message = check.Error
+	}
+	state.Valid = types.BoolValue(message == "")
+	state.Error = types.StringValue(message)
+	state.HostCount = types.Int64Value(check.Count)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *hostFilterDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
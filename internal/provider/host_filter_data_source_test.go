@@ -0,0 +1,58 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestHostFilterDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	ids := map[string]int64{}
+	for _, inventory := range []AAPInventory{{Name: "Production", Organization: 1}, {Name: "Lab", Organization: 2}} {
+		inventory := inventory
+		mock.nextId++
+		inventory.Id = mock.nextId
+		mock.inventories[inventory.Id] = &inventory
+		ids[inventory.Name] = inventory.Id
+	}
+	for _, host := range []AAPHost{
+		{Name: "web1", Inventory: ids["Production"]},
+		{Name: "web2", Inventory: ids["Production"]},
+		{Name: "db1", Inventory: ids["Production"]},
+		{Name: "web3", Inventory: ids["Lab"]},
+	} {
+		host := host
+		mock.nextId++
+		host.Id = mock.nextId
+		mock.hosts[host.Id] = &host
+		ids[host.Name] = host.Id
+	}
+	mock.nextId++
+	mock.groups[mock.nextId] = &AAPGroup{Id: mock.nextId, Name: "databases", Inventory: ids["Production"]}
+	mock.groupHosts[mock.nextId] = []int64{ids["db1"]}
+
+	p := newTestProvider(t, mock, nil)
+	testExpect(t, p.readDataSource("aap_host_filter", map[string]any{"host_filter": "name__startswith=web"}), map[string]any{
+		"host_filter": "name__startswith=web", "organization_id": nil, "valid": true, "error": "", "host_count": int64(3),
+	})
+	testExpect(t, p.readDataSource("aap_host_filter", map[string]any{"host_filter": "groups__name=databases or name=web3"}), map[string]any{
+		"valid": true, "host_count": int64(2),
+	})
+	testExpect(t, p.readDataSource("aap_host_filter", map[string]any{"host_filter": "not (name=db1 or name=web3)"}), map[string]any{
+		"valid": true, "host_count": int64(2),
+	})
+
+	// the organization scopes the count to the hosts of its inventories
+	testExpect(t, p.readDataSource("aap_host_filter", map[string]any{"host_filter": "name__startswith=web", "organization_id": 1}), map[string]any{
+		"organization_id": int64(1), "valid": true, "host_count": int64(2),
+	})
+	testExpect(t, p.readDataSource("aap_host_filter", map[string]any{"host_filter": "name=db1", "organization_id": 2}), map[string]any{
+		"valid": true, "host_count": int64(0),
+	})
+
+	// AAP rejects filters it cannot parse, which is reported rather than failing the read
+	state := p.readDataSource("aap_host_filter", map[string]any{"host_filter": "(name=web1 and"})
+	testExpect(t, state, map[string]any{"valid": false, "host_count": int64(0)})
+	if message, _ := state["error"].(string); message == "" {
+		t.Error("no error reported for an invalid host filter")
+	}
+}
@@ -0,0 +1,402 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &hostFilterFunction{}
+
+// hostFilterLookups are the lookups AAP accepts after the field of a host filter term.
+var hostFilterLookups = []string{
+	"exact", "iexact", "contains", "icontains", "startswith", "istartswith",
+	"endswith", "iendswith", "regex", "iregex", "gt", "gte", "lt", "lte", "in", "isnull",
+}
+
+// hostFilterField matches the field of a host filter term, e.g. groups__name or ansible_facts__ansible_distribution.
+var hostFilterField = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.\[\]-]*$`)
+
+// NewHostFilterFunction is a helper function to simplify the provider implementation.
+func NewHostFilterFunction() function.Function {
+	return &hostFilterFunction{}
+}
+
+// hostFilterFunction composes and validates smart inventory host filters.
+type hostFilterFunction struct{}
+
+// Metadata returns the function name.
+func (f *hostFilterFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "host_filter"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *hostFilterFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Build a smart inventory host filter",
+		Description: "Composes the host_filter of a smart inventory and checks it against the grammar AAP accepts. " +
+			"The filter is either a host filter string, which is validated and returned normalized, or an object: " +
+			"a term {field, value, lookup} where lookup is optional, or {and = [...]}, {or = [...]} or {not = ...} combining other filters.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "filter",
+				Description: "Host filter string or structured filter.",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run builds the host filter.
+func (f *hostFilterFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var filter types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &filter)
+	if resp.Error != nil {
+		return
+	}
+	if filter.IsUnderlyingValueNull() {
+		resp.Error = function.NewArgumentFuncError(0, "filter must not be null")
+		return
+	}
+
+	value, err := filter.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		resp.Error = function.NewFuncError(err.Error())
+		return
+	}
+
+	var node hostFilterNode
+	if value.Type().Is(tftypes.String) {
+		var raw string
+		if err := value.As(&raw); err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+		node, err = parseHostFilter(raw)
+	} else {
+		node, err = hostFilterFromValue(value)
+	}
+	if err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid host filter: "+err.Error())
+		return
+	}
+
+	rendered := node.render(false)
+	// the rendered filter goes through the same grammar as filters written by hand
+	if _, err := parseHostFilter(rendered); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, "Invalid host filter: "+err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, rendered)
+}
+
+// hostFilterNode is a parsed host filter: a term, a negation or an and/or of several filters.
+type hostFilterNode struct {
+	operator string // "", "not", "and" or "or"
+	key      string // field, with the lookup appended after __ if any
+	value    string
+	operands []hostFilterNode
+}
+
+// render writes the filter in AAP's syntax; nested means parentheses are needed around and/or.
+func (n hostFilterNode) render(nested bool) string {
+	switch n.operator {
+	case "":
+		return n.key + "=" + quoteHostFilterValue(n.value)
+	case "not":
+		return "not " + n.operands[0].render(true)
+	default:
+		parts := make([]string, len(n.operands))
+		for i, operand := range n.operands {
+			parts[i] = operand.render(true)
+		}
+		rendered := strings.Join(parts, " "+n.operator+" ")
+		if nested && len(parts) > 1 {
+			return "(" + rendered + ")"
+		}
+		return rendered
+	}
+}
+
+// quoteHostFilterValue quotes values that would otherwise end the term early.
+func quoteHostFilterValue(value string) string {
+	if value == "" || strings.ContainsAny(value, " \t()=") {
+		return `"` + value + `"`
+	}
+	return value
+}
+
+// checkHostFilterTerm validates the key and value of a term.
+func checkHostFilterTerm(key string, value string) error {
+	field := key
+	if i := strings.LastIndex(key, "__"); i >= 0 && slices.Contains(hostFilterLookups, key[i+2:]) {
+		field = key[:i]
+	}
+	if !hostFilterField.MatchString(field) || strings.HasSuffix(field, "__") {
+		return fmt.Errorf("%q is not a valid field", field)
+	}
+	if strings.Contains(value, `"`) {
+		return fmt.Errorf("value %q of %s must not contain double quotes", value, key)
+	}
+	return nil
+}
+
+// hostFilterFromValue converts a structured filter into a filter node.
+func hostFilterFromValue(value tftypes.Value) (hostFilterNode, error) {
+	if value.IsNull() || !value.IsFullyKnown() {
+		return hostFilterNode{}, fmt.Errorf("filters must be known and not null")
+	}
+	if !value.Type().Is(tftypes.Object{}) && !value.Type().Is(tftypes.Map{}) {
+		return hostFilterNode{}, fmt.Errorf("filters must be objects, got %s", value.Type())
+	}
+	var attributes map[string]tftypes.Value
+	if err := value.As(&attributes); err != nil {
+		return hostFilterNode{}, err
+	}
+	keys := make([]string, 0, len(attributes))
+	for key := range attributes {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, operator := range []string{"and", "or", "not"} {
+		operand, ok := attributes[operator]
+		if !ok {
+			continue
+		}
+		if len(attributes) != 1 {
+			return hostFilterNode{}, fmt.Errorf("%s cannot be combined with %s in the same object", operator, strings.Join(keys, ", "))
+		}
+		if operator == "not" {
+			negated, err := hostFilterFromValue(operand)
+			if err != nil {
+				return hostFilterNode{}, err
+			}
+			return hostFilterNode{operator: operator, operands: []hostFilterNode{negated}}, nil
+		}
+
+		var elements []tftypes.Value
+		if err := operand.As(&elements); err != nil {
+			return hostFilterNode{}, fmt.Errorf("%s must be a list of filters", operator)
+		}
+		if len(elements) == 0 {
+			return hostFilterNode{}, fmt.Errorf("%s must contain at least one filter", operator)
+		}
+		node := hostFilterNode{operator: operator}
+		for _, element := range elements {
+			operand, err := hostFilterFromValue(element)
+			if err != nil {
+				return hostFilterNode{}, err
+			}
+			node.operands = append(node.operands, operand)
+		}
+		return node, nil
+	}
+
+	for _, key := range keys {
+		if key != "field" && key != "lookup" && key != "value" {
+			return hostFilterNode{}, fmt.Errorf("unexpected attribute %q, terms have field, lookup and value", key)
+		}
+	}
+	field, err := hostFilterString(attributes, "field")
+	if err != nil {
+		return hostFilterNode{}, err
+	}
+	if field == "" {
+		return hostFilterNode{}, fmt.Errorf("terms must have a field")
+	}
+	filterValue, err := hostFilterString(attributes, "value")
+	if err != nil {
+		return hostFilterNode{}, err
+	}
+	lookup, err := hostFilterString(attributes, "lookup")
+	if err != nil {
+		return hostFilterNode{}, err
+	}
+
+	key := field
+	if lookup != "" {
+		if !slices.Contains(hostFilterLookups, lookup) {
+			return hostFilterNode{}, fmt.Errorf("unknown lookup %q, expected one of %s", lookup, strings.Join(hostFilterLookups, ", "))
+		}
+		key += "__" + lookup
+	}
+	if err := checkHostFilterTerm(key, filterValue); err != nil {
+		return hostFilterNode{}, err
+	}
+	return hostFilterNode{key: key, value: filterValue}, nil
+}
+
+// hostFilterString returns the attribute rendered as a string; numbers and booleans are accepted as values.
+func hostFilterString(attributes map[string]tftypes.Value, name string) (string, error) {
+	value, ok := attributes[name]
+	if !ok || value.IsNull() {
+		return "", nil
+	}
+	switch {
+	case value.Type().Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case value.Type().Is(tftypes.Bool):
+		var b bool
+		if err := value.As(&b); err != nil {
+			return "", err
+		}
+		if b {
+			return "true", nil
+		}
+		return "false", nil
+	case value.Type().Is(tftypes.Number):
+		n := new(big.Float)
+		if err := value.As(&n); err != nil {
+			return "", err
+		}
+		return n.Text('g', -1), nil
+	default:
+		return "", fmt.Errorf("%s must be a string, number or bool", name)
+	}
+}
+
+// parseHostFilter parses a host filter written in AAP's syntax, where and binds tighter than or:
+//
+//	filter      = conjunction { "or" conjunction }
+//	conjunction = term { "and" term }
+//	term        = "not" term | "(" filter ")" | key "=" value
+func parseHostFilter(raw string) (hostFilterNode, error) {
+	tokens, err := tokenizeHostFilter(raw)
+	if err != nil {
+		return hostFilterNode{}, err
+	}
+	if len(tokens) == 0 {
+		return hostFilterNode{}, fmt.Errorf("the filter is empty")
+	}
+	p := hostFilterParser{tokens: tokens}
+	node, err := p.filter()
+	if err != nil {
+		return hostFilterNode{}, err
+	}
+	if p.pos < len(p.tokens) {
+		return hostFilterNode{}, fmt.Errorf("unexpected %q", p.tokens[p.pos])
+	}
+	return node, nil
+}
+
+// tokenizeHostFilter splits a host filter into parentheses, operators and key=value terms.
+func tokenizeHostFilter(raw string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(raw); {
+		switch c := raw[i]; {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(' || c == ')':
+			tokens = append(tokens, string(c))
+			i++
+		default:
+			start := i
+			for i < len(raw) && !strings.ContainsRune(" \t\n()", rune(raw[i])) {
+				if raw[i] == '"' {
+					end := strings.IndexByte(raw[i+1:], '"')
+					if end < 0 {
+						return nil, fmt.Errorf("unterminated quote in %q", raw[start:])
+					}
+					i += end + 1
+				}
+				i++
+			}
+			tokens = append(tokens, raw[start:i])
+		}
+	}
+	return tokens, nil
+}
+
+type hostFilterParser struct {
+	tokens []string
+	pos    int
+}
+
+// filter parses or-separated conjunctions.
+func (p *hostFilterParser) filter() (hostFilterNode, error) {
+	return p.operation("or", p.conjunction)
+}
+
+// conjunction parses and-separated terms.
+func (p *hostFilterParser) conjunction() (hostFilterNode, error) {
+	return p.operation("and", p.term)
+}
+
+// operation parses operands separated by operator, returning the operand alone when there is only one.
+func (p *hostFilterParser) operation(operator string, operand func() (hostFilterNode, error)) (hostFilterNode, error) {
+	first, err := operand()
+	if err != nil {
+		return hostFilterNode{}, err
+	}
+	operands := []hostFilterNode{first}
+	for p.pos < len(p.tokens) && p.tokens[p.pos] == operator {
+		p.pos++
+		next, err := operand()
+		if err != nil {
+			return hostFilterNode{}, err
+		}
+		operands = append(operands, next)
+	}
+	if len(operands) == 1 {
+		return first, nil
+	}
+	return hostFilterNode{operator: operator, operands: operands}, nil
+}
+
+func (p *hostFilterParser) term() (hostFilterNode, error) {
+	if p.pos >= len(p.tokens) {
+		return hostFilterNode{}, fmt.Errorf("the filter ends unexpectedly")
+	}
+	token := p.tokens[p.pos]
+	p.pos++
+	switch token {
+	case "not":
+		negated, err := p.term()
+		if err != nil {
+			return hostFilterNode{}, err
+		}
+		return hostFilterNode{operator: "not", operands: []hostFilterNode{negated}}, nil
+	case "(":
+		node, err := p.filter()
+		if err != nil {
+			return hostFilterNode{}, err
+		}
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] != ")" {
+			return hostFilterNode{}, fmt.Errorf("missing closing parenthesis")
+		}
+		p.pos++
+		return node, nil
+	case ")", "and", "or":
+		return hostFilterNode{}, fmt.Errorf("unexpected %q", token)
+	}
+
+	key, value, ok := strings.Cut(token, "=")
+	if !ok || key == "" {
+		return hostFilterNode{}, fmt.Errorf("%q is not a key=value term", token)
+	}
+	if strings.HasPrefix(value, `"`) {
+		if len(value) < 2 || !strings.HasSuffix(value, `"`) {
+			return hostFilterNode{}, fmt.Errorf("%q is not a key=value term", token)
+		}
+		value = value[1 : len(value)-1]
+	} else if value == "" {
+		return hostFilterNode{}, fmt.Errorf("%q has no value, quote empty values", token)
+	}
+	if err := checkHostFilterTerm(key, value); err != nil {
+		return hostFilterNode{}, err
+	}
+	return hostFilterNode{key: key, value: value}, nil
+}
@@ -0,0 +1,132 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestParseHostFilter(t *testing.T) {
+	tests := []struct {
+		filter   string
+		rendered string
+		err      string
+	}{
+		{filter: "name=web1", rendered: "name=web1"},
+		{filter: `name="web 1"`, rendered: `name="web 1"`},
+		{filter: `description=""`, rendered: `description=""`},
+		{filter: "groups__name=web and name__startswith=db", rendered: "groups__name=web and name__startswith=db"},
+		// and binds tighter than or, rendered filters spell it out with parentheses
+		{filter: "a=1 and b=2 or c=3", rendered: "(a=1 and b=2) or c=3"},
+		{filter: "a=1 and (b=2 or c=3)", rendered: "a=1 and (b=2 or c=3)"},
+		{filter: "not (a=1 or b=2)", rendered: "not (a=1 or b=2)"},
+		{filter: "ansible_facts__ansible_distribution=RedHat", rendered: "ansible_facts__ansible_distribution=RedHat"},
+		{filter: "", err: "the filter is empty"},
+		{filter: "name", err: `"name" is not a key=value term`},
+		{filter: "name=", err: "quote empty values"},
+		{filter: `name="web`, err: "unterminated quote"},
+		{filter: "(a=1", err: "missing closing parenthesis"},
+		{filter: "a=1 b=2", err: `unexpected "b=2"`},
+		{filter: "a=1 and", err: "the filter ends unexpectedly"},
+		{filter: "or a=1", err: `unexpected "or"`},
+		{filter: "na$me=1", err: `"na$me" is not a valid field`},
+		{filter: "name__=1", err: "is not a valid field"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.filter, func(t *testing.T) {
+			node, err := parseHostFilter(test.filter)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("error = %v, expected %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rendered := node.render(false); rendered != test.rendered {
+				t.Errorf("rendered = %s, expected %s", rendered, test.rendered)
+			}
+		})
+	}
+}
+
+// testHostFilterValue returns a structured filter as the function receives it, objects being maps of strings to values.
+func testHostFilterValue(value any) tftypes.Value {
+	switch value := value.(type) {
+	case map[string]any:
+		attributes := map[string]tftypes.Value{}
+		types := map[string]tftypes.Type{}
+		for key, attribute := range value {
+			attributes[key] = testHostFilterValue(attribute)
+			types[key] = attributes[key].Type()
+		}
+		return tftypes.NewValue(tftypes.Object{AttributeTypes: types}, attributes)
+	case []any:
+		elements := make([]tftypes.Value, len(value))
+		types := make([]tftypes.Type, len(value))
+		for i, element := range value {
+			elements[i] = testHostFilterValue(element)
+			types[i] = elements[i].Type()
+		}
+		return tftypes.NewValue(tftypes.Tuple{ElementTypes: types}, elements)
+	case string:
+		return tftypes.NewValue(tftypes.String, value)
+	case bool:
+		return tftypes.NewValue(tftypes.Bool, value)
+	case int:
+		return tftypes.NewValue(tftypes.Number, value)
+	}
+	panic("unsupported filter value")
+}
+
+func TestHostFilterFromValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		filter   map[string]any
+		rendered string
+		err      string
+	}{
+		{name: "term", filter: map[string]any{"field": "name", "value": "web1"}, rendered: "name=web1"},
+		{name: "lookup", filter: map[string]any{"field": "name", "lookup": "icontains", "value": "web"}, rendered: "name__icontains=web"},
+		{name: "number and bool values", filter: map[string]any{"and": []any{
+			map[string]any{"field": "variables__port", "value": 8080},
+			map[string]any{"field": "enabled", "value": true},
+		}}, rendered: "variables__port=8080 and enabled=true"},
+		{name: "nested", filter: map[string]any{"or": []any{
+			map[string]any{"and": []any{map[string]any{"field": "a", "value": "1"}, map[string]any{"field": "b", "value": "x y"}}},
+			map[string]any{"not": map[string]any{"or": []any{map[string]any{"field": "c", "value": "3"}, map[string]any{"field": "d", "value": "4"}}}},
+		}}, rendered: `(a=1 and b="x y") or not (c=3 or d=4)`},
+		{name: "operator with other attributes", filter: map[string]any{"and": []any{}, "field": "a"}, err: "and cannot be combined with and, field"},
+		{name: "empty operation", filter: map[string]any{"or": []any{}}, err: "or must contain at least one filter"},
+		{name: "unknown attribute", filter: map[string]any{"field": "a", "operator": "eq"}, err: `unexpected attribute "operator"`},
+		{name: "no field", filter: map[string]any{"value": "a"}, err: "terms must have a field"},
+		{name: "unknown lookup", filter: map[string]any{"field": "a", "lookup": "like", "value": "b"}, err: `unknown lookup "like"`},
+		{name: "double quotes", filter: map[string]any{"field": "a", "value": `say "hi"`}, err: "must not contain double quotes"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			node, err := hostFilterFromValue(testHostFilterValue(test.filter))
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("error = %v, expected %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			rendered := node.render(false)
+			if rendered != test.rendered {
+				t.Errorf("rendered = %s, expected %s", rendered, test.rendered)
+			}
+			// the rendered filter parses back to itself
+			if parsed, err := parseHostFilter(rendered); err != nil || parsed.render(false) != rendered {
+				t.Errorf("parsing %s gives %v, %v", rendered, parsed.render(false), err)
+			}
+		})
+	}
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &hostMetricsCleanupResource{}
+	_ resource.ResourceWithConfigure = &hostMetricsCleanupResource{}
+)
+
+// NewHostMetricsCleanupResource is a helper function to simplify the provider implementation.
+func NewHostMetricsCleanupResource() resource.Resource {
+	return &hostMetricsCleanupResource{}
+}
+
+// hostMetricsCleanupResource soft-deletes the host metrics of hosts that are no longer automated.
+type hostMetricsCleanupResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *hostMetricsCleanupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_metrics_cleanup"
+}
+
+// Schema defines the schema for the resource.
+func (r *hostMetricsCleanupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Soft-deletes the host metrics of hosts not automated for a number of days, so that they are no longer counted " +
+			"against the subscription. The cleanup runs when the resource is created and again whenever it is replaced, " +
+			"e.g. by changing triggers. Destroying the resource does not restore the host metrics.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the cleanup ran.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"stale_days": schema.Int64Attribute{
+				Required:    true,
+				Description: "Hosts last automated more than this many days ago are soft-deleted.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"triggers": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Arbitrary values whose change runs the cleanup again.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.RequiresReplace(),
+				},
+			},
+			"deleted_hostnames": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Hosts whose metrics were soft-deleted by the cleanup.",
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// hostMetricsCleanupResourceModel maps the resource schema data.
+type hostMetricsCleanupResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	StaleDays        types.Int64  `tfsdk:"stale_days"`
+	Triggers         types.Map    `tfsdk:"triggers"`
+	DeletedHostnames types.List   `tfsdk:"deleted_hostnames"`
+}
+
+// Create soft-deletes the stale host metrics.
+func (r *hostMetricsCleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_host_metrics_cleanup", "create", &resp.Diagnostics)
+	var plan hostMetricsCleanupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	now := time.Now().UTC()
+	cutoff := now.AddDate(0, 0, -int(plan.StaleDays.ValueInt64()))
+	metrics, err := r.client.GetHostMetrics(false, cutoff.Format(time.RFC3339))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read host metrics", err.Error())
+		return
+	}
+
+	deleted := []string{}
+	for _, metric := range metrics {
+		if err := r.client.DeleteHostMetric(metric.Id); err != nil {
+			resp.Diagnostics.AddError("Unable to delete host metric", fmt.Sprintf("Host %s: %s", metric.Hostname, err))
+			return
+		}
+		deleted = append(deleted, metric.Hostname)
+	}
+	plan.Id = types.StringValue(now.Format(time.RFC3339))
+	var diags diag.Diagnostics
+	plan.DeletedHostnames, diags = types.ListValueFrom(ctx, types.StringType, deleted)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read keeps the outcome of the cleanup; there is nothing to refresh.
+func (r *hostMetricsCleanupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_host_metrics_cleanup", "read", &resp.Diagnostics)
+	var state hostMetricsCleanupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called as every configurable attribute requires replacement.
+func (r *hostMetricsCleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_host_metrics_cleanup", "update", &resp.Diagnostics)
+	var plan hostMetricsCleanupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete only removes the resource from the state; soft-deleted host metrics stay deleted.
+func (r *hostMetricsCleanupResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *hostMetricsCleanupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+	"time"
+)
+
+func TestHostMetricsCleanupResource(t *testing.T) {
+	mock := newMockAAP(t)
+	stale := mock.addObject("api/v2/host_metrics", map[string]any{"hostname": "old.example.com", "last_automation": "2020-01-01T00:00:00Z", "deleted": false})
+	fresh := mock.addObject("api/v2/host_metrics", map[string]any{"hostname": "web.example.com", "last_automation": time.Now().UTC().Format(time.RFC3339), "deleted": false})
+
+	p := newTestProvider(t, mock, nil)
+	cleanup := p.resource("aap_host_metrics_cleanup")
+	config := map[string]any{"stale_days": 30, "triggers": map[string]any{"run": "1"}}
+	state := cleanup.apply(config)
+
+	testExpect(t, state, map[string]any{"deleted_hostnames": []any{"old.example.com"}})
+	if mock.object("api/v2/host_metrics", stale)["deleted"] != true || mock.object("api/v2/host_metrics", fresh)["deleted"] != false {
+		t.Error("only the metrics of hosts not automated for 30 days should be soft-deleted")
+	}
+	if changes := cleanup.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after apply changes %v", changes)
+	}
+
+	metrics := p.readDataSource("aap_host_metrics", map[string]any{"include_deleted": true})
+	if hosts, _ := metrics["hosts"].([]any); len(hosts) != 2 {
+		t.Errorf("aap_host_metrics lists %v, expected both hosts", metrics["hosts"])
+	}
+	metrics = p.readDataSource("aap_host_metrics", nil)
+	if hosts, _ := metrics["hosts"].([]any); len(hosts) != 1 || hosts[0].(map[string]any)["hostname"] != "web.example.com" {
+		t.Errorf("aap_host_metrics lists %v, expected the host still automated", metrics["hosts"])
+	}
+
+	// changing the triggers runs the cleanup again
+	mock.addObject("api/v2/host_metrics", map[string]any{"hostname": "db.example.com", "last_automation": "2021-06-01T00:00:00Z", "deleted": false})
+	state = cleanup.apply(map[string]any{"stale_days": 30, "triggers": map[string]any{"run": "2"}})
+	testExpect(t, state, map[string]any{"deleted_hostnames": []any{"db.example.com"}})
+
+	cleanup.destroy()
+}
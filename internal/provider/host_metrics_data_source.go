@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &hostMetricsDataSource{}
+	_ datasource.DataSourceWithConfigure = &hostMetricsDataSource{}
+)
+
+// NewHostMetricsDataSource is a helper function to simplify the provider implementation.
+func NewHostMetricsDataSource() datasource.DataSource {
+	return &hostMetricsDataSource{}
+}
+
+// hostMetricsDataSource lists the automated hosts counted by the controller.
+type hostMetricsDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *hostMetricsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_host_metrics"
+}
+
+// Schema defines the schema for the data source.
+func (d *hostMetricsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the host metrics of the automation controller, i.e. the automated hosts counted against the subscription.",
+		Attributes: map[string]schema.Attribute{
+			"include_deleted": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Whether soft-deleted host metrics are listed too. Defaults to false.",
+			},
+			"last_automation_before": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list hosts last automated before this RFC 3339 time.",
+				Validators:  timestampValidators(),
+			},
+			"hosts": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Host metrics, ordered as returned by the controller.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the host metric.",
+						},
+						"hostname": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the host.",
+						},
+						"first_automation": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the host was first automated.",
+						},
+						"last_automation": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the host was last automated.",
+						},
+						"automated_counter": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of times the host was automated.",
+						},
+						"deleted": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the host metric is soft-deleted.",
+						},
+						"deleted_counter": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of times the host metric was soft-deleted.",
+						},
+						"last_deleted": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the host metric was last soft-deleted.",
+						},
+						"used_in_inventories": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of inventories the host is in.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// hostMetricsDataSourceModel maps the data source schema data.
+type hostMetricsDataSourceModel struct {
+	IncludeDeleted       types.Bool        `tfsdk:"include_deleted"`
+	LastAutomationBefore types.String      `tfsdk:"last_automation_before"`
+	Hosts                []hostMetricModel `tfsdk:"hosts"`
+}
+
+// hostMetricModel maps a host metric.
+type hostMetricModel struct {
+	Id                types.Int64  `tfsdk:"id"`
+	Hostname          types.String `tfsdk:"hostname"`
+	FirstAutomation   types.String `tfsdk:"first_automation"`
+	LastAutomation    types.String `tfsdk:"last_automation"`
+	AutomatedCounter  types.Int64  `tfsdk:"automated_counter"`
+	Deleted           types.Bool   `tfsdk:"deleted"`
+	DeletedCounter    types.Int64  `tfsdk:"deleted_counter"`
+	LastDeleted       types.String `tfsdk:"last_deleted"`
+	UsedInInventories types.Int64  `tfsdk:"used_in_inventories"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *hostMetricsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_host_metrics", "read", &resp.Diagnostics)
+	var state hostMetricsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	metrics, err := d.client.GetHostMetrics(state.IncludeDeleted.ValueBool(), state.LastAutomationBefore.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read host metrics", err.Error())
+		return
+	}
+
+	state.Hosts = make([]hostMetricModel, len(metrics))
+	for i, metric := range metrics {
+		state.Hosts[i] = hostMetricModel{
+			Id:                types.Int64Value(metric.Id),
+			Hostname:          types.StringValue(metric.Hostname),
+			FirstAutomation:   types.StringValue(metric.FirstAutomation),
+			LastAutomation:    types.StringValue(metric.LastAutomation),
+			AutomatedCounter:  types.Int64Value(metric.AutomatedCounter),
+			Deleted:           types.BoolValue(metric.Deleted),
+			DeletedCounter:    types.Int64Value(metric.DeletedCounter),
+			LastDeleted:       types.StringPointerValue(metric.LastDeleted),
+			UsedInInventories: types.Int64PointerValue(metric.UsedInInventories),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *hostMetricsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
@@ -0,0 +1,144 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &hostsDiffFunction{}
+
+// hostsDiffAttributeTypes are the attributes of the result of hosts_diff.
+var hostsDiffAttributeTypes = map[string]attr.Type{
+	"added":   types.ListType{ElemType: types.StringType},
+	"removed": types.ListType{ElemType: types.StringType},
+	"changed": types.ListType{ElemType: types.StringType},
+}
+
+// NewHostsDiffFunction is a helper function to simplify the provider implementation.
+func NewHostsDiffFunction() function.Function {
+	return &hostsDiffFunction{}
+}
+
+// hostsDiffFunction compares two maps of hosts by name.
+type hostsDiffFunction struct{}
+
+// Metadata returns the function name.
+func (f *hostsDiffFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "hosts_diff"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *hostsDiffFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	hostsParameter := func(name string, description string) function.DynamicParameter {
+		return function.DynamicParameter{
+			Name:           name,
+			Description:    description,
+			AllowNullValue: true,
+		}
+	}
+
+	resp.Definition = function.Definition{
+		Summary: "Compare two sets of hosts",
+		Description: "Compares two objects or maps of hosts keyed by host name, e.g. the hosts of an inventory before and after a change, " +
+			"and returns the sorted names of the hosts added in b, removed from a, and present in both with a different value. " +
+			"Values may be variables documents, compared as decoded JSON so that formatting differences are ignored, or any other value.",
+		Parameters: []function.Parameter{
+			hostsParameter("a", "Hosts before the change, by name; null for none."),
+			hostsParameter("b", "Hosts after the change, by name; null for none."),
+		},
+		Return: function.ObjectReturn{
+			AttributeTypes: hostsDiffAttributeTypes,
+		},
+	}
+}
+
+// Run compares the hosts.
+func (f *hostsDiffFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var a, b types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &a, &b)
+	if resp.Error != nil {
+		return
+	}
+
+	before, funcErr := hostsDiffHosts(ctx, 0, a)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+	after, funcErr := hostsDiffHosts(ctx, 1, b)
+	if funcErr != nil {
+		resp.Error = funcErr
+		return
+	}
+
+	added, removed, changed := []attr.Value{}, []attr.Value{}, []attr.Value{}
+	for _, name := range sortedKeys(after) {
+		value, ok := before[name]
+		switch {
+		case !ok:
+			added = append(added, types.StringValue(name))
+		case !bytes.Equal(value, after[name]):
+			changed = append(changed, types.StringValue(name))
+		}
+	}
+	for _, name := range sortedKeys(before) {
+		if _, ok := after[name]; !ok {
+			removed = append(removed, types.StringValue(name))
+		}
+	}
+
+	result, diags := types.ObjectValue(hostsDiffAttributeTypes, map[string]attr.Value{
+		"added":   types.ListValueMust(types.StringType, added),
+		"removed": types.ListValueMust(types.StringType, removed),
+		"changed": types.ListValueMust(types.StringType, changed),
+	})
+	if diags.HasError() {
+		resp.Error = function.FuncErrorFromDiags(ctx, diags)
+		return
+	}
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+// hostsDiffHosts returns the JSON encoding of the value of each host of an argument of hosts_diff, by name.
+// Variables documents are decoded first, so that hosts only differ when their variables do.
+func hostsDiffHosts(ctx context.Context, position int64, hosts types.Dynamic) (map[string][]byte, *function.FuncError) {
+	encoded := make(map[string][]byte)
+	if hosts.IsNull() || hosts.IsUnderlyingValueNull() {
+		return encoded, nil
+	}
+	if hosts.IsUnknown() {
+		return nil, function.NewArgumentFuncError(position, "hosts must be known")
+	}
+
+	value, err := hosts.UnderlyingValue().ToTerraformValue(ctx)
+	if err != nil {
+		return nil, function.NewFuncError(err.Error())
+	}
+	if !value.Type().Is(tftypes.Object{}) && !value.Type().Is(tftypes.Map{}) {
+		return nil, function.NewArgumentFuncError(position, "hosts must be an object or a map keyed by host name")
+	}
+	decoded, err := variablesValue(value)
+	if err != nil {
+		return nil, function.NewArgumentFuncError(position, err.Error())
+	}
+
+	for name, host := range decoded.(map[string]interface{}) {
+		if document, ok := host.(string); ok {
+			if variables, err := decodeVariables(document); err == nil {
+				host = variables
+			}
+		}
+		// maps are encoded with sorted keys, making the encodings comparable
+		if encoded[name], err = json.Marshal(host); err != nil {
+			return nil, function.NewFuncError(err.Error())
+		}
+	}
+	return encoded, nil
+}
@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestHostsDiffFunction(t *testing.T) {
+	hosts := func(values map[string]tftypes.Value) tftypes.Value {
+		types := map[string]tftypes.Type{}
+		for name, value := range values {
+			types[name] = value.Type()
+		}
+		return tftypes.NewValue(tftypes.Object{AttributeTypes: types}, values)
+	}
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+	null := tftypes.NewValue(tftypes.DynamicPseudoType, nil)
+
+	tests := []struct {
+		name    string
+		a       tftypes.Value
+		b       tftypes.Value
+		added   []string
+		removed []string
+		changed []string
+		err     string
+		arg     int64
+	}{
+		{name: "no hosts", a: null, b: null},
+		{name: "added", a: null, b: hosts(map[string]tftypes.Value{"web2": str(""), "web1": str("")}), added: []string{"web1", "web2"}},
+		{name: "removed", a: hosts(map[string]tftypes.Value{"web1": str(""), "db1": str("")}), b: hosts(map[string]tftypes.Value{"web1": str("")}), removed: []string{"db1"}},
+		{
+			name:    "variables compared decoded",
+			a:       hosts(map[string]tftypes.Value{"web1": str(`{"a": 1, "b": {"c": true}}`), "web2": str(`{"port": 80}`)}),
+			b:       hosts(map[string]tftypes.Value{"web1": str(`{"b":{"c":true},"a":1}`), "web2": str(`{"port": 8080}`)}),
+			changed: []string{"web2"},
+		},
+		{
+			name:    "other values",
+			a:       hosts(map[string]tftypes.Value{"web1": str("a: 1"), "web2": tftypes.NewValue(tftypes.Number, 1)}),
+			b:       tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"web1": str("a: 2"), "web2": str("1"), "web3": str("")}),
+			added:   []string{"web3"},
+			changed: []string{"web1", "web2"},
+		},
+		{
+			name: "objects",
+			a:    hosts(map[string]tftypes.Value{"web1": hosts(map[string]tftypes.Value{"port": tftypes.NewValue(tftypes.Number, 80)})}),
+			b:    hosts(map[string]tftypes.Value{"web1": hosts(map[string]tftypes.Value{"port": tftypes.NewValue(tftypes.Number, 80)})}),
+		},
+		{name: "list", a: tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{str("web1")}), b: null, err: "hosts must be an object or a map", arg: 0},
+		{name: "string", a: null, b: str("web1"), err: "hosts must be an object or a map", arg: 1},
+		{name: "unknown", a: null, b: tftypes.NewValue(tftypes.DynamicPseudoType, tftypes.UnknownValue), err: "hosts must be known", arg: 1},
+		{
+			name: "unknown host",
+			a:    hosts(map[string]tftypes.Value{"web1": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+			b:    null,
+			err:  "variables must be known",
+			arg:  0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testRunFunction(t, NewHostsDiffFunction(), testDynamicValue(t, test.a), testDynamicValue(t, test.b))
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Text, test.err) || err.FunctionArgument == nil || *err.FunctionArgument != test.arg {
+					t.Fatalf("error = %v, expected %q for argument %d", err, test.err, test.arg)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			attributes := result.(types.Object).Attributes()
+			for name, expected := range map[string][]string{"added": test.added, "removed": test.removed, "changed": test.changed} {
+				names := []string{}
+				for _, element := range attributes[name].(types.List).Elements() {
+					names = append(names, element.(types.String).ValueString())
+				}
+				if expected == nil {
+					expected = []string{}
+				}
+				if !reflect.DeepEqual(names, expected) {
+					t.Errorf("%s = %v, expected %v", name, names, expected)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,300 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// hubPulpAPIPath is the base path of the pulp API served by Automation Hub.
+const hubPulpAPIPath string = "api/galaxy/pulp/api/v3/"
+
+// pulp task
+type pulpTask struct {
+	PulpHref         string                 `json:"pulp_href"`
+	State            string                 `json:"state"`
+	Error            map[string]interface{} `json:"error"`
+	CreatedResources []string               `json:"created_resources"`
+}
+
+// response of pulp endpoints that run asynchronously
+type pulpAsyncResponse struct {
+	Task string `json:"task"`
+}
+
+// hub collection remote
+type HubRemote struct {
+	PulpHref         string  `json:"pulp_href,omitempty"`
+	Name             string  `json:"name"`
+	URL              string  `json:"url"`
+	RequirementsFile *string `json:"requirements_file"`
+	AuthURL          *string `json:"auth_url"`
+	Token            *string `json:"token,omitempty"`
+	Username         *string `json:"username,omitempty"`
+	Password         *string `json:"password,omitempty"`
+	ProxyURL         *string `json:"proxy_url"`
+	TLSValidation    bool    `json:"tls_validation"`
+	SignedOnly       bool    `json:"signed_only"`
+	SyncDependencies bool    `json:"sync_dependencies"`
+}
+
+// hub collection repository
+type HubRepository struct {
+	PulpHref           string  `json:"pulp_href,omitempty"`
+	Name               string  `json:"name"`
+	Description        *string `json:"description"`
+	Remote             *string `json:"remote"`
+	RetainRepoVersions *int64  `json:"retain_repo_versions"`
+	Private            bool    `json:"private"`
+	LatestVersionHref  string  `json:"latest_version_href,omitempty"`
+}
+
+// hub collection distribution, serving a repository under a base path
+type HubDistribution struct {
+	PulpHref   string `json:"pulp_href,omitempty"`
+	Name       string `json:"name"`
+	BasePath   string `json:"base_path"`
+	Repository string `json:"repository"`
+}
+
+// WaitForPulpTask polls the task until it finishes and returns it, or an error if it failed.
+func (c *AAPClient) WaitForPulpTask(ctx context.Context, taskHref string) (*pulpTask, error) {
+	for {
+		task, err := getObject[pulpTask](c, taskHref)
+		if err != nil {
+			return nil, err
+		}
+		if task == nil {
+			return nil, fmt.Errorf("task %s not found", taskHref)
+		}
+		switch task.State {
+		case "completed":
+			return task, nil
+		case "failed", "canceled":
+			return nil, fmt.Errorf("task %s %s: %v", taskHref, task.State, task.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(c.pollDelay()):
+		}
+	}
+}
+
+// pulpAsync sends a request to an endpoint answering with a task and waits for the task to finish.
+func (c *AAPClient) pulpAsync(ctx context.Context, method string, endpoint string, in any) (*pulpTask, error) {
+	var async pulpAsyncResponse
+	status, err := c.doJSON(method, endpoint, in, &async, http.StatusAccepted, http.StatusNotFound)
+	if err != nil {
+		return nil, err
+	}
+	if status == http.StatusNotFound {
+		// deleting an object that is already gone
+		return &pulpTask{State: "completed"}, nil
+	}
+	return c.WaitForPulpTask(ctx, async.Task)
+}
+
+func (c *AAPClient) GetHubRemote(href string) (*HubRemote, error) {
+	return getObject[HubRemote](c, href)
+}
+
+func (c *AAPClient) CreateHubRemote(remote HubRemote) (*HubRemote, error) {
+	return createObject(c, hubPulpAPIPath+"remotes/ansible/collection/", remote)
+}
+
+// UpdateHubRemote sets the fields of the remote. Pulp keeps the credentials left out of remote,
+// the ones listed in clear are removed by sending them as null.
+func (c *AAPClient) UpdateHubRemote(ctx context.Context, href string, remote HubRemote, clear ...string) (*HubRemote, error) {
+	var body any = remote
+	if len(clear) > 0 {
+		encoded, err := json.Marshal(remote)
+		if err != nil {
+			return nil, err
+		}
+		var fields map[string]any
+		if err := json.Unmarshal(encoded, &fields); err != nil {
+			return nil, err
+		}
+		for _, field := range clear {
+			fields[field] = nil
+		}
+		body = fields
+	}
+	if _, err := c.pulpAsync(ctx, http.MethodPatch, href, body); err != nil {
+		return nil, err
+	}
+	return c.GetHubRemote(href)
+}
+
+func (c *AAPClient) DeleteHubRemote(ctx context.Context, href string) error {
+	_, err := c.pulpAsync(ctx, http.MethodDelete, href, nil)
+	return err
+}
+
+func (c *AAPClient) GetHubRepository(href string) (*HubRepository, error) {
+	return getObject[HubRepository](c, href)
+}
+
+func (c *AAPClient) CreateHubRepository(repository HubRepository) (*HubRepository, error) {
+	return createObject(c, hubPulpAPIPath+"repositories/ansible/ansible/", repository)
+}
+
+func (c *AAPClient) UpdateHubRepository(ctx context.Context, href string, repository HubRepository) (*HubRepository, error) {
+	if _, err := c.pulpAsync(ctx, http.MethodPatch, href, repository); err != nil {
+		return nil, err
+	}
+	return c.GetHubRepository(href)
+}
+
+func (c *AAPClient) DeleteHubRepository(ctx context.Context, href string) error {
+	_, err := c.pulpAsync(ctx, http.MethodDelete, href, nil)
+	return err
+}
+
+// SyncHubRepository syncs the repository from its remote and waits for the sync to finish.
+func (c *AAPClient) SyncHubRepository(ctx context.Context, href string, mirror bool) error {
+	_, err := c.pulpAsync(ctx, http.MethodPost, buildEndpoint(href, []string{"sync"}, nil), map[string]any{"mirror": mirror})
+	return err
+}
+
+// GetHubDistributionByRepository returns the distribution serving the repository, or nil if there is none.
+func (c *AAPClient) GetHubDistributionByRepository(repositoryHref string) (*HubDistribution, error) {
+	distributions, err := listAll[HubDistribution](c, buildEndpoint(hubPulpAPIPath+"distributions/ansible/ansible/", nil, url.Values{"repository": {repositoryHref}}))
+	if err != nil || len(distributions) == 0 {
+		return nil, err
+	}
+	return &distributions[0], nil
+}
+
+// CreateHubDistribution creates the distribution and returns its href.
+func (c *AAPClient) CreateHubDistribution(ctx context.Context, distribution HubDistribution) (string, error) {
+	task, err := c.pulpAsync(ctx, http.MethodPost, hubPulpAPIPath+"distributions/ansible/ansible/", distribution)
+	if err != nil {
+		return "", err
+	}
+	if len(task.CreatedResources) == 0 {
+		return "", fmt.Errorf("task %s did not create a distribution", task.PulpHref)
+	}
+	return task.CreatedResources[0], nil
+}
+
+func (c *AAPClient) UpdateHubDistribution(ctx context.Context, href string, distribution HubDistribution) error {
+	_, err := c.pulpAsync(ctx, http.MethodPatch, href, distribution)
+	return err
+}
+
+func (c *AAPClient) DeleteHubDistribution(ctx context.Context, href string) error {
+	_, err := c.pulpAsync(ctx, http.MethodDelete, href, nil)
+	return err
+}
+
+// hubGalaxyAPIPath is the base path of the Galaxy API served by Automation Hub.
+const hubGalaxyAPIPath string = "api/galaxy/v3/"
+
+// hub collection version
+type HubCollectionVersion struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	Version   string `json:"version"`
+	Href      string `json:"href"`
+}
+
+// collectionVersionPath returns the path segments of a collection version relative to a collections endpoint.
+func collectionVersionPath(namespace string, name string, version string) []string {
+	return []string{"collections", namespace, name, "versions", version}
+}
+
+// GetHubCollectionVersion returns the collection version in the given repository, or nil if it is not there.
+func (c *AAPClient) GetHubCollectionVersion(repository string, namespace string, name string, version string) (*HubCollectionVersion, error) {
+	// the collections of a repository are listed under collections/index/, next to its other endpoints
+	segments := []string{repository, "collections", "index", namespace, name, "versions", version}
+	return getObject[HubCollectionVersion](c, buildEndpoint(hubGalaxyAPIPath+"plugin/ansible/content/", segments, nil))
+}
+
+// MoveHubCollectionVersion moves a collection version between repositories, e.g. from staging to
+// published to approve it, and waits for the resulting tasks to finish.
+func (c *AAPClient) MoveHubCollectionVersion(ctx context.Context, namespace string, name string, version string, source string, destination string) error {
+	endpoint := buildEndpoint(hubGalaxyAPIPath, append(collectionVersionPath(namespace, name, version), "move", source, destination), nil)
+
+	// depending on the hub version the response holds one or several task ids
+	var tasks map[string]interface{}
+	if _, err := c.doJSON(http.MethodPost, endpoint, map[string]any{}, &tasks, http.StatusAccepted, http.StatusOK); err != nil {
+		return err
+	}
+	for key, value := range tasks {
+		taskId, ok := value.(string)
+		if !ok || taskId == "" || !strings.HasSuffix(key, "task_id") {
+			continue
+		}
+		if _, err := c.WaitForPulpTask(ctx, buildEndpoint(hubPulpAPIPath+"tasks/", []string{taskId}, nil)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// hub RBAC group
+type HubGroup struct {
+	PulpHref string `json:"pulp_href,omitempty"`
+	Id       int64  `json:"id,omitempty"`
+	Name     string `json:"name"`
+}
+
+// role granted to a hub group, globally or on a single object
+type HubGroupRole struct {
+	PulpHref      string  `json:"pulp_href,omitempty"`
+	Role          string  `json:"role"`
+	ContentObject *string `json:"content_object"`
+}
+
+func (c *AAPClient) GetHubGroup(href string) (*HubGroup, error) {
+	return getObject[HubGroup](c, href)
+}
+
+func (c *AAPClient) CreateHubGroup(group HubGroup) (*HubGroup, error) {
+	return createObject(c, hubPulpAPIPath+"groups/", group)
+}
+
+func (c *AAPClient) UpdateHubGroup(href string, group HubGroup) (*HubGroup, error) {
+	return updateObject(c, http.MethodPatch, href, group)
+}
+
+func (c *AAPClient) DeleteHubGroup(href string) error {
+	return c.deleteObject(href)
+}
+
+func (c *AAPClient) CreateHubGroupRole(groupHref string, role HubGroupRole) (*HubGroupRole, error) {
+	return createObject(c, buildEndpoint(groupHref, []string{"roles"}, nil), role)
+}
+
+func (c *AAPClient) DeleteHubGroupRole(href string) error {
+	return c.deleteObject(href)
+}
+
+// group owning a hub namespace, with the roles it holds on the namespace
+type HubNamespaceGroup struct {
+	Id          int64    `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	ObjectRoles []string `json:"object_roles"`
+}
+
+// hub collection namespace, only the fields needed to manage its groups
+type HubNamespace struct {
+	Name   string              `json:"name"`
+	Groups []HubNamespaceGroup `json:"groups"`
+}
+
+func (c *AAPClient) GetHubNamespace(name string) (*HubNamespace, error) {
+	return getObject[HubNamespace](c, buildEndpoint(hubGalaxyAPIPath+"namespaces/", []string{name}, nil))
+}
+
+// UpdateHubNamespaceGroups replaces the groups of the namespace.
+func (c *AAPClient) UpdateHubNamespaceGroups(name string, groups []HubNamespaceGroup) (*HubNamespace, error) {
+	return updateObject(c, http.MethodPatch, buildEndpoint(hubGalaxyAPIPath+"namespaces/", []string{name}, nil), HubNamespace{Name: name, Groups: groups})
+}
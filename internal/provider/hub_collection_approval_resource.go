@@ -0,0 +1,204 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &hubCollectionApprovalResource{}
+	_ resource.ResourceWithConfigure = &hubCollectionApprovalResource{}
+)
+
+// NewHubCollectionApprovalResource is a helper function to simplify the provider implementation.
+func NewHubCollectionApprovalResource() resource.Resource {
+	return &hubCollectionApprovalResource{}
+}
+
+// hubCollectionApprovalResource approves an uploaded collection version by moving it to the published repository.
+type hubCollectionApprovalResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *hubCollectionApprovalResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hub_collection_approval"
+}
+
+// Schema defines the schema for the resource.
+func (r *hubCollectionApprovalResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Approves a collection version by moving it from the staging repository to the published one. " +
+			"Destroying the resource does not revoke the approval.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Href of the approved collection version.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"namespace": schema.StringAttribute{
+				Required:      true,
+				Description:   "Namespace of the collection.",
+				PlanModifiers: replace,
+			},
+			"name": schema.StringAttribute{
+				Required:      true,
+				Description:   "Name of the collection.",
+				PlanModifiers: replace,
+			},
+			"version": schema.StringAttribute{
+				Required:      true,
+				Description:   "Version to approve.",
+				PlanModifiers: replace,
+			},
+			"source_repository": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString("staging"),
+				Description:   "Repository holding the collection version awaiting approval.",
+				PlanModifiers: replace,
+			},
+			"destination_repository": schema.StringAttribute{
+				Optional:      true,
+				Computed:      true,
+				Default:       stringdefault.StaticString("published"),
+				Description:   "Repository the approved collection version is moved to.",
+				PlanModifiers: replace,
+			},
+		},
+	}
+}
+
+// hubCollectionApprovalResourceModel maps the resource schema data.
+type hubCollectionApprovalResourceModel struct {
+	Id                    types.String `tfsdk:"id"`
+	Namespace             types.String `tfsdk:"namespace"`
+	Name                  types.String `tfsdk:"name"`
+	Version               types.String `tfsdk:"version"`
+	SourceRepository      types.String `tfsdk:"source_repository"`
+	DestinationRepository types.String `tfsdk:"destination_repository"`
+}
+
+// getApproved returns the collection version from the destination repository, or nil if it is not there.
+func (r *hubCollectionApprovalResource) getApproved(model hubCollectionApprovalResourceModel) (*HubCollectionVersion, error) {
+	return r.client.GetHubCollectionVersion(
+		model.DestinationRepository.ValueString(),
+		model.Namespace.ValueString(),
+		model.Name.ValueString(),
+		model.Version.ValueString(),
+	)
+}
+
+// Create approves the collection version, unless it was already approved.
+func (r *hubCollectionApprovalResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_collection_approval", "create", &resp.Diagnostics)
+	var plan hubCollectionApprovalResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	approved, err := r.getApproved(plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read collection version", err.Error())
+		return
+	}
+
+	if approved == nil {
+		err := r.client.MoveHubCollectionVersion(
+			ctx,
+			plan.Namespace.ValueString(),
+			plan.Name.ValueString(),
+			plan.Version.ValueString(),
+			plan.SourceRepository.ValueString(),
+			plan.DestinationRepository.ValueString(),
+		)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to approve collection version", err.Error())
+			return
+		}
+
+		approved, err = r.getApproved(plan)
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read collection version", err.Error())
+			return
+		}
+		if approved == nil {
+			resp.Diagnostics.AddError(
+				"Unable to approve collection version",
+				fmt.Sprintf("%s.%s %s is not in the %s repository after the move.",
+					plan.Namespace.ValueString(), plan.Name.ValueString(), plan.Version.ValueString(), plan.DestinationRepository.ValueString()),
+			)
+			return
+		}
+	}
+	plan.Id = types.StringValue(approved.Href)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read removes the resource from the state when the collection version is no longer approved.
+func (r *hubCollectionApprovalResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_collection_approval", "read", &resp.Diagnostics)
+	var state hubCollectionApprovalResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	approved, err := r.getApproved(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read collection version", err.Error())
+		return
+	}
+	if approved == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Id = types.StringValue(approved.Href)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called as every attribute requires replacement.
+func (r *hubCollectionApprovalResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_collection_approval", "update", &resp.Diagnostics)
+	resp.Diagnostics.AddError("Unexpected update", "Collection approvals cannot be updated in place. Please report this issue to the provider developers.")
+}
+
+// Delete only removes the resource from the state, the collection version stays published.
+func (r *hubCollectionApprovalResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *hubCollectionApprovalResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
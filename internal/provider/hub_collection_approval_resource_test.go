@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHubCollectionApprovalResource(t *testing.T) {
+	mock := newMockAAP(t)
+	versions := hubGalaxyAPIPath + "collection_versions"
+	version := mock.addObject(versions, map[string]any{"namespace": "acme", "name": "tools", "version": "1.0.0", "repository": "staging"})
+
+	p := newTestProvider(t, mock, nil)
+	missing := p.resource("aap_hub_collection_approval")
+	if message := missing.tryApply(map[string]any{"namespace": "acme", "name": "tools", "version": "2.0.0"}); !strings.Contains(message, "Unable to approve collection version") {
+		t.Errorf("approving a missing version gives %q", message)
+	}
+
+	approval := p.resource("aap_hub_collection_approval")
+	config := map[string]any{"namespace": "acme", "name": "tools", "version": "1.0.0"}
+	state := approval.apply(config)
+	testExpect(t, state, map[string]any{
+		"id":                     "/" + hubGalaxyAPIPath + "plugin/ansible/content/published/collections/index/acme/tools/versions/1.0.0/",
+		"source_repository":      "staging",
+		"destination_repository": "published",
+	})
+	testExpect(t, mock.object(versions, version), map[string]any{"repository": "published"})
+
+	approval.read()
+	if changes := approval.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	// an approved version is not moved again
+	again := p.resource("aap_hub_collection_approval")
+	again.apply(config)
+	if count := mock.requestCount("POST", hubGalaxyAPIPath+"collections/acme/tools/versions/1.0.0/move/staging/published"); count != 1 {
+		t.Errorf("collection version moved %d times, expected once", count)
+	}
+
+	approval.destroy()
+	testExpect(t, mock.object(versions, version), map[string]any{"repository": "published"})
+}
@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &hubGroupResource{}
+	_ resource.ResourceWithConfigure   = &hubGroupResource{}
+	_ resource.ResourceWithImportState = &hubGroupResource{}
+)
+
+// NewHubGroupResource is a helper function to simplify the provider implementation.
+func NewHubGroupResource() resource.Resource {
+	return &hubGroupResource{}
+}
+
+// hubGroupResource manages an Automation Hub RBAC group.
+type hubGroupResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *hubGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hub_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *hubGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an Automation Hub group that roles can be granted to.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Pulp href of the group.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Numeric id of the group.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the group.",
+				Validators:  nameValidators(),
+			},
+		},
+	}
+}
+
+// hubGroupResourceModel maps the resource schema data.
+type hubGroupResourceModel struct {
+	Id      types.String `tfsdk:"id"`
+	GroupId types.Int64  `tfsdk:"group_id"`
+	Name    types.String `tfsdk:"name"`
+}
+
+// hubGroupAPIFields maps the fields of the hub group API to the attributes they are set from.
+var hubGroupAPIFields = map[string]string{
+	"name": "name",
+}
+
+func (m *hubGroupResourceModel) setGroup(group *HubGroup) {
+	m.Id = types.StringValue(group.PulpHref)
+	m.GroupId = types.Int64Value(group.Id)
+	m.Name = types.StringValue(group.Name)
+}
+
+// Create creates the group.
+func (r *hubGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_group", "create", &resp.Diagnostics)
+	var plan hubGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client.CreateHubGroup(HubGroup{Name: plan.Name.ValueString()})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create hub group", err, hubGroupAPIFields)...)
+		return
+	}
+	plan.setGroup(group)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *hubGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_group", "read", &resp.Diagnostics)
+	var state hubGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client.GetHubGroup(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read hub group", err.Error())
+		return
+	}
+	if group == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setGroup(group)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update renames the group.
+func (r *hubGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_group", "update", &resp.Diagnostics)
+	var plan hubGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	group, err := r.client.UpdateHubGroup(plan.Id.ValueString(), HubGroup{Name: plan.Name.ValueString()})
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update hub group", err, hubGroupAPIFields)...)
+		return
+	}
+	plan.setGroup(group)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the group.
+func (r *hubGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_group", "delete", &resp.Diagnostics)
+	var state hubGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteHubGroup(state.Id.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete hub group", err.Error())
+	}
+}
+
+// ImportState imports a group by its pulp href.
+func (r *hubGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *hubGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHubGroupResource(t *testing.T) {
+	mock := newMockAAP(t)
+	p := newTestProvider(t, mock, nil)
+	group := p.resource("aap_hub_group")
+	config := map[string]any{"name": "publishers"}
+	state := group.apply(config)
+	id := state["group_id"].(int64)
+	testExpect(t, state, map[string]any{"id": fmt.Sprintf("/%sgroups/%d/", hubPulpAPIPath, id)})
+
+	group.read()
+	if changes := group.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["name"] = "curators"
+	group.apply(config)
+	testExpect(t, mock.object(hubPulpAPIPath+"groups", id), map[string]any{"name": "curators"})
+
+	imported := p.resource("aap_hub_group")
+	testExpect(t, imported.importState(state["id"].(string)), map[string]any{"group_id": id, "name": "curators"})
+
+	group.destroy()
+	if mock.object(hubPulpAPIPath+"groups", id) != nil {
+		t.Errorf("hub group %d still exists after destroy", id)
+	}
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &hubGroupRoleResource{}
+	_ resource.ResourceWithConfigure = &hubGroupRoleResource{}
+)
+
+// NewHubGroupRoleResource is a helper function to simplify the provider implementation.
+func NewHubGroupRoleResource() resource.Resource {
+	return &hubGroupRoleResource{}
+}
+
+// hubGroupRoleResource grants a role to an Automation Hub group, globally or on one object such as a repository.
+type hubGroupRoleResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *hubGroupRoleResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hub_group_role"
+}
+
+// Schema defines the schema for the resource.
+func (r *hubGroupRoleResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Grants a role to an Automation Hub group.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Pulp href of the role assignment.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"group_id": schema.StringAttribute{
+				Required:      true,
+				Description:   "Pulp href of the group, see aap_hub_group.",
+				PlanModifiers: replace,
+				Validators:    hrefValidators(),
+			},
+			"role": schema.StringAttribute{
+				Required:      true,
+				Description:   "Name of the role, e.g. galaxy.ansible_repository_owner.",
+				PlanModifiers: replace,
+			},
+			"content_object": schema.StringAttribute{
+				Optional:      true,
+				Description:   "Pulp href of the object the role applies to, e.g. a repository. The role is granted globally when omitted.",
+				PlanModifiers: replace,
+				Validators:    hrefValidators(),
+			},
+		},
+	}
+}
+
+// hubGroupRoleResourceModel maps the resource schema data.
+type hubGroupRoleResourceModel struct {
+	Id            types.String `tfsdk:"id"`
+	GroupId       types.String `tfsdk:"group_id"`
+	Role          types.String `tfsdk:"role"`
+	ContentObject types.String `tfsdk:"content_object"`
+}
+
+// Create grants the role.
+func (r *hubGroupRoleResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_group_role", "create", &resp.Diagnostics)
+	var plan hubGroupRoleResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := r.client.CreateHubGroupRole(plan.GroupId.ValueString(), HubGroupRole{
+		Role:          plan.Role.ValueString(),
+		ContentObject: plan.ContentObject.ValueStringPointer(),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to grant hub group role", err.Error())
+		return
+	}
+	plan.Id = types.StringValue(role.PulpHref)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read removes the resource from the state when the role is no longer granted.
+func (r *hubGroupRoleResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_group_role", "read", &resp.Diagnostics)
+	var state hubGroupRoleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	role, err := getObject[HubGroupRole](r.client, state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read hub group role", err.Error())
+		return
+	}
+	if role == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Role = types.StringValue(role.Role)
+	state.ContentObject = types.StringPointerValue(role.ContentObject)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update is never called as every attribute requires replacement.
+func (r *hubGroupRoleResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_group_role", "update", &resp.Diagnostics)
+	resp.Diagnostics.AddError("Unexpected update", "Hub group roles cannot be updated in place. Please report this issue to the provider developers.")
+}
+
+// Delete revokes the role.
+func (r *hubGroupRoleResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_group_role", "delete", &resp.Diagnostics)
+	var state hubGroupRoleResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteHubGroupRole(state.Id.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Unable to revoke hub group role", err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *hubGroupRoleResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
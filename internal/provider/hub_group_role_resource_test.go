@@ -0,0 +1,43 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestHubGroupRoleResource(t *testing.T) {
+	mock := newMockAAP(t)
+	group := mock.addObject(hubPulpAPIPath+"groups", map[string]any{"name": "publishers"})
+	groupHref := fmt.Sprintf("/%sgroups/%d/", hubPulpAPIPath, group)
+	repository := mock.addObject(hubPulpAPIPath+"repositories/ansible/ansible", map[string]any{"name": "community"})
+	repositoryHref := fmt.Sprintf("/%srepositories/ansible/ansible/%d/", hubPulpAPIPath, repository)
+	roles := fmt.Sprintf("%sgroups/%d/roles", hubPulpAPIPath, group)
+
+	p := newTestProvider(t, mock, nil)
+	role := p.resource("aap_hub_group_role")
+	config := map[string]any{"group_id": groupHref, "role": "galaxy.ansible_repository_owner", "content_object": repositoryHref}
+	state := role.apply(config)
+	href := state["id"].(string)
+	testExpect(t, mock.object(roles, mockHrefId(href)), map[string]any{
+		"pulp_href": href, "role": "galaxy.ansible_repository_owner", "content_object": repositoryHref,
+	})
+
+	role.read()
+	if changes := role.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	// granting the role globally replaces the assignment
+	delete(config, "content_object")
+	state = role.apply(config)
+	if mock.object(roles, mockHrefId(href)) != nil {
+		t.Errorf("role %s still granted after replacing it", href)
+	}
+	href = state["id"].(string)
+	testExpect(t, mock.object(roles, mockHrefId(href)), map[string]any{"content_object": nil})
+
+	role.destroy()
+	if mock.object(roles, mockHrefId(href)) != nil {
+		t.Errorf("role %s still granted after destroy", href)
+	}
+}
@@ -0,0 +1,196 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &hubNamespaceGroupResource{}
+	_ resource.ResourceWithConfigure = &hubNamespaceGroupResource{}
+)
+
+// NewHubNamespaceGroupResource is a helper function to simplify the provider implementation.
+func NewHubNamespaceGroupResource() resource.Resource {
+	return &hubNamespaceGroupResource{}
+}
+
+// hubNamespaceGroupResource grants roles on an Automation Hub collection namespace to a group.
+type hubNamespaceGroupResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *hubNamespaceGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hub_namespace_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *hubNamespaceGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.String{
+		stringplanmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Grants roles on an Automation Hub collection namespace to a group. Other groups of the namespace are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"namespace": schema.StringAttribute{
+				Required:      true,
+				Description:   "Name of the collection namespace.",
+				PlanModifiers: replace,
+			},
+			"group": schema.StringAttribute{
+				Required:      true,
+				Description:   "Name of the group.",
+				PlanModifiers: replace,
+			},
+			"object_roles": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Roles granted on the namespace, e.g. galaxy.collection_namespace_owner.",
+			},
+		},
+	}
+}
+
+// hubNamespaceGroupResourceModel maps the resource schema data.
+type hubNamespaceGroupResourceModel struct {
+	Namespace   types.String `tfsdk:"namespace"`
+	Group       types.String `tfsdk:"group"`
+	ObjectRoles []string     `tfsdk:"object_roles"`
+}
+
+// setGroupRoles adds, replaces or (with nil roles) removes the group in the namespace groups.
+func (r *hubNamespaceGroupResource) setGroupRoles(model hubNamespaceGroupResourceModel, roles []string) error {
+	namespace, err := r.client.GetHubNamespace(model.Namespace.ValueString())
+	if err != nil {
+		return err
+	}
+	if namespace == nil {
+		return fmt.Errorf("namespace %q does not exist", model.Namespace.ValueString())
+	}
+
+	var groups []HubNamespaceGroup
+	for _, group := range namespace.Groups {
+		if group.Name != model.Group.ValueString() {
+			groups = append(groups, group)
+		}
+	}
+	if roles != nil {
+		groups = append(groups, HubNamespaceGroup{Name: model.Group.ValueString(), ObjectRoles: roles})
+	}
+	if groups == nil {
+		groups = []HubNamespaceGroup{}
+	}
+
+	_, err = r.client.UpdateHubNamespaceGroups(namespace.Name, groups)
+	return err
+}
+
+// Create grants the roles.
+func (r *hubNamespaceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_namespace_group", "create", &resp.Diagnostics)
+	var plan hubNamespaceGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setGroupRoles(plan, plan.ObjectRoles); err != nil {
+		resp.Diagnostics.AddError("Unable to grant hub namespace roles", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the roles the group holds on the namespace.
+func (r *hubNamespaceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_namespace_group", "read", &resp.Diagnostics)
+	var state hubNamespaceGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	namespace, err := r.client.GetHubNamespace(state.Namespace.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read hub namespace", err.Error())
+		return
+	}
+
+	var roles []string
+	if namespace != nil {
+		for _, group := range namespace.Groups {
+			if group.Name == state.Group.ValueString() {
+				roles = group.ObjectRoles
+			}
+		}
+	}
+	if len(roles) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	sort.Strings(roles)
+	state.ObjectRoles = roles
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update replaces the roles of the group.
+func (r *hubNamespaceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_namespace_group", "update", &resp.Diagnostics)
+	var plan hubNamespaceGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setGroupRoles(plan, plan.ObjectRoles); err != nil {
+		resp.Diagnostics.AddError("Unable to update hub namespace roles", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the group from the namespace.
+func (r *hubNamespaceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_namespace_group", "delete", &resp.Diagnostics)
+	var state hubNamespaceGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setGroupRoles(state, nil); err != nil {
+		resp.Diagnostics.AddError("Unable to revoke hub namespace roles", err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *hubNamespaceGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHubNamespaceGroupResource(t *testing.T) {
+	mock := newMockAAP(t)
+	mock.addObject(hubPulpAPIPath+"groups", map[string]any{"name": "publishers"})
+	owners := mock.addObject(hubPulpAPIPath+"groups", map[string]any{"name": "owners"})
+	namespace := mock.addObject(hubGalaxyAPIPath+"namespaces", map[string]any{"name": "acme", "groups": []any{
+		map[string]any{"id": owners, "name": "owners", "object_roles": []any{"galaxy.collection_namespace_owner"}},
+	}})
+	namespaces := hubGalaxyAPIPath + "namespaces"
+
+	p := newTestProvider(t, mock, nil)
+	missing := p.resource("aap_hub_namespace_group")
+	message := missing.tryApply(map[string]any{"namespace": "acme", "group": "nobody", "object_roles": []any{"galaxy.collection_publisher"}})
+	if !strings.Contains(message, "Group name=nobody does not exist") {
+		t.Errorf("granting roles to a missing group gives %q", message)
+	}
+
+	group := p.resource("aap_hub_namespace_group")
+	config := map[string]any{"namespace": "acme", "group": "publishers", "object_roles": []any{"galaxy.collection_publisher"}}
+	group.apply(config)
+	groups := mock.object(namespaces, namespace)["groups"].([]any)
+	if len(groups) != 2 {
+		t.Fatalf("namespace has groups %v, expected owners and publishers", groups)
+	}
+	testExpect(t, groups[1].(map[string]any), map[string]any{"name": "publishers", "object_roles": []any{"galaxy.collection_publisher"}})
+
+	group.read()
+	if changes := group.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["object_roles"] = []any{"galaxy.collection_publisher", "galaxy.collection_namespace_owner"}
+	group.apply(config)
+	groups = mock.object(namespaces, namespace)["groups"].([]any)
+	testExpect(t, groups[1].(map[string]any), map[string]any{"object_roles": []any{"galaxy.collection_publisher", "galaxy.collection_namespace_owner"}})
+
+	group.destroy()
+	groups = mock.object(namespaces, namespace)["groups"].([]any)
+	if len(groups) != 1 || groups[0].(map[string]any)["name"] != "owners" {
+		t.Errorf("namespace has groups %v after destroy, expected only owners", groups)
+	}
+}
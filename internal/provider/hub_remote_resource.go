@@ -0,0 +1,292 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &hubRemoteResource{}
+	_ resource.ResourceWithConfigure   = &hubRemoteResource{}
+	_ resource.ResourceWithImportState = &hubRemoteResource{}
+)
+
+// NewHubRemoteResource is a helper function to simplify the provider implementation.
+func NewHubRemoteResource() resource.Resource {
+	return &hubRemoteResource{}
+}
+
+// hubRemoteResource manages an Automation Hub collection remote.
+type hubRemoteResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *hubRemoteResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hub_remote"
+}
+
+// Schema defines the schema for the resource.
+func (r *hubRemoteResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an Automation Hub collection remote, the upstream a repository syncs collections from, " +
+			"e.g. https://galaxy.ansible.com/api/ or https://console.redhat.com/api/automation-hub/content/published/.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Pulp href of the remote.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the remote.",
+				Validators:  nameValidators(),
+			},
+			"url": schema.StringAttribute{
+				Required:    true,
+				Description: "URL of the upstream Galaxy API.",
+				Validators:  urlValidators("http", "https"),
+			},
+			"requirements_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Content of a requirements.yml file limiting which collections are synced.",
+			},
+			"auth_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL of the SSO server issuing access tokens, e.g. for console.redhat.com.",
+				Validators:  urlValidators("http", "https"),
+			},
+			"token": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Token used to authenticate to the upstream.",
+			},
+			"username": schema.StringAttribute{
+				Optional:    true,
+				Description: "Username used to authenticate to the upstream.",
+			},
+			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password used to authenticate to the upstream.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "Proxy used to reach the upstream.",
+				Validators:  urlValidators("http", "https"),
+			},
+			"tls_validation": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether to verify the TLS certificate of the upstream.",
+			},
+			"signed_only": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether to only sync signed collections.",
+			},
+			"sync_dependencies": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(true),
+				Description: "Whether to also sync the dependencies of the requested collections.",
+			},
+		},
+	}
+}
+
+// hubRemoteResourceModel maps the resource schema data.
+type hubRemoteResourceModel struct {
+	Id               types.String `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	URL              types.String `tfsdk:"url"`
+	RequirementsFile types.String `tfsdk:"requirements_file"`
+	AuthURL          types.String `tfsdk:"auth_url"`
+	Token            types.String `tfsdk:"token"`
+	Username         types.String `tfsdk:"username"`
+	Password         types.String `tfsdk:"password"`
+	ProxyURL         types.String `tfsdk:"proxy_url"`
+	TLSValidation    types.Bool   `tfsdk:"tls_validation"`
+	SignedOnly       types.Bool   `tfsdk:"signed_only"`
+	SyncDependencies types.Bool   `tfsdk:"sync_dependencies"`
+}
+
+// hubRemoteAPIFields maps the fields of the hub remote API to the attributes they are set from.
+var hubRemoteAPIFields = map[string]string{
+	"name":              "name",
+	"url":               "url",
+	"requirements_file": "requirements_file",
+	"auth_url":          "auth_url",
+	"token":             "token",
+	"username":          "username",
+	"password":          "password",
+	"proxy_url":         "proxy_url",
+	"tls_validation":    "tls_validation",
+	"signed_only":       "signed_only",
+	"sync_dependencies": "sync_dependencies",
+}
+
+func (m *hubRemoteResourceModel) remote() HubRemote {
+	return HubRemote{
+		Name:             m.Name.ValueString(),
+		URL:              m.URL.ValueString(),
+		RequirementsFile: optionalStringPointer(m.RequirementsFile),
+		AuthURL:          optionalStringPointer(m.AuthURL),
+		Token:            m.Token.ValueStringPointer(),
+		Username:         m.Username.ValueStringPointer(),
+		Password:         m.Password.ValueStringPointer(),
+		ProxyURL:         optionalStringPointer(m.ProxyURL),
+		TLSValidation:    m.TLSValidation.ValueBool(),
+		SignedOnly:       m.SignedOnly.ValueBool(),
+		SyncDependencies: m.SyncDependencies.ValueBool(),
+	}
+}
+
+// clearedCredentials returns the API fields of the credentials set in prior but no longer in the model,
+// which pulp keeps unless they are sent as null.
+func (m *hubRemoteResourceModel) clearedCredentials(prior hubRemoteResourceModel) []string {
+	var cleared []string
+	for _, credential := range []struct {
+		field        string
+		value, prior types.String
+	}{
+		{"token", m.Token, prior.Token},
+		{"username", m.Username, prior.Username},
+		{"password", m.Password, prior.Password},
+	} {
+		if credential.value.IsNull() && !credential.prior.IsNull() {
+			cleared = append(cleared, credential.field)
+		}
+	}
+	return cleared
+}
+
+// setRemote updates the model from the API response. Credentials are never returned and are kept as configured.
+func (m *hubRemoteResourceModel) setRemote(remote *HubRemote) {
+	m.Id = types.StringValue(remote.PulpHref)
+	m.Name = types.StringValue(remote.Name)
+	m.URL = types.StringValue(remote.URL)
+	m.RequirementsFile = optionalString(remote.RequirementsFile, m.RequirementsFile)
+	m.AuthURL = optionalString(remote.AuthURL, m.AuthURL)
+	m.ProxyURL = optionalString(remote.ProxyURL, m.ProxyURL)
+	m.TLSValidation = types.BoolValue(remote.TLSValidation)
+	m.SignedOnly = types.BoolValue(remote.SignedOnly)
+	m.SyncDependencies = types.BoolValue(remote.SyncDependencies)
+}
+
+// Create creates the remote.
+func (r *hubRemoteResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_remote", "create", &resp.Diagnostics)
+	var plan hubRemoteResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote, err := r.client.CreateHubRemote(plan.remote())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create hub remote", err, hubRemoteAPIFields)...)
+		return
+	}
+	plan.setRemote(remote)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *hubRemoteResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_remote", "read", &resp.Diagnostics)
+	var state hubRemoteResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote, err := r.client.GetHubRemote(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read hub remote", err.Error())
+		return
+	}
+	if remote == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setRemote(remote)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the remote.
+func (r *hubRemoteResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_remote", "update", &resp.Diagnostics)
+	var plan, state hubRemoteResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	remote, err := r.client.UpdateHubRemote(ctx, plan.Id.ValueString(), plan.remote(), plan.clearedCredentials(state)...)
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update hub remote", err, hubRemoteAPIFields)...)
+		return
+	}
+	if remote == nil {
+		resp.Diagnostics.AddError("Unable to update hub remote", "The remote no longer exists.")
+		return
+	}
+	plan.setRemote(remote)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the remote.
+func (r *hubRemoteResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_remote", "delete", &resp.Diagnostics)
+	var state hubRemoteResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteHubRemote(ctx, state.Id.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete hub remote", err.Error())
+	}
+}
+
+// ImportState imports a remote by its pulp href.
+func (r *hubRemoteResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *hubRemoteResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHubRemoteResource(t *testing.T) {
+	mock := newMockAAP(t)
+	p := newTestProvider(t, mock, nil)
+	remote := p.resource("aap_hub_remote")
+	config := map[string]any{"name": "galaxy", "url": "https://galaxy.ansible.com/api/", "token": "secret"}
+	state := remote.apply(config)
+	testExpect(t, state, map[string]any{"url": "https://galaxy.ansible.com/api/", "token": "secret", "tls_validation": true, "sync_dependencies": true})
+	href := state["id"].(string)
+	if !strings.HasPrefix(href, "/"+hubPulpAPIPath+"remotes/ansible/collection/") {
+		t.Fatalf("remote id = %q, expected its pulp href", href)
+	}
+
+	remote.read()
+	if changes := remote.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["requirements_file"] = "collections:\n  - ansible.posix\n"
+	config["sync_dependencies"] = false
+	remote.apply(config)
+	testExpect(t, mock.object(hubPulpAPIPath+"remotes/ansible/collection", mockHrefId(href)), map[string]any{
+		"requirements_file": "collections:\n  - ansible.posix\n", "sync_dependencies": false,
+	})
+	if count := mock.requestCount("PATCH", strings.Trim(href, "/")); count != 1 {
+		t.Errorf("remote updated %d times, expected once", count)
+	}
+
+	// removing a credential from the configuration removes it from the upstream
+	delete(config, "token")
+	testExpect(t, remote.apply(config), map[string]any{"token": nil})
+	if token, ok := mock.objects[hubPulpAPIPath+"remotes/ansible/collection"][mockHrefId(href)]["token"]; !ok || token != nil {
+		t.Errorf("remote token = %#v after removing it, expected null", token)
+	}
+
+	imported := p.resource("aap_hub_remote")
+	testExpect(t, imported.importState(href), map[string]any{"name": "galaxy", "token": nil, "sync_dependencies": false})
+
+	remote.destroy()
+	if mock.object(hubPulpAPIPath+"remotes/ansible/collection", mockHrefId(href)) != nil {
+		t.Errorf("remote %s still exists after destroy", href)
+	}
+}
@@ -0,0 +1,382 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &hubRepositoryResource{}
+	_ resource.ResourceWithConfigure   = &hubRepositoryResource{}
+	_ resource.ResourceWithImportState = &hubRepositoryResource{}
+	_ resource.ResourceWithModifyPlan  = &hubRepositoryResource{}
+)
+
+// NewHubRepositoryResource is a helper function to simplify the provider implementation.
+func NewHubRepositoryResource() resource.Resource {
+	return &hubRepositoryResource{}
+}
+
+// hubRepositoryResource manages an Automation Hub collection repository and its distribution.
+type hubRepositoryResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *hubRepositoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_hub_repository"
+}
+
+// Schema defines the schema for the resource.
+func (r *hubRepositoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an Automation Hub collection repository, optionally served under a base path and synced from a remote.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Pulp href of the repository.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the repository.",
+				Validators:  nameValidators(),
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Description: "Description of the repository.",
+			},
+			"remote_id": schema.StringAttribute{
+				Optional:    true,
+				Description: "Pulp href of the remote the repository syncs from, see aap_hub_remote.",
+				Validators:  hrefValidators(),
+			},
+			"retain_repo_versions": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Number of repository versions to keep, all of them when not set.",
+			},
+			"private": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether the repository is hidden from users without explicit permissions.",
+			},
+			"base_path": schema.StringAttribute{
+				Optional:    true,
+				Description: "Base path the repository content is distributed under. No distribution is created when omitted.",
+			},
+			"distribution_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Pulp href of the distribution serving the repository.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"sync_trigger": schema.StringAttribute{
+				Optional: true,
+				Description: "When set, the repository is synced from its remote on creation and each time this value changes. " +
+					"Setting it from a rotating value, e.g. a time_rotating resource, schedules regular syncs.",
+			},
+			"sync_mirror": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether syncs remove content that is no longer present upstream.",
+			},
+			"latest_version_id": schema.StringAttribute{
+				Computed:    true,
+				Description: "Pulp href of the latest repository version.",
+			},
+		},
+	}
+}
+
+// hubRepositoryResourceModel maps the resource schema data.
+type hubRepositoryResourceModel struct {
+	Id                 types.String `tfsdk:"id"`
+	Name               types.String `tfsdk:"name"`
+	Description        types.String `tfsdk:"description"`
+	RemoteId           types.String `tfsdk:"remote_id"`
+	RetainRepoVersions types.Int64  `tfsdk:"retain_repo_versions"`
+	Private            types.Bool   `tfsdk:"private"`
+	BasePath           types.String `tfsdk:"base_path"`
+	DistributionId     types.String `tfsdk:"distribution_id"`
+	SyncTrigger        types.String `tfsdk:"sync_trigger"`
+	SyncMirror         types.Bool   `tfsdk:"sync_mirror"`
+	LatestVersionId    types.String `tfsdk:"latest_version_id"`
+}
+
+// hubRepositoryAPIFields maps the fields of the hub repository and distribution API to the attributes they are set from.
+var hubRepositoryAPIFields = map[string]string{
+	"name":                 "name",
+	"description":          "description",
+	"remote":               "remote_id",
+	"retain_repo_versions": "retain_repo_versions",
+	"private":              "private",
+	"base_path":            "base_path",
+}
+
+func (m *hubRepositoryResourceModel) repository() HubRepository {
+	return HubRepository{
+		Name:               m.Name.ValueString(),
+		Description:        optionalStringPointer(m.Description),
+		Remote:             optionalStringPointer(m.RemoteId),
+		RetainRepoVersions: m.RetainRepoVersions.ValueInt64Pointer(),
+		Private:            m.Private.ValueBool(),
+	}
+}
+
+func (m *hubRepositoryResourceModel) setRepository(repository *HubRepository) {
+	m.Id = types.StringValue(repository.PulpHref)
+	m.Name = types.StringValue(repository.Name)
+	m.Description = optionalString(repository.Description, m.Description)
+	m.RemoteId = optionalString(repository.Remote, m.RemoteId)
+	m.RetainRepoVersions = types.Int64PointerValue(repository.RetainRepoVersions)
+	m.Private = types.BoolValue(repository.Private)
+	m.LatestVersionId = types.StringValue(repository.LatestVersionHref)
+}
+
+// sync syncs the repository and refreshes its latest version.
+func (r *hubRepositoryResource) sync(ctx context.Context, model *hubRepositoryResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if model.RemoteId.IsNull() {
+		diags.AddAttributeError(path.Root("sync_trigger"), "Unable to sync hub repository", "The repository has no remote_id to sync from.")
+		return diags
+	}
+	if err := r.client.SyncHubRepository(ctx, model.Id.ValueString(), model.SyncMirror.ValueBool()); err != nil {
+		diags.AddError("Unable to sync hub repository", err.Error())
+		return diags
+	}
+
+	repository, err := r.client.GetHubRepository(model.Id.ValueString())
+	if err != nil || repository == nil {
+		diags.AddError("Unable to read hub repository", fmt.Sprintf("%v", err))
+		return diags
+	}
+	model.setRepository(repository)
+	return diags
+}
+
+// ModifyPlan plans the distribution id when the base path is set or removed, as the distribution is then created
+// or deleted.
+func (r *hubRepositoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state hubRepositoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.BasePath.IsNull() == state.DistributionId.IsNull() {
+		return
+	}
+
+	if plan.BasePath.IsNull() {
+		plan.DistributionId = types.StringNull()
+	} else {
+		plan.DistributionId = types.StringUnknown()
+	}
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// Create creates the repository, its distribution, and runs the first sync when requested.
+func (r *hubRepositoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_repository", "create", &resp.Diagnostics)
+	var plan hubRepositoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repository, err := r.client.CreateHubRepository(plan.repository())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create hub repository", err, hubRepositoryAPIFields)...)
+		return
+	}
+	plan.setRepository(repository)
+	plan.DistributionId = types.StringNull()
+
+	// Save the repository first so that a failing distribution or sync does not leak it.
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !plan.BasePath.IsNull() {
+		href, err := r.client.CreateHubDistribution(ctx, HubDistribution{
+			Name:       plan.Name.ValueString(),
+			BasePath:   plan.BasePath.ValueString(),
+			Repository: repository.PulpHref,
+		})
+		if err != nil {
+			resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create hub distribution", err, hubRepositoryAPIFields)...)
+			return
+		}
+		plan.DistributionId = types.StringValue(href)
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+	}
+
+	if !plan.SyncTrigger.IsNull() {
+		resp.Diagnostics.Append(r.sync(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *hubRepositoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_repository", "read", &resp.Diagnostics)
+	var state hubRepositoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repository, err := r.client.GetHubRepository(state.Id.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read hub repository", err.Error())
+		return
+	}
+	if repository == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setRepository(repository)
+
+	distribution, err := r.client.GetHubDistributionByRepository(repository.PulpHref)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read hub distribution", err.Error())
+		return
+	}
+	if distribution != nil {
+		state.DistributionId = types.StringValue(distribution.PulpHref)
+		state.BasePath = types.StringValue(distribution.BasePath)
+	} else {
+		state.DistributionId = types.StringNull()
+		state.BasePath = types.StringNull()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the repository and its distribution, syncing when the trigger changed.
+func (r *hubRepositoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_repository", "update", &resp.Diagnostics)
+	var plan, state hubRepositoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	repository, err := r.client.UpdateHubRepository(ctx, plan.Id.ValueString(), plan.repository())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update hub repository", err, hubRepositoryAPIFields)...)
+		return
+	}
+	if repository == nil {
+		resp.Diagnostics.AddError("Unable to update hub repository", "The repository no longer exists.")
+		return
+	}
+	plan.setRepository(repository)
+
+	switch {
+	case plan.BasePath.IsNull() && !state.DistributionId.IsNull():
+		if err := r.client.DeleteHubDistribution(ctx, state.DistributionId.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Unable to delete hub distribution", err.Error())
+			return
+		}
+		plan.DistributionId = types.StringNull()
+	case !plan.BasePath.IsNull() && state.DistributionId.IsNull():
+		href, err := r.client.CreateHubDistribution(ctx, HubDistribution{
+			Name:       plan.Name.ValueString(),
+			BasePath:   plan.BasePath.ValueString(),
+			Repository: repository.PulpHref,
+		})
+		if err != nil {
+			resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create hub distribution", err, hubRepositoryAPIFields)...)
+			return
+		}
+		plan.DistributionId = types.StringValue(href)
+	case !plan.BasePath.Equal(state.BasePath):
+		err := r.client.UpdateHubDistribution(ctx, state.DistributionId.ValueString(), HubDistribution{
+			Name:       plan.Name.ValueString(),
+			BasePath:   plan.BasePath.ValueString(),
+			Repository: repository.PulpHref,
+		})
+		if err != nil {
+			resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update hub distribution", err, hubRepositoryAPIFields)...)
+			return
+		}
+	}
+
+	if !plan.SyncTrigger.IsNull() && !plan.SyncTrigger.Equal(state.SyncTrigger) {
+		resp.Diagnostics.Append(r.sync(ctx, &plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the distribution and the repository.
+func (r *hubRepositoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_hub_repository", "delete", &resp.Diagnostics)
+	var state hubRepositoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !state.DistributionId.IsNull() {
+		if err := r.client.DeleteHubDistribution(ctx, state.DistributionId.ValueString()); err != nil {
+			resp.Diagnostics.AddError("Unable to delete hub distribution", err.Error())
+			return
+		}
+	}
+
+	if err := r.client.DeleteHubRepository(ctx, state.Id.ValueString()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete hub repository", err.Error())
+	}
+}
+
+// ImportState imports a repository by its pulp href.
+func (r *hubRepositoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *hubRepositoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHubRepositoryResource(t *testing.T) {
+	mock := newMockAAP(t)
+	remoteId := mock.addObject(hubPulpAPIPath+"remotes/ansible/collection", map[string]any{"name": "galaxy", "url": "https://galaxy.ansible.com/api/"})
+	remote := mock.object(hubPulpAPIPath+"remotes/ansible/collection", remoteId)["pulp_href"].(string)
+	distributions := hubPulpAPIPath + "distributions/ansible/ansible"
+
+	p := newTestProvider(t, mock, nil)
+	unsynced := p.resource("aap_hub_repository")
+	if message := unsynced.tryApply(map[string]any{"name": "local", "sync_trigger": "1"}); !strings.Contains(message, "no remote_id to sync from") {
+		t.Errorf("syncing a repository without remote gives %q", message)
+	}
+
+	repository := p.resource("aap_hub_repository")
+	config := map[string]any{"name": "community", "remote_id": remote, "base_path": "community", "sync_trigger": "1"}
+	state := repository.apply(config)
+	href := state["id"].(string)
+	testExpect(t, state, map[string]any{"remote_id": remote, "retain_repo_versions": nil, "latest_version_id": href + "versions/1/"})
+	distribution := mockHrefId(state["distribution_id"].(string))
+	testExpect(t, mock.object(distributions, distribution), map[string]any{"name": "community", "base_path": "community", "repository": href})
+	testExpect(t, mock.object(hubPulpAPIPath+"repositories/ansible/ansible", mockHrefId(href)), map[string]any{"retain_repo_versions": nil})
+
+	repository.read()
+	if changes := repository.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["base_path"] = "galaxy"
+	config["sync_trigger"] = "2"
+	state = repository.apply(config)
+	testExpect(t, state, map[string]any{"latest_version_id": href + "versions/2/"})
+	testExpect(t, mock.object(distributions, distribution), map[string]any{"base_path": "galaxy"})
+
+	delete(config, "base_path")
+	config["private"] = true
+	config["retain_repo_versions"] = int64(5)
+	state = repository.apply(config)
+	testExpect(t, state, map[string]any{"distribution_id": nil, "private": true, "retain_repo_versions": int64(5)})
+	testExpect(t, mock.object(hubPulpAPIPath+"repositories/ansible/ansible", mockHrefId(href)), map[string]any{"retain_repo_versions": float64(5)})
+	if mock.object(distributions, distribution) != nil {
+		t.Errorf("distribution %d still exists after removing the base path", distribution)
+	}
+
+	imported := p.resource("aap_hub_repository")
+	testExpect(t, imported.importState(href), map[string]any{"name": "community", "remote_id": remote, "base_path": nil, "private": true, "retain_repo_versions": int64(5)})
+
+	repository.destroy()
+	if mock.object(hubPulpAPIPath+"repositories/ansible/ansible", mockHrefId(href)) != nil {
+		t.Errorf("repository %s still exists after destroy", href)
+	}
+}
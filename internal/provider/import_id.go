@@ -0,0 +1,33 @@
+package provider
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// resolveImportID returns the id given to terraform import when it is numeric. Otherwise the id is
+// a name, which lookup resolves; lookup returns nil when no object has that name.
+func resolveImportID(raw string, kind string, lookup func(name string) (*int64, error)) (int64, error) {
+	if id, err := strconv.ParseInt(raw, 10, 64); err == nil {
+		return id, nil
+	}
+	id, err := lookup(raw)
+	if err != nil {
+		return 0, err
+	}
+	if id == nil {
+		return 0, fmt.Errorf("no %s named %q exists", kind, raw)
+	}
+	return *id, nil
+}
+
+// splitScopedName splits the name of an object scoped to an organization, written "Organization/Name".
+// Only the first slash separates the organization, so object names may contain slashes.
+func splitScopedName(raw string, kind string) (string, string, error) {
+	organization, name, ok := strings.Cut(raw, "/")
+	if !ok || organization == "" || name == "" {
+		return "", "", fmt.Errorf("expected a numeric %s id or Organization/Name, got: %q", kind, raw)
+	}
+	return organization, name, nil
+}
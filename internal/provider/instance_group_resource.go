@@ -0,0 +1,322 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &instanceGroupResource{}
+	_ resource.ResourceWithConfigure      = &instanceGroupResource{}
+	_ resource.ResourceWithImportState    = &instanceGroupResource{}
+	_ resource.ResourceWithValidateConfig = &instanceGroupResource{}
+)
+
+// NewInstanceGroupResource is a helper function to simplify the provider implementation.
+func NewInstanceGroupResource() resource.Resource {
+	return &instanceGroupResource{}
+}
+
+// instanceGroupResource manages an automation controller instance group.
+type instanceGroupResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *instanceGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *instanceGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an automation controller instance group and the policy assigning instances to it. " +
+			"AAP assigns an instance to the group when it is pinned in policy_instance_list, or to reach " +
+			"policy_instance_minimum instances or policy_instance_percentage percent of the instances, whichever is larger.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the instance group.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the instance group.",
+				Validators:  nameValidators(),
+			},
+			"policy_instance_percentage": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Description: "Minimum percentage of the instances automatically assigned to the group when new instances come online.",
+				Validators:  []validator.Int64{int64validator.Between(0, 100)},
+			},
+			"policy_instance_minimum": schema.Int64Attribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(0),
+				Description: "Minimum number of instances automatically assigned to the group when new instances come online.",
+				Validators:  []validator.Int64{int64validator.AtLeast(0)},
+			},
+			"policy_instance_list": schema.ListAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Computed:    true,
+				Default:     listdefault.StaticValue(types.ListValueMust(types.StringType, nil)),
+				Description: "Hostnames of the instances pinned to the group, which are always assigned to it.",
+				Validators: []validator.List{
+					listvalidator.UniqueValues(),
+					listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				},
+			},
+			"is_container_group": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether jobs of the group run in pods of a Kubernetes or OpenShift cluster. Changing it recreates the group.",
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"credential_id": schema.Int64Attribute{
+				Optional: true,
+				Description: "Id of the OpenShift or Kubernetes API Bearer Token credential the container group connects to the cluster with. " +
+					"Without it, the cluster the controller runs in is used.",
+				Validators: idValidators(),
+			},
+			"pod_spec_override": schema.StringAttribute{
+				Optional: true,
+				Description: "Pod specification of the container group as a YAML document, replacing the default one. " +
+					"Documents differing only in formatting, key order or comments are equal, so the layout AAP stores it with does not show as a change.",
+			},
+		},
+	}
+}
+
+// instanceGroupResourceModel maps the resource schema data.
+type instanceGroupResourceModel struct {
+	Id                       types.Int64  `tfsdk:"id"`
+	Name                     types.String `tfsdk:"name"`
+	PolicyInstancePercentage types.Int64  `tfsdk:"policy_instance_percentage"`
+	PolicyInstanceMinimum    types.Int64  `tfsdk:"policy_instance_minimum"`
+	PolicyInstanceList       []string     `tfsdk:"policy_instance_list"`
+	IsContainerGroup         types.Bool   `tfsdk:"is_container_group"`
+	CredentialId             types.Int64  `tfsdk:"credential_id"`
+	PodSpecOverride          types.String `tfsdk:"pod_spec_override"`
+}
+
+// instanceGroupAPIFields maps the fields of the instance group API to the attributes they are set from.
+var instanceGroupAPIFields = map[string]string{
+	"name":                       "name",
+	"policy_instance_percentage": "policy_instance_percentage",
+	"policy_instance_minimum":    "policy_instance_minimum",
+	"policy_instance_list":       "policy_instance_list",
+	"is_container_group":         "is_container_group",
+	"credential":                 "credential_id",
+	"pod_spec_override":          "pod_spec_override",
+}
+
+func (m *instanceGroupResourceModel) instanceGroup() AAPInstanceGroup {
+	pinned := m.PolicyInstanceList
+	if pinned == nil {
+		pinned = []string{}
+	}
+	return AAPInstanceGroup{
+		Name:                     m.Name.ValueString(),
+		PolicyInstancePercentage: m.PolicyInstancePercentage.ValueInt64(),
+		PolicyInstanceMinimum:    m.PolicyInstanceMinimum.ValueInt64(),
+		PolicyInstanceList:       pinned,
+		IsContainerGroup:         m.IsContainerGroup.ValueBool(),
+		Credential:               m.CredentialId.ValueInt64Pointer(),
+		PodSpecOverride:          m.PodSpecOverride.ValueString(),
+	}
+}
+
+func (m *instanceGroupResourceModel) setInstanceGroup(instanceGroup *AAPInstanceGroup) {
+	m.Id = types.Int64Value(instanceGroup.Id)
+	m.Name = types.StringValue(instanceGroup.Name)
+	m.PolicyInstancePercentage = types.Int64Value(instanceGroup.PolicyInstancePercentage)
+	m.PolicyInstanceMinimum = types.Int64Value(instanceGroup.PolicyInstanceMinimum)
+	// an empty list rather than null, matching the default
+	m.PolicyInstanceList = append([]string{}, instanceGroup.PolicyInstanceList...)
+	m.IsContainerGroup = types.BoolValue(instanceGroup.IsContainerGroup)
+	m.CredentialId = types.Int64PointerValue(instanceGroup.Credential)
+	// the document is kept as written unless AAP holds a different pod specification
+	if !yamlDocumentsEqual(m.PodSpecOverride.ValueString(), instanceGroup.PodSpecOverride) {
+		m.PodSpecOverride = optionalString(&instanceGroup.PodSpecOverride, m.PodSpecOverride)
+	}
+}
+
+// yamlDocumentsEqual reports whether two YAML documents hold the same value, ignoring formatting,
+// key order and comments. Documents that cannot be parsed are compared as text.
+func yamlDocumentsEqual(a string, b string) bool {
+	if a == b {
+		return true
+	}
+	var valueA, valueB interface{}
+	if yaml.Unmarshal([]byte(a), &valueA) != nil || yaml.Unmarshal([]byte(b), &valueB) != nil {
+		return false
+	}
+	return reflect.DeepEqual(valueA, valueB)
+}
+
+// ValidateConfig ensures the container group settings are only set on container groups
+// and that the pod specification is a YAML document.
+func (r *instanceGroupResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config instanceGroupResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if !config.IsContainerGroup.IsUnknown() && !config.IsContainerGroup.ValueBool() {
+		for attribute, isSet := range map[string]bool{
+			"credential_id":     !config.CredentialId.IsNull(),
+			"pod_spec_override": !config.PodSpecOverride.IsNull(),
+		} {
+			if isSet {
+				resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid instance group setting",
+					fmt.Sprintf("%s is only used by container groups, is_container_group must be true.", attribute))
+			}
+		}
+	}
+
+	if config.PodSpecOverride.IsNull() || config.PodSpecOverride.IsUnknown() {
+		return
+	}
+	var spec map[string]interface{}
+	if err := yaml.Unmarshal([]byte(config.PodSpecOverride.ValueString()), &spec); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("pod_spec_override"), "Invalid pod specification",
+			fmt.Sprintf("pod_spec_override must be a YAML mapping: %s", err.Error()))
+	}
+}
+
+// Create creates the instance group.
+func (r *instanceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_instance_group", "create", &resp.Diagnostics)
+	var plan instanceGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceGroup, err := r.client.CreateInstanceGroup(plan.instanceGroup())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create instance group", err, instanceGroupAPIFields)...)
+		return
+	}
+	plan.setInstanceGroup(instanceGroup)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *instanceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_instance_group", "read", &resp.Diagnostics)
+	var state instanceGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceGroup, err := r.client.GetInstanceGroup(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read instance group", err.Error())
+		return
+	}
+	if instanceGroup == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setInstanceGroup(instanceGroup)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update updates the instance group.
+func (r *instanceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_instance_group", "update", &resp.Diagnostics)
+	var plan instanceGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	instanceGroup, err := r.client.UpdateInstanceGroup(plan.Id.ValueInt64(), plan.instanceGroup())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update instance group", err, instanceGroupAPIFields)...)
+		return
+	}
+	plan.setInstanceGroup(instanceGroup)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the instance group.
+func (r *instanceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_instance_group", "delete", &resp.Diagnostics)
+	var state instanceGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteInstanceGroup(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete instance group", err.Error())
+	}
+}
+
+// ImportState imports an instance group by id or name.
+func (r *instanceGroupResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(req.ID, "instance group", func(name string) (*int64, error) {
+		found, err := r.client.GetInstanceGroupByName(name)
+		if err != nil || found == nil {
+			return nil, err
+		}
+		return &found.Id, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *instanceGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
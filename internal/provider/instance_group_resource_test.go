@@ -0,0 +1,49 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestInstanceGroupResource(t *testing.T) {
+	mock := newMockAAP(t)
+	token := mock.addObject("api/v2/credentials", map[string]any{"name": "cluster"})
+
+	p := newTestProvider(t, mock, nil)
+	group := p.resource("aap_instance_group")
+	config := map[string]any{
+		"name":                    "pods",
+		"policy_instance_minimum": 2,
+		"is_container_group":      true,
+		"credential_id":           token,
+		"pod_spec_override":       "apiVersion: v1\nkind: Pod\nmetadata:\n  namespace: aap\n",
+	}
+	state := group.apply(config)
+	testExpect(t, state, map[string]any{"policy_instance_percentage": int64(0), "policy_instance_list": []any{}})
+
+	id := state["id"].(int64)
+	testExpect(t, mock.object("api/v2/instance_groups", id), map[string]any{
+		"name": "pods", "policy_instance_minimum": float64(2), "is_container_group": true, "credential": float64(token),
+	})
+
+	// AAP storing the pod specification with another layout is not a change
+	mock.objects["api/v2/instance_groups"][id]["pod_spec_override"] = "kind: Pod\napiVersion: v1\nmetadata: {namespace: aap}\n"
+	group.read()
+	testExpect(t, group.State(), map[string]any{"pod_spec_override": config["pod_spec_override"]})
+	if changes := group.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["policy_instance_list"] = []any{"node1.example.com"}
+	group.apply(config)
+	testExpect(t, mock.object("api/v2/instance_groups", id), map[string]any{"policy_instance_list": []any{"node1.example.com"}})
+
+	imported := p.resource("aap_instance_group")
+	testExpect(t, imported.importState("pods"), map[string]any{
+		"id": id, "name": "pods", "policy_instance_minimum": int64(2), "credential_id": token, "policy_instance_list": []any{"node1.example.com"},
+	})
+
+	group.destroy()
+	if mock.object("api/v2/instance_groups", id) != nil {
+		t.Errorf("instance group %d still exists after destroy", id)
+	}
+}
@@ -0,0 +1,208 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &instanceGroupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &instanceGroupsDataSource{}
+)
+
+// NewInstanceGroupsDataSource is a helper function to simplify the provider implementation.
+func NewInstanceGroupsDataSource() datasource.DataSource {
+	return &instanceGroupsDataSource{}
+}
+
+// instanceGroupsDataSource lists the capacity of the controller instance groups.
+type instanceGroupsDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *instanceGroupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_instance_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *instanceGroupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the automation controller instance groups with their consumed capacity and running jobs, ordered by name, " +
+			"e.g. to scale the replicas of a container group from Terraform. " +
+			"The values are read at each refresh and change as jobs start and finish.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the instance group with this exact name.",
+				Validators:  nameValidators(),
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the instance groups whose name starts with this prefix.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"is_container_group": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list the container groups when true, or the groups of instances when false.",
+			},
+			"max_results": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of instance groups listed; all of them are listed when omitted.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ids": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Ids of the instance groups, in the order of instance_groups.",
+			},
+			"instance_groups": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Instance groups, ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the instance group.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the instance group.",
+						},
+						"is_container_group": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the jobs of the group run in pods of a Kubernetes or OpenShift cluster.",
+						},
+						"instances": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of instances in the group, 0 for container groups.",
+						},
+						"capacity": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Total capacity of the instances of the group, 0 for container groups.",
+						},
+						"consumed_capacity": schema.Float64Attribute{
+							Computed:    true,
+							Description: "Capacity consumed by the jobs running in the group.",
+						},
+						"percent_capacity_remaining": schema.Float64Attribute{
+							Computed:    true,
+							Description: "Percentage of the capacity of the group not consumed.",
+						},
+						"jobs_running": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of jobs running or waiting in the group.",
+						},
+						"jobs_total": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Number of jobs that ran in the group.",
+						},
+						"max_concurrent_jobs": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Maximum number of jobs running at once in the group, 0 when unlimited.",
+						},
+						"max_forks": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Maximum number of forks of the jobs running at once in the group, 0 when unlimited.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// instanceGroupsDataSourceModel maps the data source schema data.
+type instanceGroupsDataSourceModel struct {
+	Name             types.String                 `tfsdk:"name"`
+	NamePrefix       types.String                 `tfsdk:"name_prefix"`
+	IsContainerGroup types.Bool                   `tfsdk:"is_container_group"`
+	MaxResults       types.Int64                  `tfsdk:"max_results"`
+	Ids              []int64                      `tfsdk:"ids"`
+	InstanceGroups   []instanceGroupCapacityModel `tfsdk:"instance_groups"`
+}
+
+// instanceGroupCapacityModel maps the capacity of an instance group.
+type instanceGroupCapacityModel struct {
+	Id                       types.Int64   `tfsdk:"id"`
+	Name                     types.String  `tfsdk:"name"`
+	IsContainerGroup         types.Bool    `tfsdk:"is_container_group"`
+	Instances                types.Int64   `tfsdk:"instances"`
+	Capacity                 types.Int64   `tfsdk:"capacity"`
+	ConsumedCapacity         types.Float64 `tfsdk:"consumed_capacity"`
+	PercentCapacityRemaining types.Float64 `tfsdk:"percent_capacity_remaining"`
+	JobsRunning              types.Int64   `tfsdk:"jobs_running"`
+	JobsTotal                types.Int64   `tfsdk:"jobs_total"`
+	MaxConcurrentJobs        types.Int64   `tfsdk:"max_concurrent_jobs"`
+	MaxForks                 types.Int64   `tfsdk:"max_forks"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *instanceGroupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_instance_groups", "read", &resp.Diagnostics)
+	var state instanceGroupsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := listQuery(types.Int64Null(), map[string]attr.Value{
+		"name":               state.Name,
+		"name__startswith":   state.NamePrefix,
+		"is_container_group": state.IsContainerGroup,
+	})
+	groups, err := d.client.GetInstanceGroupsCapacity(query, int(state.MaxResults.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read instance groups", err.Error())
+		return
+	}
+
+	state.Ids = make([]int64, len(groups))
+	state.InstanceGroups = make([]instanceGroupCapacityModel, len(groups))
+	for i, group := range groups {
+		state.Ids[i] = group.Id
+		state.InstanceGroups[i] = instanceGroupCapacityModel{
+			Id:                       types.Int64Value(group.Id),
+			Name:                     types.StringValue(group.Name),
+			IsContainerGroup:         types.BoolValue(group.IsContainerGroup),
+			Instances:                types.Int64Value(group.Instances),
+			Capacity:                 types.Int64Value(group.Capacity),
+			ConsumedCapacity:         types.Float64Value(group.ConsumedCapacity),
+			PercentCapacityRemaining: types.Float64Value(group.PercentCapacityRemaining),
+			JobsRunning:              types.Int64Value(group.JobsRunning),
+			JobsTotal:                types.Int64Value(group.JobsTotal),
+			MaxConcurrentJobs:        types.Int64Value(group.MaxConcurrentJobs),
+			MaxForks:                 types.Int64Value(group.MaxForks),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *instanceGroupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestInstanceGroupsDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	controlplane := mock.addObject("api/v2/instance_groups", map[string]any{
+		"name": "controlplane", "is_container_group": false, "instances": 3, "capacity": 171, "consumed_capacity": 42.5,
+		"percent_capacity_remaining": 75.15, "jobs_running": 2, "jobs_total": 120, "max_concurrent_jobs": 0, "max_forks": 0,
+	})
+	defaultGroup := mock.addObject("api/v2/instance_groups", map[string]any{
+		"name": "default", "is_container_group": false, "instances": 2, "capacity": 114, "consumed_capacity": 0,
+		"percent_capacity_remaining": 100, "jobs_running": 0, "jobs_total": 15, "max_concurrent_jobs": 10, "max_forks": 50,
+	})
+	pods := mock.addObject("api/v2/instance_groups", map[string]any{
+		"name": "pods", "is_container_group": true, "instances": 0, "capacity": 0, "consumed_capacity": 0,
+		"percent_capacity_remaining": 0, "jobs_running": 1, "jobs_total": 4, "max_concurrent_jobs": 5, "max_forks": 0,
+	})
+
+	p := newTestProvider(t, mock, nil)
+	state := p.readDataSource("aap_instance_groups", nil)
+	testExpect(t, state, map[string]any{"ids": []any{controlplane, defaultGroup, pods}})
+	if groups, _ := state["instance_groups"].([]any); len(groups) != 3 {
+		t.Fatalf("instance_groups = %v, expected every instance group", state["instance_groups"])
+	} else {
+		testExpect(t, groups[0].(map[string]any), map[string]any{
+			"id": controlplane, "name": "controlplane", "is_container_group": false, "instances": int64(3), "capacity": int64(171),
+			"consumed_capacity": 42.5, "percent_capacity_remaining": 75.15, "jobs_running": int64(2), "jobs_total": int64(120),
+			"max_concurrent_jobs": int64(0), "max_forks": int64(0),
+		})
+		testExpect(t, groups[2].(map[string]any), map[string]any{"is_container_group": true, "capacity": int64(0), "max_concurrent_jobs": int64(5)})
+	}
+
+	testExpect(t, p.readDataSource("aap_instance_groups", map[string]any{"name": "default"}), map[string]any{"ids": []any{defaultGroup}})
+	testExpect(t, p.readDataSource("aap_instance_groups", map[string]any{"name_prefix": "co"}), map[string]any{"ids": []any{controlplane}})
+	testExpect(t, p.readDataSource("aap_instance_groups", map[string]any{"is_container_group": true}), map[string]any{"ids": []any{pods}})
+	testExpect(t, p.readDataSource("aap_instance_groups", map[string]any{"is_container_group": false}), map[string]any{"ids": []any{controlplane, defaultGroup}})
+	testExpect(t, p.readDataSource("aap_instance_groups", map[string]any{"max_results": 2}), map[string]any{"ids": []any{controlplane, defaultGroup}})
+}
@@ -2,11 +2,14 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"slices"
+	"strings"
 
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
@@ -36,7 +39,43 @@ func (d *inventoryDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
-				Required: true,
+				Required:    true,
+				Description: "Id of the Terraform state stored in AAP the hosts and groups are read from.",
+				Validators:  idValidators(),
+			},
+			"inventory_id": schema.Int64Attribute{
+				Optional: true,
+				Description: "Id of an AAP inventory to report the counts and audit fields of, e.g. the inventory the state is " +
+					"synchronized to. They are null when it is not set.",
+				Validators: idValidators(),
+			},
+			"total_hosts": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of hosts in the inventory set by inventory_id, as counted by AAP.",
+			},
+			"total_groups": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of groups in the inventory set by inventory_id, as counted by AAP.",
+			},
+			"hosts_with_active_failures": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of hosts of the inventory set by inventory_id whose last job failed.",
+			},
+			"has_inventory_sources": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether inventory sources also add hosts to the inventory set by inventory_id.",
+			},
+			"created": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the inventory set by inventory_id was created in AAP.",
+			},
+			"modified": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the inventory set by inventory_id was last modified in AAP.",
+			},
+			"created_by": schema.StringAttribute{
+				Computed:    true,
+				Description: "Username of the user who created the inventory set by inventory_id, empty when AAP does not report one.",
 			},
 			"groups": schema.MapNestedAttribute{
 				NestedObject: schema.NestedAttributeObject{
@@ -49,6 +88,10 @@ func (d *inventoryDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 							ElementType: types.StringType,
 							Computed:    true,
 						},
+						"groupvars": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+						},
 					},
 				},
 				Computed: true,
@@ -60,6 +103,12 @@ func (d *inventoryDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 							ElementType: types.StringType,
 							Computed:    true,
 						},
+						"effective_variables": schema.MapAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Variables of the host merged with those of its groups, the way Ansible resolves them: " +
+								"child groups override their parents, groups of the same depth are applied by name and host variables win.",
+						},
 					},
 				},
 				Computed: true,
@@ -73,12 +122,49 @@ const allgroupsName string = "all"
 
 // Read refreshes the Terraform state with the latest data.
 func (d *inventoryDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_inventory", "read", &resp.Diagnostics)
 	var state inventoryDataSourceModel
 
 	// Read Terraform configuration data into the model
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.TotalHosts = types.Int64Null()
+	state.TotalGroups = types.Int64Null()
+	state.HostsWithActiveFailures = types.Int64Null()
+	state.HasInventorySources = types.BoolNull()
+	state.Created = types.StringNull()
+	state.Modified = types.StringNull()
+	state.CreatedBy = types.StringNull()
+	if !state.InventoryId.IsNull() {
+		inventory, err := d.client.GetInventory(state.InventoryId.ValueInt64())
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Unable to Read AAP inventory",
+				err.Error(),
+			)
+			return
+		}
+		if inventory == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("inventory_id"),
+				"Unable to Read AAP inventory",
+				fmt.Sprintf("Inventory %s does not exist.", state.InventoryId.String()),
+			)
+			return
+		}
+		state.TotalHosts = types.Int64Value(inventory.TotalHosts)
+		state.TotalGroups = types.Int64Value(inventory.TotalGroups)
+		state.HostsWithActiveFailures = types.Int64Value(inventory.HostsWithActiveFailures)
+		state.HasInventorySources = types.BoolValue(inventory.HasInventorySources)
+		state.Created = types.StringValue(inventory.Created)
+		state.Modified = types.StringValue(inventory.Modified)
+		state.CreatedBy = types.StringValue(inventory.SummaryFields.createdBy())
+	}
 
-	hosts, err := d.client.GetHosts(state.Id.String())
+	hosts, err := d.client.GetHosts(state.Id.ValueInt64())
 	if err != nil {
 		resp.Diagnostics.AddError(
 			"Unable to Read Ansible hosts",
@@ -92,6 +178,8 @@ func (d *inventoryDataSource) Read(ctx context.Context, req datasource.ReadReque
 	state.Hosts = make(map[string]hostDataSourceModel)
 
 	all_groups := []string{}
+	hostvars := make(map[string]map[string]interface{})
+	groupvars := make(map[string]map[string]interface{})
 
 	for _, host := range hosts.Hosts {
 		// add host to group
@@ -117,8 +205,27 @@ func (d *inventoryDataSource) Read(ctx context.Context, req datasource.ReadReque
 			HostVars: make(map[string]string),
 		}
 		state.Hosts[host.Name] = empty_host
+		hostvars[host.Name] = host.Variables
 		for key, value := range host.Variables {
-			state.addHostVariable(host.Name, key, value)
+			state.addHostVariable(host.Name, key, variableString(value))
+		}
+	}
+
+	for _, group := range hosts.Groups {
+		// add child groups and group variables
+		vars := make(map[string]string)
+		for key, value := range group.Variables {
+			vars[key] = variableString(value)
+		}
+		state.addGroup(group.Name, group.Children, vars)
+		groupvars[group.Name] = group.Variables
+		if !slices.Contains(all_groups, group.Name) {
+			all_groups = append(all_groups, group.Name)
+		}
+		for _, child := range group.Children {
+			if !slices.Contains(all_groups, child) {
+				all_groups = append(all_groups, child)
+			}
 		}
 	}
 
@@ -127,6 +234,9 @@ func (d *inventoryDataSource) Read(ctx context.Context, req datasource.ReadReque
 		Children: all_groups,
 	}
 
+	state.setEffectiveVariables(hostvars, groupvars)
+	state.sortMembers()
+
 	// Set state
 	diags := resp.State.Set(ctx, &state)
 	resp.Diagnostics.Append(diags...)
@@ -151,23 +261,33 @@ func (d *inventoryDataSource) Configure(_ context.Context, req datasource.Config
 		return
 	}
 
-	d.client = client
+	d.client = client.withStats()
 }
 
 // inventoryDataSourceModel maps the data source schema data.
 type inventoryDataSourceModel struct {
-	Id     types.Int64                     `tfsdk:"id"`
-	Groups map[string]groupDataSourceModel `tfsdk:"groups"`
-	Hosts  map[string]hostDataSourceModel  `tfsdk:"hosts"`
+	Id                      types.Int64                     `tfsdk:"id"`
+	InventoryId             types.Int64                     `tfsdk:"inventory_id"`
+	TotalHosts              types.Int64                     `tfsdk:"total_hosts"`
+	TotalGroups             types.Int64                     `tfsdk:"total_groups"`
+	HostsWithActiveFailures types.Int64                     `tfsdk:"hosts_with_active_failures"`
+	HasInventorySources     types.Bool                      `tfsdk:"has_inventory_sources"`
+	Created                 types.String                    `tfsdk:"created"`
+	Modified                types.String                    `tfsdk:"modified"`
+	CreatedBy               types.String                    `tfsdk:"created_by"`
+	Groups                  map[string]groupDataSourceModel `tfsdk:"groups"`
+	Hosts                   map[string]hostDataSourceModel  `tfsdk:"hosts"`
 }
 
 type groupDataSourceModel struct {
-	Hosts    []string `tfsdk:"hosts"`
-	Children []string `tfsdk:"children"`
+	Hosts     []string          `tfsdk:"hosts"`
+	Children  []string          `tfsdk:"children"`
+	GroupVars map[string]string `tfsdk:"groupvars"`
 }
 
 type hostDataSourceModel struct {
-	HostVars map[string]string `tfsdk:"hostvars"`
+	HostVars           map[string]string `tfsdk:"hostvars"`
+	EffectiveVariables map[string]string `tfsdk:"effective_variables"`
 }
 
 // add host to group
@@ -184,6 +304,35 @@ func (d *inventoryDataSourceModel) addHost(groupName string, hostName string) {
 	}
 }
 
+// add group children and variables
+func (d *inventoryDataSourceModel) addGroup(groupName string, children []string, variables map[string]string) {
+	group, ok := d.Groups[groupName]
+	if !ok {
+		group = groupDataSourceModel{}
+	}
+	for _, child := range children {
+		if !slices.Contains(group.Children, child) {
+			group.Children = append(group.Children, child)
+		}
+		// make sure child groups are listed even without hosts
+		if _, ok := d.Groups[child]; !ok {
+			d.Groups[child] = groupDataSourceModel{}
+		}
+	}
+	group.GroupVars = variables
+	d.Groups[groupName] = group
+}
+
+// sortMembers sorts the hosts and children of every group, so that the lists do not change
+// when the hosts and groups are listed in a different order in the state.
+func (d *inventoryDataSourceModel) sortMembers() {
+	for name, group := range d.Groups {
+		slices.Sort(group.Hosts)
+		slices.Sort(group.Children)
+		d.Groups[name] = group
+	}
+}
+
 // add host variables
 func (d *inventoryDataSourceModel) addHostVariable(hostName string, varName string, varValue string) {
 	_, ok := d.Hosts[hostName]
@@ -194,3 +343,84 @@ func (d *inventoryDataSourceModel) addHostVariable(hostName string, varName stri
 	}
 	d.Hosts[hostName].HostVars[varName] = varValue
 }
+
+// setEffectiveVariables merges the variables of every host with those of the groups it belongs to,
+// directly or through child groups. Groups are applied from the shallowest to the deepest, by name
+// within the same depth, and top level variables of later groups replace those of earlier ones.
+func (d *inventoryDataSourceModel) setEffectiveVariables(hostvars map[string]map[string]interface{}, groupvars map[string]map[string]interface{}) {
+	parents := make(map[string][]string)
+	for name, group := range d.Groups {
+		for _, child := range group.Children {
+			parents[child] = append(parents[child], name)
+		}
+	}
+
+	// the depth of a group is its longest path from all, a cycle stops the walk
+	depths := make(map[string]int)
+	var depth func(name string, seen []string) int
+	depth = func(name string, seen []string) int {
+		if value, ok := depths[name]; ok {
+			return value
+		}
+		value := 0
+		for _, parent := range parents[name] {
+			if slices.Contains(seen, parent) {
+				continue
+			}
+			value = max(value, depth(parent, append(seen, name))+1)
+		}
+		depths[name] = value
+		return value
+	}
+
+	for hostName, host := range d.Hosts {
+		var groups []string
+		var visit func(name string)
+		visit = func(name string) {
+			if slices.Contains(groups, name) {
+				return
+			}
+			groups = append(groups, name)
+			for _, parent := range parents[name] {
+				visit(parent)
+			}
+		}
+		visit(allgroupsName)
+		for name, group := range d.Groups {
+			if slices.Contains(group.Hosts, hostName) {
+				visit(name)
+			}
+		}
+		slices.SortFunc(groups, func(a, b string) int {
+			if depthA, depthB := depth(a, nil), depth(b, nil); depthA != depthB {
+				return depthA - depthB
+			}
+			return strings.Compare(a, b)
+		})
+
+		effective := make(map[string]string)
+		for _, name := range groups {
+			for key, value := range groupvars[name] {
+				effective[key] = variableString(value)
+			}
+		}
+		for key, value := range hostvars[hostName] {
+			effective[key] = variableString(value)
+		}
+		host.EffectiveVariables = effective
+		d.Hosts[hostName] = host
+	}
+}
+
+// variableString returns string variables unchanged and the JSON encoding of any other value,
+// since the data source exposes variables as a map of strings.
+func variableString(value interface{}) string {
+	if str, ok := value.(string); ok {
+		return str
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
@@ -0,0 +1,95 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestAccInventoryDataSource(t *testing.T) {
+	providerConfig, _, mock := testAccAAP(t)
+	if mock == nil {
+		t.Skip("the Terraform states stored in a real controller are not known in advance")
+	}
+
+	stateId := mock.addState(testAccStateDocument(t,
+		map[string]any{"name": "web1", "groups": []string{"web"}, "variables": map[string]any{"http_port": 8080}},
+		map[string]any{"name": "lonely"},
+		map[string]any{"name": "servers", "children": []string{"web"}, "variables": map[string]any{"region": "eu"}},
+	))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + fmt.Sprintf(`
+data "aap_inventory" "test" {
+  id = %d
+}
+`, stateId),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.aap_inventory.test", "hosts.%", "2"),
+					resource.TestCheckResourceAttr("data.aap_inventory.test", "hosts.web1.hostvars.http_port", "8080"),
+					resource.TestCheckResourceAttr("data.aap_inventory.test", "groups.web.hosts.0", "web1"),
+					resource.TestCheckResourceAttr("data.aap_inventory.test", "groups.ungrouped.hosts.0", "lonely"),
+					resource.TestCheckResourceAttr("data.aap_inventory.test", "groups.servers.children.0", "web"),
+					resource.TestCheckResourceAttr("data.aap_inventory.test", "groups.servers.groupvars.region", "eu"),
+					resource.TestCheckResourceAttr("data.aap_inventory.test", "hosts.web1.effective_variables.region", "eu"),
+					resource.TestCheckResourceAttr("data.aap_inventory.test", "hosts.web1.effective_variables.http_port", "8080"),
+					resource.TestCheckResourceAttr("data.aap_inventory.test", "hosts.lonely.effective_variables.%", "0"),
+					resource.TestCheckNoResourceAttr("data.aap_inventory.test", "total_hosts"),
+				),
+			},
+		},
+	})
+}
+
+func TestInventoryDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	stateId := mock.addState(testAccStateDocument(t,
+		map[string]any{"name": "web1", "groups": []string{"web"}},
+		map[string]any{"name": "web2", "groups": []string{"web"}},
+	))
+	mock.nextId++
+	inventoryId := mock.nextId
+	created := mockTimestamp()
+	mock.inventories[inventoryId] = &AAPInventory{
+		Id: inventoryId, Name: "Servers", Created: created, Modified: created,
+		SummaryFields: &aapAuditSummaryFields{CreatedBy: &AAPUserSummary{Id: 1, Username: mockUsername}},
+	}
+	mock.nextId++
+	mock.hosts[mock.nextId] = &AAPHost{Id: mock.nextId, Name: "web1", Inventory: inventoryId}
+
+	p := newTestProvider(t, mock, nil)
+	// the state id is not an inventory id, so without inventory_id the summary is left out
+	state := p.readDataSource("aap_inventory", map[string]any{"id": stateId})
+	if hosts, _ := state["hosts"].(map[string]any); len(hosts) != 2 {
+		t.Errorf("hosts = %v, expected the hosts of the state", state["hosts"])
+	}
+	for _, name := range []string{"total_hosts", "total_groups", "hosts_with_active_failures", "has_inventory_sources", "created", "modified", "created_by"} {
+		if state[name] != nil {
+			t.Errorf("%s = %v without inventory_id", name, state[name])
+		}
+	}
+
+	state = p.readDataSource("aap_inventory", map[string]any{"id": stateId, "inventory_id": inventoryId})
+	testExpect(t, state, map[string]any{
+		"total_hosts":           int64(1),
+		"total_groups":          int64(0),
+		"has_inventory_sources": false,
+		"created":               created,
+		"created_by":            mockUsername,
+	})
+	if hosts, _ := state["hosts"].(map[string]any); len(hosts) != 2 {
+		t.Errorf("hosts = %v with inventory_id", state["hosts"])
+	}
+	if _, err := time.Parse(time.RFC3339, state["modified"].(string)); err != nil {
+		t.Errorf("modified: %v", err)
+	}
+
+	if _, errors := p.tryReadDataSource("aap_inventory", map[string]any{"id": stateId, "inventory_id": inventoryId + 100}); errors == "" {
+		t.Error("reading an unknown inventory_id did not fail")
+	}
+}
@@ -0,0 +1,173 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &inventorySourcesDataSource{}
+	_ datasource.DataSourceWithConfigure = &inventorySourcesDataSource{}
+)
+
+// NewInventorySourcesDataSource is a helper function to simplify the provider implementation.
+func NewInventorySourcesDataSource() datasource.DataSource {
+	return &inventorySourcesDataSource{}
+}
+
+// inventorySourcesDataSource lists the sources of an inventory with the outcome of their last update.
+type inventorySourcesDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *inventorySourcesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_inventory_sources"
+}
+
+// Schema defines the schema for the data source.
+func (d *inventorySourcesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the inventory sources of an inventory with the status of their last update, " +
+			"e.g. to check in a precondition that they synced successfully before launching jobs.",
+		Attributes: map[string]schema.Attribute{
+			"inventory_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the inventory.",
+				Validators:  idValidators(),
+			},
+			"all_successful": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether the last update of every source succeeded; true when the inventory has no sources.",
+			},
+			"sources": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Sources of the inventory, ordered as returned by AAP.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the inventory source.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the inventory source.",
+						},
+						"source": schema.StringAttribute{
+							Computed:    true,
+							Description: "Kind of source, e.g. scm, ec2 or constructed.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Status of the last update, e.g. successful, failed, running or never updated.",
+						},
+						"last_updated": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the source was last updated, null if it never was.",
+						},
+						"last_update_failed": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the last update failed.",
+						},
+						"last_job_run": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the last update job of the source finished, null if none ran.",
+						},
+						"last_job_failed": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the last update job of the source failed.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// inventorySourcesDataSourceModel maps the data source schema data.
+type inventorySourcesDataSourceModel struct {
+	InventoryId   types.Int64            `tfsdk:"inventory_id"`
+	AllSuccessful types.Bool             `tfsdk:"all_successful"`
+	Sources       []inventorySourceModel `tfsdk:"sources"`
+}
+
+// inventorySourceModel maps an inventory source.
+type inventorySourceModel struct {
+	Id               types.Int64  `tfsdk:"id"`
+	Name             types.String `tfsdk:"name"`
+	Source           types.String `tfsdk:"source"`
+	Status           types.String `tfsdk:"status"`
+	LastUpdated      types.String `tfsdk:"last_updated"`
+	LastUpdateFailed types.Bool   `tfsdk:"last_update_failed"`
+	LastJobRun       types.String `tfsdk:"last_job_run"`
+	LastJobFailed    types.Bool   `tfsdk:"last_job_failed"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *inventorySourcesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_inventory_sources", "read", &resp.Diagnostics)
+	var state inventorySourcesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inventory, err := d.client.GetInventory(state.InventoryId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read AAP inventory", err.Error())
+		return
+	}
+	if inventory == nil {
+		resp.Diagnostics.AddError("Unable to read AAP inventory", fmt.Sprintf("Inventory %s does not exist.", state.InventoryId.String()))
+		return
+	}
+
+	sources, err := d.client.GetInventorySources(state.InventoryId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read inventory sources", err.Error())
+		return
+	}
+
+	allSuccessful := true
+	state.Sources = make([]inventorySourceModel, len(sources))
+	for i, source := range sources {
+		state.Sources[i] = inventorySourceModel{
+			Id:               types.Int64Value(source.Id),
+			Name:             types.StringValue(source.Name),
+			Source:           types.StringValue(source.Source),
+			Status:           types.StringValue(source.Status),
+			LastUpdated:      types.StringPointerValue(source.LastUpdated),
+			LastUpdateFailed: types.BoolValue(source.LastUpdateFailed),
+			LastJobRun:       types.StringPointerValue(source.LastJobRun),
+			LastJobFailed:    types.BoolValue(source.LastJobFailed),
+		}
+		allSuccessful = allSuccessful && source.Status == "successful"
+	}
+	state.AllSuccessful = types.BoolValue(allSuccessful)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *inventorySourcesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
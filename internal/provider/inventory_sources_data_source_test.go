@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestInventorySourcesDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	mock.nextId++
+	inventoryId := mock.nextId
+	mock.inventories[inventoryId] = &AAPInventory{Id: inventoryId, Name: "Cloud"}
+	mock.nextId++
+	emptyId := mock.nextId
+	mock.inventories[emptyId] = &AAPInventory{Id: emptyId, Name: "Static"}
+	mock.nextId++
+	otherId := mock.nextId
+	mock.inventories[otherId] = &AAPInventory{Id: otherId, Name: "Other"}
+
+	ec2 := mock.addObject("api/v2/inventory_sources", map[string]any{
+		"name": "ec2", "inventory": inventoryId, "source": "ec2", "status": "successful",
+		"last_updated": "2024-05-02T08:00:00.000000Z", "last_update_failed": false,
+		"last_job_run": "2024-05-02T08:00:00.000000Z", "last_job_failed": false,
+	})
+	mock.addObject("api/v2/inventory_sources", map[string]any{"name": "other", "inventory": otherId, "source": "scm", "status": "failed"})
+
+	p := newTestProvider(t, mock, nil)
+	state := p.readDataSource("aap_inventory_sources", map[string]any{"inventory_id": inventoryId})
+	testExpect(t, state, map[string]any{"all_successful": true})
+	if sources, _ := state["sources"].([]any); len(sources) != 1 {
+		t.Fatalf("sources = %v, expected the sources of the inventory", state["sources"])
+	} else {
+		testExpect(t, sources[0].(map[string]any), map[string]any{
+			"id": ec2, "name": "ec2", "source": "ec2", "status": "successful",
+			"last_updated": "2024-05-02T08:00:00.000000Z", "last_update_failed": false,
+			"last_job_run": "2024-05-02T08:00:00.000000Z", "last_job_failed": false,
+		})
+	}
+
+	// a source which never synced is not successful
+	scm := mock.addObject("api/v2/inventory_sources", map[string]any{
+		"name": "git", "inventory": inventoryId, "source": "scm", "status": "never updated", "last_updated": nil,
+		"last_job_run": nil, "last_job_failed": false,
+	})
+	state = p.readDataSource("aap_inventory_sources", map[string]any{"inventory_id": inventoryId})
+	testExpect(t, state, map[string]any{"all_successful": false})
+	if sources, _ := state["sources"].([]any); len(sources) != 2 {
+		t.Fatalf("sources = %v, expected the sources of the inventory", state["sources"])
+	} else {
+		testExpect(t, sources[1].(map[string]any), map[string]any{
+			"id": scm, "status": "never updated", "last_updated": nil, "last_job_run": nil, "last_job_failed": false,
+		})
+	}
+
+	testExpect(t, p.readDataSource("aap_inventory_sources", map[string]any{"inventory_id": emptyId}), map[string]any{
+		"all_successful": true, "sources": []any{},
+	})
+
+	if _, errors := p.tryReadDataSource("aap_inventory_sources", map[string]any{"inventory_id": emptyId + 100}); errors == "" {
+		t.Error("reading the sources of an unknown inventory did not fail")
+	}
+}
@@ -0,0 +1,183 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &jobTemplateInstanceGroupResource{}
+	_ resource.ResourceWithConfigure = &jobTemplateInstanceGroupResource{}
+)
+
+// NewJobTemplateInstanceGroupResource is a helper function to simplify the provider implementation.
+func NewJobTemplateInstanceGroupResource() resource.Resource {
+	return &jobTemplateInstanceGroupResource{}
+}
+
+// jobTemplateInstanceGroupResource sets the ordered instance groups of a job template managed elsewhere.
+type jobTemplateInstanceGroupResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *jobTemplateInstanceGroupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_job_template_instance_group"
+}
+
+// Schema defines the schema for the resource.
+func (r *jobTemplateInstanceGroupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Sets the instance groups a job template runs on, in order of preference. " +
+			"The job template itself is managed elsewhere; instance groups associated with it outside of this resource are removed.",
+		Attributes: map[string]schema.Attribute{
+			"job_template_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the job template.",
+				Validators:  idValidators(),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"instance_group_ids": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Required:    true,
+				Description: "Ids of the instance groups, the first one being preferred.",
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.UniqueValues(),
+					listvalidator.ValueInt64sAre(idValidators()...),
+				},
+			},
+		},
+	}
+}
+
+// jobTemplateInstanceGroupResourceModel maps the resource schema data.
+type jobTemplateInstanceGroupResourceModel struct {
+	JobTemplateId    types.Int64 `tfsdk:"job_template_id"`
+	InstanceGroupIds []int64     `tfsdk:"instance_group_ids"`
+}
+
+// Create associates the instance groups.
+func (r *jobTemplateInstanceGroupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_job_template_instance_group", "create", &resp.Diagnostics)
+	var plan jobTemplateInstanceGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetJobTemplateInstanceGroups(plan.JobTemplateId.ValueInt64(), plan.InstanceGroupIds); err != nil {
+		resp.Diagnostics.AddError("Unable to set job template instance groups", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the instance groups of the job template.
+func (r *jobTemplateInstanceGroupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_job_template_instance_group", "read", &resp.Diagnostics)
+	var state jobTemplateInstanceGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jobTemplateId := state.JobTemplateId.ValueInt64()
+	jobTemplate, err := r.client.GetJobTemplate(jobTemplateId)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read job template", err.Error())
+		return
+	}
+	if jobTemplate == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	groups, err := r.client.GetJobTemplateInstanceGroups(jobTemplateId)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read job template instance groups", err.Error())
+		return
+	}
+	if len(groups) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.InstanceGroupIds = make([]int64, len(groups))
+	for i, group := range groups {
+		state.InstanceGroupIds[i] = group.Id
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update reorders, adds and removes instance groups.
+func (r *jobTemplateInstanceGroupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_job_template_instance_group", "update", &resp.Diagnostics)
+	var plan jobTemplateInstanceGroupResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.SetJobTemplateInstanceGroups(plan.JobTemplateId.ValueInt64(), plan.InstanceGroupIds); err != nil {
+		resp.Diagnostics.AddError("Unable to set job template instance groups", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the instance groups from the job template.
+func (r *jobTemplateInstanceGroupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_job_template_instance_group", "delete", &resp.Diagnostics)
+	var state jobTemplateInstanceGroupResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jobTemplateId := state.JobTemplateId.ValueInt64()
+	jobTemplate, err := r.client.GetJobTemplate(jobTemplateId)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read job template", err.Error())
+		return
+	}
+	if jobTemplate == nil {
+		return
+	}
+
+	if err := r.client.SetJobTemplateInstanceGroups(jobTemplateId, nil); err != nil {
+		resp.Diagnostics.AddError("Unable to remove job template instance groups", err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *jobTemplateInstanceGroupResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
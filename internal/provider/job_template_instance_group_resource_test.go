@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestJobTemplateInstanceGroupResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	template := mock.addObject("api/v2/job_templates", map[string]any{"name": "Deploy", "organization": organization})
+	controlplane := mock.addObject("api/v2/instance_groups", map[string]any{"name": "controlplane"})
+	edge := mock.addObject("api/v2/instance_groups", map[string]any{"name": "edge"})
+	gpu := mock.addObject("api/v2/instance_groups", map[string]any{"name": "gpu"})
+
+	p := newTestProvider(t, mock, nil)
+	groups := p.resource("aap_job_template_instance_group")
+	config := map[string]any{"job_template_id": template, "instance_group_ids": []any{edge, controlplane}}
+	groups.apply(config)
+	if ids := mock.related("api/v2/job_templates", template, "instance_groups"); !slices.Equal(ids, []int64{edge, controlplane}) {
+		t.Errorf("job template runs on %v, expected edge then controlplane", ids)
+	}
+
+	groups.read()
+	testExpect(t, groups.State(), map[string]any{"instance_group_ids": []any{edge, controlplane}})
+	if changes := groups.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	// reordering keeps the groups already in place at the front
+	groups.apply(map[string]any{"job_template_id": template, "instance_group_ids": []any{edge, gpu}})
+	if ids := mock.related("api/v2/job_templates", template, "instance_groups"); !slices.Equal(ids, []int64{edge, gpu}) {
+		t.Errorf("job template runs on %v, expected edge then gpu", ids)
+	}
+
+	groups.destroy()
+	if ids := mock.related("api/v2/job_templates", template, "instance_groups"); len(ids) > 0 {
+		t.Errorf("job template runs on %v after destroy", ids)
+	}
+}
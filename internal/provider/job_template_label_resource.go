@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &jobTemplateLabelResource{}
+	_ resource.ResourceWithConfigure = &jobTemplateLabelResource{}
+)
+
+// NewJobTemplateLabelResource is a helper function to simplify the provider implementation.
+func NewJobTemplateLabelResource() resource.Resource {
+	return &jobTemplateLabelResource{}
+}
+
+// jobTemplateLabelResource attaches a label to a job template managed elsewhere.
+type jobTemplateLabelResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *jobTemplateLabelResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_job_template_label"
+}
+
+// Schema defines the schema for the resource.
+func (r *jobTemplateLabelResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.Int64{
+		int64planmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Attaches a label to a job template. Other labels of the job template are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"job_template_id": schema.Int64Attribute{
+				Required:      true,
+				Description:   "Id of the job template.",
+				Validators:    idValidators(),
+				PlanModifiers: replace,
+			},
+			"label_id": schema.Int64Attribute{
+				Required:      true,
+				Description:   "Id of the label.",
+				Validators:    idValidators(),
+				PlanModifiers: replace,
+			},
+		},
+	}
+}
+
+// jobTemplateLabelResourceModel maps the resource schema data.
+type jobTemplateLabelResourceModel struct {
+	JobTemplateId types.Int64 `tfsdk:"job_template_id"`
+	LabelId       types.Int64 `tfsdk:"label_id"`
+}
+
+// Create attaches the label.
+func (r *jobTemplateLabelResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_job_template_label", "create", &resp.Diagnostics)
+	var plan jobTemplateLabelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.AssociateJobTemplateLabel(plan.JobTemplateId.ValueInt64(), plan.LabelId.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to attach job template label", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read checks that the label is still attached to the job template.
+func (r *jobTemplateLabelResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_job_template_label", "read", &resp.Diagnostics)
+	var state jobTemplateLabelResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jobTemplate, err := r.client.GetJobTemplate(state.JobTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read job template", err.Error())
+		return
+	}
+	if jobTemplate == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	labels, err := r.client.GetJobTemplateLabels(state.JobTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read job template labels", err.Error())
+		return
+	}
+	for _, label := range labels {
+		if label.Id == state.LabelId.ValueInt64() {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// Update is never called as every attribute requires replacement.
+func (r *jobTemplateLabelResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_job_template_label", "update", &resp.Diagnostics)
+	var plan jobTemplateLabelResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete detaches the label from the job template.
+func (r *jobTemplateLabelResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_job_template_label", "delete", &resp.Diagnostics)
+	var state jobTemplateLabelResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	jobTemplate, err := r.client.GetJobTemplate(state.JobTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read job template", err.Error())
+		return
+	}
+	if jobTemplate == nil {
+		return
+	}
+
+	if err := r.client.DisassociateJobTemplateLabel(state.JobTemplateId.ValueInt64(), state.LabelId.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to detach job template label", err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *jobTemplateLabelResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
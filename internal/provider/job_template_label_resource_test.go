@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestJobTemplateLabelResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	template := mock.addObject("api/v2/job_templates", map[string]any{"name": "Deploy", "organization": organization})
+	production := mock.addObject("api/v2/labels", map[string]any{"name": "production", "organization": organization})
+	web := mock.addObject("api/v2/labels", map[string]any{"name": "web", "organization": organization})
+
+	p := newTestProvider(t, mock, nil)
+	other := p.resource("aap_job_template_label")
+	other.apply(map[string]any{"job_template_id": template, "label_id": web})
+	label := p.resource("aap_job_template_label")
+	config := map[string]any{"job_template_id": template, "label_id": production}
+	label.apply(config)
+
+	if labels := mock.related("api/v2/job_templates", template, "labels"); !slices.Equal(labels, []int64{web, production}) {
+		t.Errorf("job template has labels %v, expected web and production", labels)
+	}
+	if state := label.read(); state == nil {
+		t.Fatal("the attached label was removed from the state")
+	}
+	if changes := label.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	label.destroy()
+	if labels := mock.related("api/v2/job_templates", template, "labels"); !slices.Equal(labels, []int64{web}) {
+		t.Errorf("job template has labels %v after destroy, expected the other label to be kept", labels)
+	}
+}
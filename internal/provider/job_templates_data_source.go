@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &jobTemplatesDataSource{}
+	_ datasource.DataSourceWithConfigure = &jobTemplatesDataSource{}
+)
+
+// NewJobTemplatesDataSource is a helper function to simplify the provider implementation.
+func NewJobTemplatesDataSource() datasource.DataSource {
+	return &jobTemplatesDataSource{}
+}
+
+// jobTemplatesDataSource lists the controller job templates matching filters.
+type jobTemplatesDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *jobTemplatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_job_templates"
+}
+
+// Schema defines the schema for the data source.
+func (d *jobTemplatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the automation controller job templates visible to the provider credentials, ordered by name, " +
+			"e.g. to attach the same schedule or notification to every template carrying a label with for_each.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only list the job templates of this organization.",
+				Validators:  idValidators(),
+			},
+			"project_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only list the job templates running playbooks of this project.",
+				Validators:  idValidators(),
+			},
+			"label": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the job templates with a label of this name.",
+				Validators:  nameValidators(),
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the job templates whose name starts with this prefix.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"search": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the job templates matching this search term of the AAP API, looked up in names and descriptions.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Number of job templates requested at once, from 1 to %d (the default).", listPageSize),
+				Validators:  []validator.Int64{int64validator.Between(1, listPageSize)},
+			},
+			"max_results": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of job templates listed; all of them are listed when omitted.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ids": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Ids of the job templates, in the order of job_templates.",
+			},
+			"job_templates": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Job templates, ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the job template.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the job template.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the job template.",
+						},
+						"organization_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the organization of the job template.",
+						},
+						"project_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the project the playbook is read from.",
+						},
+						"inventory_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the inventory, null when it is prompted on launch.",
+						},
+						"playbook": schema.StringAttribute{
+							Computed:    true,
+							Description: "Path of the playbook in the project.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Status of the last job of the template, e.g. successful, failed, running or never updated.",
+						},
+						"last_job_run": schema.StringAttribute{
+							Computed:    true,
+							Description: "When the last job of the template finished, null if none ran.",
+						},
+						"last_job_failed": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the last job of the template failed.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// jobTemplatesDataSourceModel maps the data source schema data.
+type jobTemplatesDataSourceModel struct {
+	OrganizationId types.Int64        `tfsdk:"organization_id"`
+	ProjectId      types.Int64        `tfsdk:"project_id"`
+	Label          types.String       `tfsdk:"label"`
+	NamePrefix     types.String       `tfsdk:"name_prefix"`
+	Search         types.String       `tfsdk:"search"`
+	PageSize       types.Int64        `tfsdk:"page_size"`
+	MaxResults     types.Int64        `tfsdk:"max_results"`
+	Ids            []int64            `tfsdk:"ids"`
+	JobTemplates   []jobTemplateModel `tfsdk:"job_templates"`
+}
+
+// jobTemplateModel maps a job template.
+type jobTemplateModel struct {
+	Id             types.Int64  `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	OrganizationId types.Int64  `tfsdk:"organization_id"`
+	ProjectId      types.Int64  `tfsdk:"project_id"`
+	InventoryId    types.Int64  `tfsdk:"inventory_id"`
+	Playbook       types.String `tfsdk:"playbook"`
+	Status         types.String `tfsdk:"status"`
+	LastJobRun     types.String `tfsdk:"last_job_run"`
+	LastJobFailed  types.Bool   `tfsdk:"last_job_failed"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *jobTemplatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_job_templates", "read", &resp.Diagnostics)
+	var state jobTemplatesDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := listQuery(state.PageSize, map[string]attr.Value{
+		"organization":     state.OrganizationId,
+		"project":          state.ProjectId,
+		"labels__name":     state.Label,
+		"name__startswith": state.NamePrefix,
+		"search":           state.Search,
+	})
+	templates, err := d.client.GetJobTemplates(query, int(state.MaxResults.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read job templates", err.Error())
+		return
+	}
+
+	state.Ids = []int64{}
+	state.JobTemplates = []jobTemplateModel{}
+	for _, template := range templates {
+		state.Ids = append(state.Ids, template.Id)
+		state.JobTemplates = append(state.JobTemplates, jobTemplateModel{
+			Id:             types.Int64Value(template.Id),
+			Name:           types.StringValue(template.Name),
+			Description:    types.StringValue(template.Description),
+			OrganizationId: types.Int64PointerValue(template.Organization),
+			ProjectId:      types.Int64PointerValue(template.Project),
+			InventoryId:    types.Int64PointerValue(template.Inventory),
+			Playbook:       types.StringValue(template.Playbook),
+			Status:         types.StringValue(template.Status),
+			LastJobRun:     types.StringPointerValue(template.LastJobRun),
+			LastJobFailed:  types.BoolValue(template.LastJobFailed),
+		})
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *jobTemplatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
@@ -0,0 +1,72 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestJobTemplatesDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	defaultOrganization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	salesOrganization := mock.addObject("api/v2/organizations", map[string]any{"name": "Sales"})
+	project := mock.addObject("api/v2/projects", map[string]any{"name": "Playbooks", "organization": defaultOrganization})
+	inventory := mock.addObject("api/v2/inventories", map[string]any{"name": "Servers", "organization": defaultOrganization})
+	deploy := mock.addObject("api/v2/job_templates", map[string]any{
+		"name": "Deploy", "description": "Deploy the application", "organization": defaultOrganization,
+		"project": project, "inventory": inventory, "playbook": "deploy.yml",
+		"status": "failed", "last_job_run": "2024-05-02T08:00:00.000000Z", "last_job_failed": true,
+	})
+	backup := mock.addObject("api/v2/job_templates", map[string]any{
+		"name": "Backup", "organization": salesOrganization, "project": project, "inventory": nil, "playbook": "backup.yml",
+		"status": "never updated", "last_job_run": nil, "last_job_failed": false,
+	})
+	cleanup := mock.addObject("api/v2/job_templates", map[string]any{
+		"name": "Cleanup", "organization": defaultOrganization, "playbook": "cleanup.yml",
+		"status": "successful", "last_job_run": "2024-05-03T08:00:00.000000Z", "last_job_failed": false,
+	})
+
+	// a template carrying two labels of the same name, in two organizations, is listed twice by AAP
+	production := mock.addObject("api/v2/labels", map[string]any{"name": "production", "organization": defaultOrganization})
+	salesProduction := mock.addObject("api/v2/labels", map[string]any{"name": "production", "organization": salesOrganization})
+	mock.members[fmt.Sprintf("api/v2/job_templates/%d/labels", deploy)] = []int64{production, salesProduction}
+	mock.members[fmt.Sprintf("api/v2/job_templates/%d/labels", backup)] = []int64{salesProduction}
+
+	p := newTestProvider(t, mock, nil)
+	state := p.readDataSource("aap_job_templates", nil)
+	testExpect(t, state, map[string]any{"ids": []any{backup, cleanup, deploy}})
+	if templates, _ := state["job_templates"].([]any); len(templates) != 3 {
+		t.Fatalf("job_templates = %v, expected every job template", state["job_templates"])
+	} else {
+		testExpect(t, templates[0].(map[string]any), map[string]any{
+			"organization_id": salesOrganization, "inventory_id": nil, "playbook": "backup.yml",
+			"status": "never updated", "last_job_run": nil, "last_job_failed": false,
+		})
+		testExpect(t, templates[1].(map[string]any), map[string]any{
+			"status": "successful", "last_job_run": "2024-05-03T08:00:00.000000Z", "last_job_failed": false,
+		})
+		testExpect(t, templates[2].(map[string]any), map[string]any{
+			"id": deploy, "name": "Deploy", "description": "Deploy the application", "organization_id": defaultOrganization,
+			"project_id": project, "inventory_id": inventory, "playbook": "deploy.yml",
+			"status": "failed", "last_job_run": "2024-05-02T08:00:00.000000Z", "last_job_failed": true,
+		})
+	}
+
+	testExpect(t, p.readDataSource("aap_job_templates", map[string]any{"organization_id": defaultOrganization}), map[string]any{"ids": []any{cleanup, deploy}})
+	testExpect(t, p.readDataSource("aap_job_templates", map[string]any{"project_id": project}), map[string]any{"ids": []any{backup, deploy}})
+	testExpect(t, p.readDataSource("aap_job_templates", map[string]any{"name_prefix": "C"}), map[string]any{"ids": []any{cleanup}})
+	testExpect(t, p.readDataSource("aap_job_templates", map[string]any{"search": "application"}), map[string]any{"ids": []any{deploy}})
+	testExpect(t, p.readDataSource("aap_job_templates", map[string]any{"page_size": 1, "max_results": 1}), map[string]any{"ids": []any{backup}})
+
+	state = p.readDataSource("aap_job_templates", map[string]any{"label": "production"})
+	testExpect(t, state, map[string]any{"ids": []any{backup, deploy}})
+	if templates, _ := state["job_templates"].([]any); len(templates) != 2 {
+		t.Errorf("job_templates = %v, expected each template once", state["job_templates"])
+	}
+
+	// the template listed twice counts once towards max_results
+	migrate := mock.addObject("api/v2/job_templates", map[string]any{"name": "Migrate", "organization": defaultOrganization, "playbook": "migrate.yml"})
+	mock.members[fmt.Sprintf("api/v2/job_templates/%d/labels", migrate)] = []int64{production}
+	testExpect(t, p.readDataSource("aap_job_templates", map[string]any{"label": "production", "page_size": 1, "max_results": 3}), map[string]any{
+		"ids": []any{backup, deploy, migrate},
+	})
+}
@@ -0,0 +1,163 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &meshTopologyDataSource{}
+	_ datasource.DataSourceWithConfigure = &meshTopologyDataSource{}
+)
+
+// NewMeshTopologyDataSource is a helper function to simplify the provider implementation.
+func NewMeshTopologyDataSource() datasource.DataSource {
+	return &meshTopologyDataSource{}
+}
+
+// meshTopologyDataSource reads the instances of the automation mesh and the links between them.
+type meshTopologyDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *meshTopologyDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_mesh_topology"
+}
+
+// Schema defines the schema for the data source.
+func (d *meshTopologyDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Reads the instances of the automation mesh and the receptor links between them, as the topology viewer of AAP draws them, " +
+			"e.g. to document the mesh or to check that every execution node is reachable.",
+		Attributes: map[string]schema.Attribute{
+			"nodes": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Instances of the mesh.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the instance.",
+						},
+						"hostname": schema.StringAttribute{
+							Computed:    true,
+							Description: "Hostname of the instance, referenced by links.",
+						},
+						"node_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Role of the instance: control, execution, hybrid or hop.",
+						},
+						"node_state": schema.StringAttribute{
+							Computed:    true,
+							Description: "State of the instance, e.g. healthy, unavailable or deprovisioning.",
+						},
+						"enabled": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether jobs may run on the instance.",
+						},
+					},
+				},
+			},
+			"links": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Receptor links between the instances.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"source": schema.StringAttribute{
+							Computed:    true,
+							Description: "Hostname of the instance opening the connection.",
+						},
+						"target": schema.StringAttribute{
+							Computed:    true,
+							Description: "Hostname of the instance listening for the connection.",
+						},
+						"link_state": schema.StringAttribute{
+							Computed:    true,
+							Description: "State of the link, e.g. established, adding or disconnected.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// meshTopologyDataSourceModel maps the data source schema data.
+type meshTopologyDataSourceModel struct {
+	Nodes []meshNodeModel `tfsdk:"nodes"`
+	Links []meshLinkModel `tfsdk:"links"`
+}
+
+// meshNodeModel maps an instance of the mesh.
+type meshNodeModel struct {
+	Id        types.Int64  `tfsdk:"id"`
+	Hostname  types.String `tfsdk:"hostname"`
+	NodeType  types.String `tfsdk:"node_type"`
+	NodeState types.String `tfsdk:"node_state"`
+	Enabled   types.Bool   `tfsdk:"enabled"`
+}
+
+// meshLinkModel maps a link between two instances.
+type meshLinkModel struct {
+	Source    types.String `tfsdk:"source"`
+	Target    types.String `tfsdk:"target"`
+	LinkState types.String `tfsdk:"link_state"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *meshTopologyDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_mesh_topology", "read", &resp.Diagnostics)
+	topology, err := d.client.GetMeshTopology()
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read mesh topology", err.Error())
+		return
+	}
+
+	state := meshTopologyDataSourceModel{
+		Nodes: make([]meshNodeModel, len(topology.Nodes)),
+		Links: make([]meshLinkModel, len(topology.Links)),
+	}
+	for i, node := range topology.Nodes {
+		state.Nodes[i] = meshNodeModel{
+			Id:        types.Int64Value(node.Id),
+			Hostname:  types.StringValue(node.Hostname),
+			NodeType:  types.StringValue(node.NodeType),
+			NodeState: types.StringValue(node.NodeState),
+			Enabled:   types.BoolValue(node.Enabled),
+		}
+	}
+	for i, link := range topology.Links {
+		state.Links[i] = meshLinkModel{
+			Source:    types.StringValue(link.Source),
+			Target:    types.StringValue(link.Target),
+			LinkState: types.StringValue(link.LinkState),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *meshTopologyDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
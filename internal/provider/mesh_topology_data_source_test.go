@@ -0,0 +1,40 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestMeshTopologyDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	p := newTestProvider(t, mock, nil)
+	testExpect(t, p.readDataSource("aap_mesh_topology", map[string]any{}), map[string]any{"nodes": []any{}, "links": []any{}})
+
+	control := mock.addObject("api/v2/instances", map[string]any{
+		"hostname": "controller.example.com", "node_type": "control", "node_state": "healthy", "enabled": true,
+	})
+	hop := mock.addObject("api/v2/instances", map[string]any{
+		"hostname": "hop.example.com", "node_type": "hop", "node_state": "healthy", "enabled": true,
+	})
+	execution := mock.addObject("api/v2/instances", map[string]any{
+		"hostname": "exec.example.com", "node_type": "execution", "node_state": "unavailable", "enabled": false,
+	})
+	mock.members[fmt.Sprintf("api/v2/instances/%d/peers", control)] = []int64{hop}
+	mock.members[fmt.Sprintf("api/v2/instances/%d/peers", execution)] = []int64{hop}
+
+	state := p.readDataSource("aap_mesh_topology", map[string]any{})
+	if nodes, _ := state["nodes"].([]any); len(nodes) != 3 {
+		t.Fatalf("nodes = %v, expected the instances of the mesh", state["nodes"])
+	} else {
+		testExpect(t, nodes[0].(map[string]any), map[string]any{
+			"id": control, "hostname": "controller.example.com", "node_type": "control", "node_state": "healthy", "enabled": true,
+		})
+		testExpect(t, nodes[2].(map[string]any), map[string]any{
+			"id": execution, "hostname": "exec.example.com", "node_type": "execution", "node_state": "unavailable", "enabled": false,
+		})
+	}
+	testExpect(t, state, map[string]any{"links": []any{
+		map[string]any{"source": "controller.example.com", "target": "hop.example.com", "link_state": "established"},
+		map[string]any{"source": "exec.example.com", "target": "hop.example.com", "link_state": "established"},
+	}})
+}
@@ -0,0 +1,1521 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const (
+	// mockPageSize is deliberately small so that the pagination of list endpoints is exercised.
+	mockPageSize = 2
+	// mockSummaryLimit is the number of related objects AAP includes in summary fields.
+	mockSummaryLimit = 5
+	// mockUsername is reported as the creator of the objects created in the mock.
+	mockUsername = "admin"
+)
+
+// mockAAP is an in-memory implementation of the parts of the AAP API used by the provider. Inventories,
+// hosts, groups and their memberships, and stored Terraform states have dedicated handlers; the objects of
+// every other collection, of the controller, EDA, gateway and Automation Hub APIs, are stored as decoded JSON.
+type mockAAP struct {
+	mu sync.Mutex
+
+	server *httptest.Server
+	nextId int64
+
+	inventories   map[int64]*AAPInventory
+	hosts         map[int64]*AAPHost
+	groups        map[int64]*AAPGroup
+	groupHosts    map[int64][]int64
+	groupChildren map[int64][]int64
+	states        map[int64][]byte
+	// inventoryRoles holds the object roles of inventories by inventory id, e.g. {"use_role": {"id": 4, "name": "Use"}}.
+	inventoryRoles map[int64]map[string]any
+
+	// objects holds the objects of the other collections by collection, e.g. api/v2/job_templates, and id.
+	objects map[string]map[int64]map[string]any
+	// members holds the ids of the objects associated with an object by related endpoint, e.g. api/v2/job_templates/3/labels.
+	members map[string][]int64
+	// collections customise how the objects of a collection are served, by collection.
+	collections map[string]*mockCollection
+	// requests counts the requests by method and path, e.g. "POST api/v2/job_templates/3/copy".
+	requests map[string]int
+}
+
+// newMockAAP starts a mock AAP server that is shut down at the end of the test.
+func newMockAAP(t *testing.T) *mockAAP {
+	t.Helper()
+
+	m := &mockAAP{
+		inventories:    make(map[int64]*AAPInventory),
+		hosts:          make(map[int64]*AAPHost),
+		groups:         make(map[int64]*AAPGroup),
+		groupHosts:     make(map[int64][]int64),
+		groupChildren:  make(map[int64][]int64),
+		states:         make(map[int64][]byte),
+		inventoryRoles: make(map[int64]map[string]any),
+		objects:        make(map[string]map[int64]map[string]any),
+		members:        make(map[string][]int64),
+		requests:       make(map[string]int),
+	}
+	m.collections = m.mockCollections()
+	m.server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	t.Cleanup(m.server.Close)
+	return m
+}
+
+// URL returns the address to configure as the provider host.
+func (m *mockAAP) URL() string {
+	return m.server.URL
+}
+
+// addState stores a Terraform state document and returns its id.
+func (m *mockAAP) addState(body []byte) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextId++
+	m.states[m.nextId] = body
+	return m.nextId
+}
+
+func (m *mockAAP) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	username, password, basic := r.BasicAuth()
+	token, bearer := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !(basic && username != "" && password != "") && !(bearer && token != "") {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"detail": "Authentication credentials were not provided."})
+		return
+	}
+	m.requests[r.Method+" "+strings.Trim(r.URL.Path, "/")]++
+
+	if strings.HasPrefix(r.URL.Path, "/"+hubGalaxyAPIPath) {
+		m.serveGalaxy(w, r)
+		return
+	}
+	if strings.Trim(r.URL.Path, "/") == "api/v2/schedules/preview" && r.Method == http.MethodPost {
+		m.previewSchedule(w, r)
+		return
+	}
+	if strings.Trim(r.URL.Path, "/") == gatewayAPIPath+"me" && r.Method == http.MethodGet {
+		m.serveGatewayMe(w, r)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/api/v2/") {
+		m.serveObjects(w, r)
+		return
+	}
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v2/"), "/"), "/")
+	if !slices.Contains([]string{"inventories", "hosts", "groups", "state", "mesh_visualizer"}, parts[0]) {
+		m.serveObjects(w, r)
+		return
+	}
+	var id int64
+	var related []string
+	if len(parts) > 1 {
+		var err error
+		if id, err = strconv.ParseInt(parts[1], 10, 64); err != nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+			return
+		}
+		related = parts[2:]
+	}
+
+	switch {
+	case parts[0] == "inventories":
+		m.serveInventories(w, r, id, related)
+	case parts[0] == "hosts":
+		m.serveHosts(w, r, id, related)
+	case parts[0] == "groups":
+		m.serveGroups(w, r, id, related)
+	case parts[0] == "state" && len(parts) == 2 && r.Method == http.MethodGet:
+		body, ok := m.states[id]
+		if !ok {
+			writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	case parts[0] == "mesh_visualizer" && len(parts) == 1 && r.Method == http.MethodGet:
+		m.serveMeshVisualizer(w)
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+	}
+}
+
+// previewSchedule returns the first occurrences of a rule made of a DTSTART with an optional TZID and an RRULE
+// with a MINUTELY, HOURLY, DAILY or WEEKLY frequency, an optional INTERVAL and an optional COUNT.
+func (m *mockAAP) previewSchedule(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		RRule string `json:"rrule"`
+	}
+	if !decodeBody(w, r, &body) {
+		return
+	}
+	invalid := func(message string) {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{"rrule": {message}})
+	}
+
+	start, rule, ok := strings.Cut(body.RRule, " RRULE:")
+	if !ok || !strings.HasPrefix(start, "DTSTART") {
+		invalid("Valid rrule must contain DTSTART and RRULE.")
+		return
+	}
+	location := time.UTC
+	timestamp := start[strings.LastIndex(start, ":")+1:]
+	if zone, found := strings.CutPrefix(start[:strings.LastIndex(start, ":")], "DTSTART;TZID="); found {
+		var err error
+		if location, err = time.LoadLocation(zone); err != nil {
+			invalid("Unknown time zone " + zone + ".")
+			return
+		}
+	}
+	first, err := time.ParseInLocation("20060102T150405", strings.TrimSuffix(timestamp, "Z"), location)
+	if err != nil {
+		invalid("Invalid DTSTART " + timestamp + ".")
+		return
+	}
+
+	steps := map[string]time.Duration{"MINUTELY": time.Minute, "HOURLY": time.Hour, "DAILY": 24 * time.Hour, "WEEKLY": 7 * 24 * time.Hour}
+	var step time.Duration
+	interval, count := 1, 10
+	for _, part := range strings.Split(rule, ";") {
+		name, value, _ := strings.Cut(part, "=")
+		switch name {
+		case "FREQ":
+			step = steps[value]
+		case "INTERVAL":
+			interval, err = strconv.Atoi(value)
+		case "COUNT":
+			count, err = strconv.Atoi(value)
+			count = min(count, 10)
+		default:
+			err = fmt.Errorf("unsupported %s", name)
+		}
+		if err != nil {
+			invalid(fmt.Sprintf("Invalid rrule part %s.", part))
+			return
+		}
+	}
+	if step == 0 {
+		invalid("Valid rrule must contain a supported FREQ.")
+		return
+	}
+
+	preview := AAPSchedulePreview{Local: []string{}, UTC: []string{}}
+	for i := 0; i < count; i++ {
+		// days and weeks follow the wall clock of the time zone across daylight saving changes
+		occurrence := first.AddDate(0, 0, i*interval*int(step/(24*time.Hour)))
+		if step < 24*time.Hour {
+			occurrence = first.Add(time.Duration(i*interval) * step)
+		}
+		preview.Local = append(preview.Local, occurrence.Format(time.RFC3339))
+		preview.UTC = append(preview.UTC, occurrence.UTC().Format(time.RFC3339))
+	}
+	writeJSON(w, http.StatusOK, preview)
+}
+
+// serveMeshVisualizer draws the mesh from the objects of api/v2/instances, linking every instance to its peers.
+func (m *mockAAP) serveMeshVisualizer(w http.ResponseWriter) {
+	instances := m.filterObjects("api/v2/instances", nil, nil)
+	nodes := make([]map[string]any, len(instances))
+	links := []map[string]any{}
+	for i, instance := range instances {
+		nodes[i] = map[string]any{}
+		for _, field := range []string{"id", "hostname", "node_type", "node_state", "enabled"} {
+			nodes[i][field] = (*instance)[field]
+		}
+		for _, peer := range m.members[fmt.Sprintf("api/v2/instances/%d/peers", mockId((*instance)["id"]))] {
+			links = append(links, map[string]any{
+				"source": (*instance)["hostname"], "target": m.objects["api/v2/instances"][peer]["hostname"], "link_state": "established",
+			})
+		}
+	}
+	writeJSON(w, http.StatusOK, map[string]any{"nodes": nodes, "links": links})
+}
+
+func (m *mockAAP) serveInventories(w http.ResponseWriter, r *http.Request, id int64, related []string) {
+	if id == 0 {
+		if r.Method == http.MethodGet {
+			query := r.URL.Query()
+			writePage(w, r, filterValues(m.inventories, func(inventory *AAPInventory) bool {
+				return (!query.Has("name") || inventory.Name == query.Get("name")) &&
+					(!query.Has("organization") || strconv.FormatInt(inventory.Organization, 10) == query.Get("organization"))
+			}))
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeJSON(w, http.StatusMethodNotAllowed, nil)
+			return
+		}
+		var inventory AAPInventory
+		if !decodeBody(w, r, &inventory) || !validateName(w, inventory.Name) {
+			return
+		}
+		m.nextId++
+		inventory.Id = m.nextId
+		inventory.Created = mockTimestamp()
+		inventory.Modified = inventory.Created
+		inventory.SummaryFields = &aapAuditSummaryFields{CreatedBy: &AAPUserSummary{Id: 1, Username: mockUsername}}
+		m.inventories[inventory.Id] = &inventory
+		writeJSON(w, http.StatusCreated, inventory)
+		return
+	}
+
+	inventory, ok := m.inventories[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+
+	if len(related) == 1 && r.Method == http.MethodGet {
+		switch related[0] {
+		case "hosts":
+			hosts := filterValues(m.hosts, func(host *AAPHost) bool { return host.Inventory == id })
+			for i, host := range hosts {
+				hosts[i] = m.withSummaryFields(host)
+			}
+			writePage(w, r, hosts)
+			return
+		case "groups":
+			writePage(w, r, filterValues(m.groups, func(group *AAPGroup) bool { return group.Inventory == id }))
+			return
+		case "inventory_sources":
+			writePage(w, r, m.filterObjects("api/v2/inventory_sources", r.URL.Query(), func(source map[string]any) bool {
+				return mockId(source["inventory"]) == id
+			}))
+			return
+		}
+	}
+	if len(related) > 0 {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, m.withObjectRoles(m.withCounts(inventory)))
+	case http.MethodPut:
+		var updated AAPInventory
+		if !decodeBody(w, r, &updated) || !validateName(w, updated.Name) {
+			return
+		}
+		updated.Id = id
+		updated.Created = inventory.Created
+		updated.Modified = mockTimestamp()
+		updated.SummaryFields = inventory.SummaryFields
+		m.inventories[id] = &updated
+		writeJSON(w, http.StatusOK, m.withCounts(&updated))
+	case http.MethodDelete:
+		for hostId, host := range m.hosts {
+			if host.Inventory == id {
+				m.deleteHost(hostId)
+			}
+		}
+		for groupId, group := range m.groups {
+			if group.Inventory == id {
+				m.deleteGroup(groupId)
+			}
+		}
+		delete(m.inventories, id)
+		writeJSON(w, http.StatusAccepted, nil)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+func (m *mockAAP) serveHosts(w http.ResponseWriter, r *http.Request, id int64, related []string) {
+	if id == 0 {
+		if r.Method == http.MethodGet {
+			organization := r.URL.Query().Get("inventory__organization")
+			filter := hostFilterNode{operator: "and"}
+			if raw := r.URL.Query().Get("host_filter"); raw != "" {
+				var err error
+				if filter, err = parseHostFilter(raw); err != nil {
+					writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "Invalid host_filter: " + err.Error()})
+					return
+				}
+			}
+			writePage(w, r, filterValues(m.hosts, func(host *AAPHost) bool {
+				inventory, ok := m.inventories[host.Inventory]
+				return (organization == "" || ok && strconv.FormatInt(inventory.Organization, 10) == organization) &&
+					m.matchesHostFilter(host, filter)
+			}))
+			return
+		}
+		if r.Method != http.MethodPost {
+			writeJSON(w, http.StatusMethodNotAllowed, nil)
+			return
+		}
+		var host AAPHost
+		if !decodeBody(w, r, &host) || !m.validateMember(w, host.Name, host.Inventory, "host", 0) {
+			return
+		}
+		m.nextId++
+		host.Id = m.nextId
+		m.hosts[host.Id] = &host
+		writeJSON(w, http.StatusCreated, host)
+		return
+	}
+
+	host, ok := m.hosts[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+
+	if len(related) == 1 && related[0] == "groups" && r.Method == http.MethodGet {
+		writePage(w, r, filterValues(m.groups, func(group *AAPGroup) bool { return slices.Contains(m.groupHosts[group.Id], id) }))
+		return
+	}
+	if len(related) > 0 {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, host)
+	case http.MethodPut:
+		var updated AAPHost
+		if !decodeBody(w, r, &updated) || !m.validateMember(w, updated.Name, updated.Inventory, "host", id) {
+			return
+		}
+		updated.Id = id
+		m.hosts[id] = &updated
+		writeJSON(w, http.StatusOK, updated)
+	case http.MethodDelete:
+		m.deleteHost(id)
+		writeJSON(w, http.StatusNoContent, nil)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+// matchesHostFilter reports whether the host matches a parsed smart inventory host filter. Terms compare the fields
+// of the host with the lookups filterObjects supports, groups__ terms match any group the host is a direct member of.
+func (m *mockAAP) matchesHostFilter(host *AAPHost, filter hostFilterNode) bool {
+	switch filter.operator {
+	case "not":
+		return !m.matchesHostFilter(host, filter.operands[0])
+	case "and", "or":
+		for _, operand := range filter.operands {
+			if m.matchesHostFilter(host, operand) != (filter.operator == "and") {
+				return filter.operator == "or"
+			}
+		}
+		return filter.operator == "and"
+	}
+
+	path := strings.Split(filter.key, "__")
+	if path[0] == "groups" && len(path) > 1 {
+		for _, group := range m.groups {
+			object := map[string]any{"id": group.Id, "name": group.Name, "description": group.Description}
+			if slices.Contains(m.groupHosts[group.Id], host.Id) && m.matches("", object, path[1:], filter.value) {
+				return true
+			}
+		}
+		return false
+	}
+	object := map[string]any{"id": host.Id, "name": host.Name, "description": host.Description, "inventory": host.Inventory}
+	return m.matches("", object, path, filter.value)
+}
+
+func (m *mockAAP) serveGroups(w http.ResponseWriter, r *http.Request, id int64, related []string) {
+	if id == 0 {
+		if r.Method != http.MethodPost {
+			writeJSON(w, http.StatusMethodNotAllowed, nil)
+			return
+		}
+		var group AAPGroup
+		if !decodeBody(w, r, &group) || !m.validateMember(w, group.Name, group.Inventory, "group", 0) {
+			return
+		}
+		m.nextId++
+		group.Id = m.nextId
+		m.groups[group.Id] = &group
+		writeJSON(w, http.StatusCreated, group)
+		return
+	}
+
+	group, ok := m.groups[id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+
+	if len(related) == 1 && (related[0] == "hosts" || related[0] == "children") {
+		members := m.groupHosts
+		if related[0] == "children" {
+			members = m.groupChildren
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			if related[0] == "hosts" {
+				writePage(w, r, filterValues(m.hosts, func(host *AAPHost) bool { return slices.Contains(members[id], host.Id) }))
+			} else {
+				writePage(w, r, filterValues(m.groups, func(child *AAPGroup) bool { return slices.Contains(members[id], child.Id) }))
+			}
+		case http.MethodPost:
+			var payload struct {
+				Id           int64 `json:"id"`
+				Disassociate bool  `json:"disassociate"`
+			}
+			if !decodeBody(w, r, &payload) {
+				return
+			}
+			if payload.Disassociate {
+				members[id] = slices.DeleteFunc(members[id], func(member int64) bool { return member == payload.Id })
+			} else if !slices.Contains(members[id], payload.Id) {
+				members[id] = append(members[id], payload.Id)
+			}
+			writeJSON(w, http.StatusNoContent, nil)
+		default:
+			writeJSON(w, http.StatusMethodNotAllowed, nil)
+		}
+		return
+	}
+	if len(related) > 0 {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, group)
+	case http.MethodPut:
+		var updated AAPGroup
+		if !decodeBody(w, r, &updated) || !m.validateMember(w, updated.Name, updated.Inventory, "group", id) {
+			return
+		}
+		updated.Id = id
+		m.groups[id] = &updated
+		writeJSON(w, http.StatusOK, updated)
+	case http.MethodDelete:
+		m.deleteGroupRecursive(id)
+		writeJSON(w, http.StatusNoContent, nil)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+// withSummaryFields returns a copy of the host with its group summary, which AAP truncates.
+func (m *mockAAP) withSummaryFields(host *AAPHost) *AAPHost {
+	groups := filterValues(m.groups, func(group *AAPGroup) bool { return slices.Contains(m.groupHosts[group.Id], host.Id) })
+	summary := aapSummaryList{Count: int64(len(groups))}
+	for _, group := range groups[:min(len(groups), mockSummaryLimit)] {
+		summary.Results = append(summary.Results, AAPGroup{Id: group.Id, Name: group.Name})
+	}
+
+	withSummary := *host
+	withSummary.SummaryFields = &aapHostSummaryFields{Groups: summary}
+	return &withSummary
+}
+
+// withCounts returns a copy of the inventory with the summary counts AAP computes.
+func (m *mockAAP) withCounts(inventory *AAPInventory) *AAPInventory {
+	withCounts := *inventory
+	withCounts.TotalHosts = int64(len(filterValues(m.hosts, func(host *AAPHost) bool { return host.Inventory == inventory.Id })))
+	withCounts.TotalGroups = int64(len(filterValues(m.groups, func(group *AAPGroup) bool { return group.Inventory == inventory.Id })))
+	return &withCounts
+}
+
+// withObjectRoles adds the object roles of the inventory to its summary fields, as AAP returns them.
+func (m *mockAAP) withObjectRoles(inventory *AAPInventory) any {
+	roles, ok := m.inventoryRoles[inventory.Id]
+	if !ok {
+		return inventory
+	}
+	body, _ := json.Marshal(inventory)
+	var rendered map[string]any
+	_ = json.Unmarshal(body, &rendered)
+	summaryFields, _ := rendered["summary_fields"].(map[string]any)
+	if summaryFields == nil {
+		summaryFields = map[string]any{}
+	}
+	summaryFields["object_roles"] = roles
+	rendered["summary_fields"] = summaryFields
+	return rendered
+}
+
+// mockTimestamp returns the current time formatted like the timestamps of AAP objects.
+func mockTimestamp() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000000Z")
+}
+
+func (m *mockAAP) deleteHost(id int64) {
+	delete(m.hosts, id)
+	for groupId, hosts := range m.groupHosts {
+		m.groupHosts[groupId] = slices.DeleteFunc(hosts, func(host int64) bool { return host == id })
+	}
+}
+
+func (m *mockAAP) deleteGroup(id int64) {
+	delete(m.groups, id)
+	delete(m.groupHosts, id)
+	delete(m.groupChildren, id)
+	for parentId, children := range m.groupChildren {
+		m.groupChildren[parentId] = slices.DeleteFunc(children, func(child int64) bool { return child == id })
+	}
+}
+
+// deleteGroupRecursive deletes a group like AAP does, together with its hosts and child groups
+// that are not members of any other group.
+func (m *mockAAP) deleteGroupRecursive(id int64) {
+	hosts, children := m.groupHosts[id], m.groupChildren[id]
+	m.deleteGroup(id)
+	for _, host := range hosts {
+		if !m.isMember(m.groupHosts, host) {
+			m.deleteHost(host)
+		}
+	}
+	for _, child := range children {
+		if _, ok := m.groups[child]; ok && !m.isMember(m.groupChildren, child) {
+			m.deleteGroupRecursive(child)
+		}
+	}
+}
+
+// isMember reports whether the host or group is a member of any group.
+func (m *mockAAP) isMember(members map[int64][]int64, id int64) bool {
+	for _, ids := range members {
+		if slices.Contains(ids, id) {
+			return true
+		}
+	}
+	return false
+}
+
+// validateMember checks the name and inventory of a host or group like AAP does,
+// including the uniqueness of the name within the inventory.
+func (m *mockAAP) validateMember(w http.ResponseWriter, name string, inventory int64, kind string, id int64) bool {
+	if !validateName(w, name) {
+		return false
+	}
+	if _, ok := m.inventories[inventory]; !ok {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{
+			"inventory": {fmt.Sprintf("Invalid pk \"%d\" - object does not exist.", inventory)},
+		})
+		return false
+	}
+
+	var names []string
+	if kind == "host" {
+		names = filterNames(m.hosts, func(host *AAPHost) (int64, int64, string) { return host.Id, host.Inventory, host.Name }, inventory, id)
+	} else {
+		names = filterNames(m.groups, func(group *AAPGroup) (int64, int64, string) { return group.Id, group.Inventory, group.Name }, inventory, id)
+	}
+	if slices.Contains(names, name) {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{
+			"__all__": {fmt.Sprintf("A %s with this name and inventory already exists.", kind)},
+		})
+		return false
+	}
+	return true
+}
+
+func validateName(w http.ResponseWriter, name string) bool {
+	if name == "" {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{"name": {"This field may not be blank."}})
+		return false
+	}
+	return true
+}
+
+// filterNames returns the names of the objects of the inventory, excluding the object being updated.
+func filterNames[T any](objects map[int64]*T, fields func(*T) (int64, int64, string), inventory int64, exclude int64) []string {
+	var names []string
+	for _, object := range objects {
+		id, objectInventory, name := fields(object)
+		if objectInventory == inventory && id != exclude {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// filterValues returns the objects matching keep, ordered by id like AAP list endpoints.
+func filterValues[T any](objects map[int64]*T, keep func(*T) bool) []*T {
+	ids := make([]int64, 0, len(objects))
+	for id := range objects {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	results := []*T{}
+	for _, id := range ids {
+		if keep(objects[id]) {
+			results = append(results, objects[id])
+		}
+	}
+	return results
+}
+
+// writePage writes the page requested by the page and page_size query parameters, linking to the next one.
+func writePage[T any](w http.ResponseWriter, r *http.Request, results []*T) {
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, err := strconv.Atoi(r.URL.Query().Get("page_size"))
+	if err != nil || pageSize < 1 {
+		pageSize = mockPageSize
+	}
+
+	start := min((page-1)*pageSize, len(results))
+	end := min(start+pageSize, len(results))
+	response := aapListResponse[*T]{
+		Count:   int64(len(results)),
+		Results: results[start:end],
+	}
+	if end < len(results) {
+		// like AAP, the next page keeps the filters and ordering of the request
+		query := r.URL.Query()
+		query.Set("page", strconv.Itoa(page+1))
+		query.Set("page_size", strconv.Itoa(pageSize))
+		next := r.URL.Path + "?" + query.Encode()
+		response.Next = &next
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, v any) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"detail": "JSON parse error - " + err.Error()})
+		return false
+	}
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	if v == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// mockCollection customises how the mock serves the objects of a collection.
+type mockCollection struct {
+	// defaults are the fields of created objects that the request leaves out.
+	defaults map[string]any
+	// unique are the fields whose values must be unique in the collection, e.g. name.
+	unique []string
+	// members are the collections of the objects associated with an object, by related name, when
+	// they differ from the collection of the same name next to this one.
+	members map[string]string
+	// related serve the related endpoints of an object which are not associations, by name.
+	related map[string]func(w http.ResponseWriter, r *http.Request, object map[string]any)
+	// render returns the object as AAP returns it, with the fields it computes.
+	render func(object map[string]any) map[string]any
+	// softDelete are the fields set on deleted objects, which are kept, e.g. deleted: true.
+	softDelete map[string]any
+	// async are the methods answered with a pulp task, which completes right away, instead of the object.
+	async []string
+	// created returns the object AAP returns on creation, when it differs from the object it returns afterwards,
+	// e.g. with a secret.
+	created func(r *http.Request, object map[string]any) map[string]any
+}
+
+// mockCollections returns the collections with a behaviour of their own.
+func (m *mockAAP) mockCollections() map[string]*mockCollection {
+	return map[string]*mockCollection{
+		"api/v2/organizations": {
+			unique:  []string{"name"},
+			members: mockNotificationMembers(map[string]string{}, true),
+		},
+		"api/v2/projects": {defaults: map[string]any{"status": "successful", "scm_type": "", "scm_branch": ""}},
+		"api/v2/job_templates": {
+			members: mockNotificationMembers(map[string]string{}, false),
+			related: map[string]func(http.ResponseWriter, *http.Request, map[string]any){
+				"copy": m.copyTemplate,
+			},
+		},
+		"api/v2/host_metrics": {softDelete: map[string]any{"deleted": true}},
+		"api/v2/users": {
+			unique:   []string{"username"},
+			defaults: map[string]any{"email": "", "first_name": "", "last_name": "", "is_superuser": false, "ldap_dn": ""},
+		},
+		"api/v2/workflow_job_templates": {
+			members: mockNotificationMembers(map[string]string{}, true),
+			related: map[string]func(http.ResponseWriter, *http.Request, map[string]any){
+				"workflow_nodes": m.serveWorkflowNodes,
+				"copy":           m.copyTemplate,
+			},
+		},
+		"api/eda/v1/projects": {
+			unique:   []string{"name"},
+			defaults: map[string]any{"import_state": "completed", "git_hash": "4e1243bd22c66e76c2ba9eddc1f91394e57f9f83"},
+			render:   m.withEDARefs,
+		},
+		"api/eda/v1/eda-credentials":       {unique: []string{"name"}, render: m.withEDARefs},
+		"api/eda/v1/decision-environments": {unique: []string{"name"}, render: m.withEDARefs},
+		"api/eda/v1/event-streams":         {unique: []string{"name"}, render: m.withEventStreamSettings},
+		hubPulpAPIPath + "remotes/ansible/collection": {
+			unique: []string{"name"},
+			async:  []string{http.MethodPatch, http.MethodDelete},
+			render: withoutHubCredentials,
+		},
+		hubPulpAPIPath + "repositories/ansible/ansible": {
+			unique:  []string{"name"},
+			async:   []string{http.MethodPatch, http.MethodDelete},
+			related: map[string]func(http.ResponseWriter, *http.Request, map[string]any){"sync": m.syncRepository},
+			render:  withLatestVersion,
+		},
+		hubPulpAPIPath + "distributions/ansible/ansible": {
+			unique: []string{"name", "base_path"},
+			async:  []string{http.MethodPost, http.MethodPatch, http.MethodDelete},
+		},
+		hubPulpAPIPath + "groups": {
+			unique:  []string{"name"},
+			related: map[string]func(http.ResponseWriter, *http.Request, map[string]any){"roles": m.serveGroupRoles},
+		},
+		gatewayAPIPath + "organizations": {unique: []string{"name"}, defaults: map[string]any{"description": ""}},
+		gatewayAPIPath + "teams":         {defaults: map[string]any{"description": ""}},
+		gatewayAPIPath + "users": {
+			unique:   []string{"username"},
+			defaults: map[string]any{"email": "", "first_name": "", "last_name": "", "is_superuser": false},
+			render:   withoutPassword,
+			related:  map[string]func(http.ResponseWriter, *http.Request, map[string]any){"tokens": m.serveUserTokens},
+		},
+		gatewayAPIPath + "tokens":                {created: m.issueToken},
+		gatewayAPIPath + "role_definitions":      {unique: []string{"name"}, defaults: map[string]any{"managed": false}},
+		hubGalaxyAPIPath + "namespaces":          {defaults: map[string]any{"groups": []any{}}},
+		hubGalaxyAPIPath + "collection_versions": {render: withGalaxyHref},
+		"api/v2/workflow_job_template_nodes": {
+			defaults: map[string]any{"identifier": "", "extra_data": map[string]any{}, "limit": nil, "scm_branch": nil, "verbosity": nil},
+			members: map[string]string{"success_nodes": "api/v2/workflow_job_template_nodes",
+				"failure_nodes": "api/v2/workflow_job_template_nodes", "always_nodes": "api/v2/workflow_job_template_nodes"},
+			render: m.withWorkflowLinks,
+		},
+	}
+}
+
+// mockNotificationMembers adds the notification templates sent on the events of an object to its members, along with
+// the ones sent on approvals when the object has workflow approvals.
+func mockNotificationMembers(members map[string]string, approvals bool) map[string]string {
+	events := []string{"started", "success", "error"}
+	if approvals {
+		events = append(events, "approvals")
+	}
+	for _, event := range events {
+		members["notification_templates_"+event] = "api/v2/notification_templates"
+	}
+	return members
+}
+
+// addObject stores an object in the collection, e.g. addObject("api/v2/job_templates", map[string]any{"name": "Deploy"}),
+// and returns its id.
+func (m *mockAAP) addObject(collection string, fields map[string]any) int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// fields are stored as decoded from the JSON of a request
+	body, _ := json.Marshal(fields)
+	var decoded map[string]any
+	_ = json.Unmarshal(body, &decoded)
+	return m.createObject(collection, decoded)["id"].(int64)
+}
+
+// object returns the object with the given id in the collection as AAP returns it, nil if it does not exist.
+func (m *mockAAP) object(collection string, id int64) map[string]any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	object, ok := m.objects[collection][id]
+	if !ok {
+		return nil
+	}
+	return m.render(collection, object)
+}
+
+// related returns the ids of the objects associated with an object, e.g. related("api/v2/job_templates", 3, "labels").
+func (m *mockAAP) related(collection string, id int64, related string) []int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return slices.Clone(m.members[fmt.Sprintf("%s/%d/%s", collection, id, related)])
+}
+
+// requestCount returns the number of requests made with the method to the path, e.g. "POST", "api/v2/job_templates/3/copy".
+func (m *mockAAP) requestCount(method string, path string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.requests[method+" "+path]
+}
+
+// methodCount returns the number of requests made with any of the methods, on any path.
+func (m *mockAAP) methodCount(methods ...string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	count := 0
+	for request, n := range m.requests {
+		method, _, _ := strings.Cut(request, " ")
+		if slices.Contains(methods, method) {
+			count += n
+		}
+	}
+	return count
+}
+
+func (m *mockAAP) createObject(collection string, fields map[string]any) map[string]any {
+	m.nextId++
+	object := map[string]any{}
+	if behaviour := m.collections[collection]; behaviour != nil {
+		for field, value := range behaviour.defaults {
+			object[field] = value
+		}
+	}
+	for field, value := range fields {
+		object[field] = value
+	}
+	object["id"] = m.nextId
+	switch {
+	case strings.Contains(collection, "/pulp/"):
+		// pulp objects link to themselves with pulp_href, the url of a remote is its upstream
+		object["pulp_href"] = fmt.Sprintf("/%s/%d/", collection, m.nextId)
+	case !strings.HasPrefix(collection, "api/eda/"):
+		// EDA objects have no link to themselves, the url of a project is its repository
+		object["url"] = fmt.Sprintf("/%s/%d/", collection, m.nextId)
+	}
+	object["created"] = mockTimestamp()
+	object["modified"] = object["created"]
+
+	if m.objects[collection] == nil {
+		m.objects[collection] = make(map[int64]map[string]any)
+	}
+	m.objects[collection][m.nextId] = object
+	return object
+}
+
+// render returns a copy of the object with the fields computed by AAP.
+func (m *mockAAP) render(collection string, object map[string]any) map[string]any {
+	rendered := make(map[string]any, len(object))
+	for field, value := range object {
+		rendered[field] = value
+	}
+	if behaviour := m.collections[collection]; behaviour != nil && behaviour.render != nil {
+		rendered = behaviour.render(rendered)
+	}
+	return rendered
+}
+
+// serveObjects serves the collections without a dedicated handler: listing with filters, creating, reading,
+// updating and deleting objects, and listing, associating and disassociating related objects.
+func (m *mockAAP) serveObjects(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	// objects nested in another are identified by the last id of the path, e.g. the role in groups/3/roles/4
+	index := -1
+	for i, segment := range segments {
+		if _, err := strconv.ParseInt(segment, 10, 64); err == nil {
+			index = i
+		}
+	}
+	if index < 0 {
+		m.serveCollection(w, r, strings.Join(segments, "/"))
+		return
+	}
+
+	collection := strings.Join(segments[:index], "/")
+	id, _ := strconv.ParseInt(segments[index], 10, 64)
+	object, ok := m.objects[collection][id]
+	if !ok {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+	related := segments[index+1:]
+	if len(related) > 1 {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+	if len(related) == 1 {
+		if handler := m.relatedHandler(collection, related[0]); handler != nil {
+			handler(w, r, object)
+			return
+		}
+		m.serveMembers(w, r, collection, id, related[0])
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, m.render(collection, object))
+	case http.MethodPut, http.MethodPatch:
+		var fields map[string]any
+		if !decodeBody(w, r, &fields) || !m.validateUnique(w, collection, id, fields) {
+			return
+		}
+		delete(fields, "id")
+		for field, value := range fields {
+			object[field] = value
+		}
+		object["modified"] = mockTimestamp()
+		if m.isAsync(collection, r.Method) {
+			m.writeTask(w, nil)
+			return
+		}
+		writeJSON(w, http.StatusOK, m.render(collection, object))
+	case http.MethodDelete:
+		if behaviour := m.collections[collection]; behaviour != nil && behaviour.softDelete != nil {
+			for field, value := range behaviour.softDelete {
+				object[field] = value
+			}
+		} else {
+			m.deleteObject(collection, id)
+		}
+		if m.isAsync(collection, r.Method) {
+			m.writeTask(w, nil)
+			return
+		}
+		writeJSON(w, http.StatusNoContent, nil)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+func (m *mockAAP) relatedHandler(collection string, related string) func(http.ResponseWriter, *http.Request, map[string]any) {
+	if behaviour := m.collections[collection]; behaviour != nil {
+		return behaviour.related[related]
+	}
+	return nil
+}
+
+// serveCollection lists the objects of the collection matching the query, or creates one.
+func (m *mockAAP) serveCollection(w http.ResponseWriter, r *http.Request, collection string) {
+	switch r.Method {
+	case http.MethodGet:
+		writePage(w, r, m.filterObjects(collection, r.URL.Query(), nil))
+	case http.MethodPost:
+		var fields map[string]any
+		if !decodeBody(w, r, &fields) || !m.validateUnique(w, collection, 0, fields) {
+			return
+		}
+		object := m.createObject(collection, fields)
+		if m.isAsync(collection, r.Method) {
+			m.writeTask(w, []any{object["pulp_href"]})
+			return
+		}
+		if behaviour := m.collections[collection]; behaviour != nil && behaviour.created != nil {
+			writeJSON(w, http.StatusCreated, behaviour.created(r, object))
+			return
+		}
+		writeJSON(w, http.StatusCreated, m.render(collection, object))
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+// isAsync reports whether requests with the method to the objects of the collection are answered with a task.
+func (m *mockAAP) isAsync(collection string, method string) bool {
+	behaviour := m.collections[collection]
+	return behaviour != nil && slices.Contains(behaviour.async, method)
+}
+
+// createTask adds a pulp task which completed after creating the objects with the given hrefs.
+func (m *mockAAP) createTask(created []any) map[string]any {
+	return m.createObject(hubPulpAPIPath+"tasks", map[string]any{"state": "completed", "error": nil, "created_resources": created})
+}
+
+// writeTask answers a request to an asynchronous pulp endpoint with a completed task.
+func (m *mockAAP) writeTask(w http.ResponseWriter, created []any) {
+	writeJSON(w, http.StatusAccepted, map[string]any{"task": m.createTask(created)["pulp_href"]})
+}
+
+// membersCollection returns the collection of the objects associated with the objects of collection under related.
+func (m *mockAAP) membersCollection(collection string, related string) string {
+	if behaviour := m.collections[collection]; behaviour != nil && behaviour.members[related] != "" {
+		return behaviour.members[related]
+	}
+	return collection[:strings.LastIndex(collection, "/")+1] + related
+}
+
+// serveMembers lists, associates and disassociates the objects related to an object.
+func (m *mockAAP) serveMembers(w http.ResponseWriter, r *http.Request, collection string, id int64, related string) {
+	key := fmt.Sprintf("%s/%d/%s", collection, id, related)
+	membersCollection := m.membersCollection(collection, related)
+
+	switch r.Method {
+	case http.MethodGet:
+		// associated objects are listed in the order they were associated in
+		results := []*map[string]any{}
+		for _, member := range m.members[key] {
+			if object, ok := m.objects[membersCollection][member]; ok {
+				rendered := m.render(membersCollection, object)
+				results = append(results, &rendered)
+			}
+		}
+		writePage(w, r, results)
+	case http.MethodPost:
+		var payload struct {
+			Id           int64 `json:"id"`
+			Disassociate bool  `json:"disassociate"`
+		}
+		if !decodeBody(w, r, &payload) {
+			return
+		}
+		if _, ok := m.objects[membersCollection][payload.Id]; !ok {
+			writeJSON(w, http.StatusBadRequest, map[string]string{"msg": fmt.Sprintf("Related object %d does not exist.", payload.Id)})
+			return
+		}
+		if payload.Disassociate {
+			m.members[key] = slices.DeleteFunc(m.members[key], func(member int64) bool { return member == payload.Id })
+		} else if !slices.Contains(m.members[key], payload.Id) {
+			m.members[key] = append(m.members[key], payload.Id)
+		}
+		writeJSON(w, http.StatusNoContent, nil)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+// filterObjects returns the objects of the collection matching the query and keep, when not nil, as AAP returns
+// them. Fields are matched exactly or with the lookups of AAP, e.g. organization__name=Default or
+// finished__isnull=false, following the ids of the objects a field refers to. Filtering on associated objects, e.g.
+// labels__name=prod, lists an object once per matching associated object like AAP does. search looks the term up
+// in names, descriptions, usernames and emails, not__ negates a filter and order_by sorts the results.
+func (m *mockAAP) filterObjects(collection string, query map[string][]string, keep func(map[string]any) bool) []*map[string]any {
+	prefix := collection[:strings.LastIndex(collection, "/")+1]
+	ids := make([]int64, 0, len(m.objects[collection]))
+	for id := range m.objects[collection] {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	var objects []map[string]any
+	for _, id := range ids {
+		object := m.objects[collection][id]
+		if keep != nil && !keep(object) {
+			continue
+		}
+		matches := 1
+		for filter, values := range query {
+			path := strings.Split(filter, "__")
+			members, associated := m.members[fmt.Sprintf("%s/%d/%s", collection, id, path[0])]
+			switch {
+			case slices.Contains([]string{"page", "page_size", "order_by"}, filter):
+			case filter == "search":
+				found := false
+				for _, field := range []string{"name", "description", "username", "first_name", "last_name", "email"} {
+					found = found || (object[field] != nil && m.matches(prefix, object, []string{field, "icontains"}, values[0]))
+				}
+				if !found {
+					matches = 0
+				}
+			case path[0] == "not":
+				if m.matches(prefix, object, path[1:], values[0]) {
+					matches = 0
+				}
+			case associated && len(path) > 1:
+				membersCollection := m.membersCollection(collection, path[0])
+				count := 0
+				for _, member := range members {
+					if m.matches(prefix, m.objects[membersCollection][member], path[1:], values[0]) {
+						count++
+					}
+				}
+				matches *= count
+			case !m.matches(prefix, object, path, values[0]):
+				matches = 0
+			}
+		}
+		for i := 0; i < matches; i++ {
+			objects = append(objects, object)
+		}
+	}
+
+	if orderBy := query["order_by"]; len(orderBy) > 0 {
+		field, descending := strings.CutPrefix(orderBy[0], "-")
+		sort.SliceStable(objects, func(i, j int) bool {
+			a, b := fmt.Sprint(objects[i][field]), fmt.Sprint(objects[j][field])
+			if descending {
+				return a > b
+			}
+			return a < b
+		})
+	}
+
+	results := make([]*map[string]any, len(objects))
+	for i, object := range objects {
+		rendered := m.render(collection, object)
+		results[i] = &rendered
+	}
+	return results
+}
+
+// matches reports whether the field at path matches a filter value, the last element of the path being a lookup
+// such as isnull, startswith, icontains, lt or gt. Fields holding the id of another object are followed into the
+// collection named after them, e.g. organization into organizations.
+func (m *mockAAP) matches(prefix string, object map[string]any, path []string, expected string) bool {
+	value := object[path[0]]
+	rest := path[1:]
+	if len(rest) > 0 && !slices.Contains([]string{"isnull", "startswith", "icontains", "iexact", "lt", "gt", "in"}, rest[0]) {
+		referenced := m.objects[prefix+mockPlural(path[0])][mockId(value)]
+		if referenced == nil {
+			return false
+		}
+		return m.matches(prefix, referenced, rest, expected)
+	}
+
+	actual := fmt.Sprint(value)
+	if mockId(value) != 0 {
+		actual = strconv.FormatInt(mockId(value), 10)
+	}
+	if len(rest) == 0 {
+		return actual == expected
+	}
+	switch rest[0] {
+	case "isnull":
+		return (value == nil) == (expected == "true")
+	case "startswith":
+		return strings.HasPrefix(actual, expected)
+	case "icontains":
+		return strings.Contains(strings.ToLower(actual), strings.ToLower(expected))
+	case "iexact":
+		return strings.EqualFold(actual, expected)
+	case "lt":
+		return value != nil && actual < expected
+	case "gt":
+		return value != nil && actual > expected
+	default:
+		return slices.Contains(strings.Split(expected, ","), actual)
+	}
+}
+
+// mockPlural returns the name of the collection of the objects a field refers to, e.g. inventories for inventory.
+func mockPlural(field string) string {
+	if strings.HasSuffix(field, "y") {
+		return strings.TrimSuffix(field, "y") + "ies"
+	}
+	return field + "s"
+}
+
+// mockId returns the id a decoded JSON value holds, 0 when it is not an id.
+func mockId(value any) int64 {
+	switch id := value.(type) {
+	case int:
+		return int64(id)
+	case int64:
+		return id
+	case float64:
+		if id == float64(int64(id)) {
+			return int64(id)
+		}
+	}
+	return 0
+}
+
+// validateUnique checks that the fields which must be unique in the collection are, like AAP does.
+func (m *mockAAP) validateUnique(w http.ResponseWriter, collection string, id int64, fields map[string]any) bool {
+	behaviour := m.collections[collection]
+	if behaviour == nil {
+		return true
+	}
+	for _, field := range behaviour.unique {
+		value, ok := fields[field]
+		if !ok {
+			continue
+		}
+		for otherId, other := range m.objects[collection] {
+			if otherId != id && other[field] == value {
+				writeJSON(w, http.StatusBadRequest, map[string][]string{field: {"An object with this " + field + " already exists."}})
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// deleteObject removes the object and its associations.
+func (m *mockAAP) deleteObject(collection string, id int64) {
+	delete(m.objects[collection], id)
+	prefix := fmt.Sprintf("%s/%d/", collection, id)
+	for key, ids := range m.members {
+		if strings.HasPrefix(key, prefix) {
+			delete(m.members, key)
+			continue
+		}
+		// ids are unique across collections
+		m.members[key] = slices.DeleteFunc(ids, func(member int64) bool { return member == id })
+	}
+}
+
+// serveWorkflowNodes lists the nodes of the workflow job template, or adds one.
+func (m *mockAAP) serveWorkflowNodes(w http.ResponseWriter, r *http.Request, workflow map[string]any) {
+	switch r.Method {
+	case http.MethodGet:
+		writePage(w, r, m.filterObjects("api/v2/workflow_job_template_nodes", r.URL.Query(), func(node map[string]any) bool {
+			return mockId(node["workflow_job_template"]) == mockId(workflow["id"])
+		}))
+	case http.MethodPost:
+		var fields map[string]any
+		if !decodeBody(w, r, &fields) {
+			return
+		}
+		fields["workflow_job_template"] = workflow["id"]
+		node := m.createObject("api/v2/workflow_job_template_nodes", fields)
+		writeJSON(w, http.StatusCreated, m.withWorkflowLinks(node))
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+// withWorkflowLinks adds the ids of the nodes a workflow node leads to.
+func (m *mockAAP) withWorkflowLinks(node map[string]any) map[string]any {
+	rendered := make(map[string]any, len(node)+3)
+	for field, value := range node {
+		rendered[field] = value
+	}
+	for _, linkType := range workflowLinkTypes {
+		rendered[linkType+"_nodes"] = append([]int64{}, m.members[fmt.Sprintf("api/v2/workflow_job_template_nodes/%d/%s_nodes", mockId(node["id"]), linkType)]...)
+	}
+	return rendered
+}
+
+// copyTemplate copies the fields of the template into a new template with the name of the request.
+func (m *mockAAP) copyTemplate(w http.ResponseWriter, r *http.Request, template map[string]any) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+		return
+	}
+	var payload struct {
+		Name string `json:"name"`
+	}
+	if !decodeBody(w, r, &payload) {
+		return
+	}
+	collection := strings.Trim(template["url"].(string), "/")
+	collection = collection[:strings.LastIndex(collection, "/")]
+	fields := map[string]any{}
+	for field, value := range template {
+		fields[field] = value
+	}
+	fields["name"] = payload.Name
+	writeJSON(w, http.StatusCreated, m.render(collection, m.createObject(collection, fields)))
+}
+
+// mockEDARefs are the collections the id fields of EDA objects refer to.
+var mockEDARefs = map[string]string{
+	"organization_id":    "api/eda/v1/organizations",
+	"eda_credential_id":  "api/eda/v1/eda-credentials",
+	"credential_type_id": "api/eda/v1/credential-types",
+}
+
+// withEDARefs replaces the id fields of an EDA object with the objects they refer to, as EDA detail responses do,
+// e.g. organization_id with organization: {id, name}.
+func (m *mockAAP) withEDARefs(object map[string]any) map[string]any {
+	for field, collection := range mockEDARefs {
+		value, ok := object[field]
+		if !ok {
+			continue
+		}
+		delete(object, field)
+		object[strings.TrimSuffix(field, "_id")] = nil
+		if value != nil {
+			ref := map[string]any{"id": value}
+			if referenced, ok := m.objects[collection][mockId(value)]; ok {
+				ref["name"] = referenced["name"]
+			}
+			object[strings.TrimSuffix(field, "_id")] = ref
+		}
+	}
+	return object
+}
+
+// withEventStreamSettings adds the URL events are posted to and the type of the event stream, which is the kind of
+// the credential type of its credential.
+func (m *mockAAP) withEventStreamSettings(stream map[string]any) map[string]any {
+	stream["url"] = fmt.Sprintf("%s/eda-event-streams/api/eda/v1/external_event_stream/%d/post/", m.URL(), mockId(stream["id"]))
+	stream["event_stream_type"] = ""
+	if credential, ok := m.objects["api/eda/v1/eda-credentials"][mockId(stream["eda_credential_id"])]; ok {
+		if credentialType, ok := m.objects["api/eda/v1/credential-types"][mockId(credential["credential_type_id"])]; ok {
+			stream["event_stream_type"] = credentialType["kind"]
+		}
+	}
+	return m.withEDARefs(stream)
+}
+
+// withoutHubCredentials leaves out the credentials of a hub remote, which pulp never returns.
+func withoutHubCredentials(remote map[string]any) map[string]any {
+	for _, field := range []string{"token", "username", "password"} {
+		delete(remote, field)
+	}
+	return remote
+}
+
+// withLatestVersion adds the first version to hub repositories which were never synced.
+func withLatestVersion(repository map[string]any) map[string]any {
+	if repository["latest_version_href"] == nil {
+		repository["latest_version_href"] = fmt.Sprintf("%sversions/0/", repository["pulp_href"])
+	}
+	return repository
+}
+
+// syncRepository syncs the hub repository from its remote, which adds a repository version.
+func (m *mockAAP) syncRepository(w http.ResponseWriter, r *http.Request, repository map[string]any) {
+	if r.Method != http.MethodPost {
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+		return
+	}
+	if repository["remote"] == nil {
+		writeJSON(w, http.StatusBadRequest, map[string][]string{"remote": {"A remote must be provided."}})
+		return
+	}
+	href := repository["pulp_href"].(string)
+	repository["latest_version_href"] = fmt.Sprintf("%sversions/%d/", href, m.requests["POST "+strings.Trim(href, "/")+"/sync"])
+	m.writeTask(w, nil)
+}
+
+// serveGroupRoles lists, grants and revokes the roles of a hub group, which are nested in the group.
+func (m *mockAAP) serveGroupRoles(w http.ResponseWriter, r *http.Request, group map[string]any) {
+	m.serveCollection(w, r, strings.Trim(group["pulp_href"].(string), "/")+"/roles")
+}
+
+// serveGalaxy serves the Galaxy API of Automation Hub, which addresses objects by name: namespaces, stored in
+// api/galaxy/v3/namespaces, and collection versions, stored with the name of their repository in
+// api/galaxy/v3/collection_versions.
+func (m *mockAAP) serveGalaxy(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/"+hubGalaxyAPIPath), "/"), "/")
+	switch {
+	case len(segments) == 2 && segments[0] == "namespaces":
+		m.serveNamespace(w, r, segments[1])
+	case len(segments) == 10 && strings.Join(segments[:3], "/") == "plugin/ansible/content" && segments[5] == "index" && r.Method == http.MethodGet:
+		version := m.collectionVersion(segments[3], segments[5:])
+		if version == nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+			return
+		}
+		writeJSON(w, http.StatusOK, m.render(hubGalaxyAPIPath+"collection_versions", version))
+	case len(segments) == 8 && segments[0] == "collections" && segments[5] == "move" && r.Method == http.MethodPost:
+		version := m.collectionVersion(segments[6], segments)
+		if version == nil {
+			writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+			return
+		}
+		version["repository"] = segments[7]
+		writeJSON(w, http.StatusAccepted, map[string]any{
+			"copy_task_id":   fmt.Sprint(m.createTask(nil)["id"]),
+			"remove_task_id": fmt.Sprint(m.createTask(nil)["id"]),
+		})
+	default:
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+	}
+}
+
+// withGalaxyHref adds the href of a collection version, which depends on the repository holding it.
+func withGalaxyHref(version map[string]any) map[string]any {
+	version["href"] = fmt.Sprintf("/%splugin/ansible/content/%s/collections/index/%s/%s/versions/%s/",
+		hubGalaxyAPIPath, version["repository"], version["namespace"], version["name"], version["version"])
+	return version
+}
+
+// collectionVersion returns the collection version in the repository at a path ending in
+// <namespace>/<name>/versions/<version>, after collections or collections/index.
+func (m *mockAAP) collectionVersion(repository string, path []string) map[string]any {
+	for _, version := range m.objects[hubGalaxyAPIPath+"collection_versions"] {
+		if version["repository"] == repository && version["namespace"] == path[1] && version["name"] == path[2] && version["version"] == path[4] {
+			return version
+		}
+	}
+	return nil
+}
+
+// serveNamespace reads the hub namespace and replaces its groups, which must exist in pulp.
+func (m *mockAAP) serveNamespace(w http.ResponseWriter, r *http.Request, name string) {
+	var namespace map[string]any
+	for _, object := range m.objects[hubGalaxyAPIPath+"namespaces"] {
+		if object["name"] == name {
+			namespace = object
+		}
+	}
+	if namespace == nil {
+		writeJSON(w, http.StatusNotFound, map[string]string{"detail": "Not found."})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, namespace)
+	case http.MethodPatch:
+		var fields struct {
+			Groups []any `json:"groups"`
+		}
+		if !decodeBody(w, r, &fields) {
+			return
+		}
+		for _, value := range fields.Groups {
+			group, _ := value.(map[string]any)
+			matches := m.filterObjects(hubPulpAPIPath+"groups", map[string][]string{"name": {fmt.Sprint(group["name"])}}, nil)
+			if len(matches) == 0 {
+				writeJSON(w, http.StatusBadRequest, map[string][]string{"groups": {fmt.Sprintf("Group name=%s does not exist", group["name"])}})
+				return
+			}
+			group["id"] = (*matches[0])["id"]
+		}
+		namespace["groups"] = fields.Groups
+		writeJSON(w, http.StatusOK, namespace)
+	default:
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+	}
+}
+
+// mockHrefId returns the id of the object at a pulp href.
+func mockHrefId(href string) int64 {
+	segments := strings.Split(strings.Trim(href, "/"), "/")
+	id, _ := strconv.ParseInt(segments[len(segments)-1], 10, 64)
+	return id
+}
+
+// withoutPassword leaves out the password of a gateway user, which AAP never returns.
+func withoutPassword(user map[string]any) map[string]any {
+	delete(user, "password")
+	return user
+}
+
+// serveGatewayMe lists the gateway user the request authenticates as with basic auth as the only result.
+func (m *mockAAP) serveGatewayMe(w http.ResponseWriter, r *http.Request) {
+	username, _, _ := r.BasicAuth()
+	writePage(w, r, m.filterObjects(gatewayAPIPath+"users", map[string][]string{"username": {username}}, nil))
+}
+
+// serveUserTokens lists the gateway tokens owned by the user.
+func (m *mockAAP) serveUserTokens(w http.ResponseWriter, r *http.Request, user map[string]any) {
+	if r.Method != http.MethodGet {
+		writeJSON(w, http.StatusMethodNotAllowed, nil)
+		return
+	}
+	writePage(w, r, m.filterObjects(gatewayAPIPath+"tokens", r.URL.Query(), func(token map[string]any) bool {
+		return mockId(token["user"]) == mockId(user["id"])
+	}))
+}
+
+// issueToken makes the user the request authenticates as with basic auth the owner of a new gateway token, and
+// returns the token with its value, which is only returned on creation.
+func (m *mockAAP) issueToken(r *http.Request, token map[string]any) map[string]any {
+	token["user"] = nil
+	if username, password, ok := r.BasicAuth(); ok {
+		for id, user := range m.objects[gatewayAPIPath+"users"] {
+			if user["username"] == username && user["password"] == password {
+				token["user"] = id
+			}
+		}
+	}
+	rendered := m.render(gatewayAPIPath+"tokens", token)
+	rendered["token"] = fmt.Sprintf("token-%d", token["id"])
+	return rendered
+}
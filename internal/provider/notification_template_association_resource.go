@@ -0,0 +1,221 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &notificationTemplateAssociationResource{}
+	_ resource.ResourceWithConfigure      = &notificationTemplateAssociationResource{}
+	_ resource.ResourceWithValidateConfig = &notificationTemplateAssociationResource{}
+)
+
+// NewNotificationTemplateAssociationResource is a helper function to simplify the provider implementation.
+func NewNotificationTemplateAssociationResource() resource.Resource {
+	return &notificationTemplateAssociationResource{}
+}
+
+// notificationTemplateAssociationResource sends a notification template on an event of an object managed elsewhere.
+type notificationTemplateAssociationResource struct {
+	client *AAPClient
+}
+
+// approvalNotifiables are the kinds of objects whose workflow approvals send notifications.
+var approvalNotifiables = []string{"organization", "workflow_job_template"}
+
+// Metadata returns the resource type name.
+func (r *notificationTemplateAssociationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_notification_template_association"
+}
+
+// Schema defines the schema for the resource.
+func (r *notificationTemplateAssociationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	replace := []planmodifier.Int64{
+		int64planmodifier.RequiresReplace(),
+	}
+
+	resp.Schema = schema.Schema{
+		Description: "Sends a notification template when a job of an object starts, succeeds or fails. " +
+			"Notification templates attached to an organization apply to every job of the organization, " +
+			"those attached to a project to its project updates. Other notification templates of the object are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"resource_type": schema.StringAttribute{
+				Required:    true,
+				Description: "Kind of object: organization, project, inventory_source, job_template or workflow_job_template.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("organization", "project", "inventory_source", "job_template", "workflow_job_template"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource_id": schema.Int64Attribute{
+				Required:      true,
+				Description:   "Id of the object.",
+				Validators:    idValidators(),
+				PlanModifiers: replace,
+			},
+			"event": schema.StringAttribute{
+				Required: true,
+				Description: "Event the notification is sent on: started, success or error, " +
+					"or approvals for organizations and workflow job templates.",
+				Validators: []validator.String{
+					stringvalidator.OneOf("started", "success", "error", "approvals"),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"notification_template_id": schema.Int64Attribute{
+				Required:      true,
+				Description:   "Id of the notification template.",
+				Validators:    idValidators(),
+				PlanModifiers: replace,
+			},
+		},
+	}
+}
+
+// notificationTemplateAssociationResourceModel maps the resource schema data.
+type notificationTemplateAssociationResourceModel struct {
+	ResourceType           types.String `tfsdk:"resource_type"`
+	ResourceId             types.Int64  `tfsdk:"resource_id"`
+	Event                  types.String `tfsdk:"event"`
+	NotificationTemplateId types.Int64  `tfsdk:"notification_template_id"`
+}
+
+// ValidateConfig ensures approval notifications are only requested where AAP sends them.
+func (r *notificationTemplateAssociationResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config notificationTemplateAssociationResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() || config.ResourceType.IsUnknown() || config.Event.IsUnknown() {
+		return
+	}
+
+	if config.Event.ValueString() == "approvals" && !slices.Contains(approvalNotifiables, config.ResourceType.ValueString()) {
+		resp.Diagnostics.AddAttributeError(path.Root("event"), "Invalid notification event",
+			fmt.Sprintf("Approval notifications are only sent for organizations and workflow job templates, not for a %s.",
+				config.ResourceType.ValueString()))
+	}
+}
+
+// Create attaches the notification template.
+func (r *notificationTemplateAssociationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_notification_template_association", "create", &resp.Diagnostics)
+	var plan notificationTemplateAssociationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.AssociateNotificationTemplate(plan.ResourceType.ValueString(), plan.ResourceId.ValueInt64(),
+		plan.Event.ValueString(), plan.NotificationTemplateId.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to attach notification template", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read checks that the notification template is still attached to the object.
+func (r *notificationTemplateAssociationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_notification_template_association", "read", &resp.Diagnostics)
+	var state notificationTemplateAssociationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := state.ResourceType.ValueString()
+	object, err := r.client.GetNotifiable(kind, state.ResourceId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read "+kind, err.Error())
+		return
+	}
+	if object == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	notificationTemplates, err := r.client.GetNotificationTemplates(kind, state.ResourceId.ValueInt64(), state.Event.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read notification templates", err.Error())
+		return
+	}
+	for _, notificationTemplate := range notificationTemplates {
+		if notificationTemplate.Id == state.NotificationTemplateId.ValueInt64() {
+			resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+			return
+		}
+	}
+	resp.State.RemoveResource(ctx)
+}
+
+// Update is never called as every attribute requires replacement.
+func (r *notificationTemplateAssociationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_notification_template_association", "update", &resp.Diagnostics)
+	var plan notificationTemplateAssociationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete detaches the notification template from the object.
+func (r *notificationTemplateAssociationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_notification_template_association", "delete", &resp.Diagnostics)
+	var state notificationTemplateAssociationResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := state.ResourceType.ValueString()
+	object, err := r.client.GetNotifiable(kind, state.ResourceId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read "+kind, err.Error())
+		return
+	}
+	if object == nil {
+		return
+	}
+
+	if err := r.client.DisassociateNotificationTemplate(kind, state.ResourceId.ValueInt64(),
+		state.Event.ValueString(), state.NotificationTemplateId.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to detach notification template", err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *notificationTemplateAssociationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestNotificationTemplateAssociationResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	workflow := mock.addObject("api/v2/workflow_job_templates", map[string]any{"name": "Release", "organization": organization})
+	template := mock.addObject("api/v2/job_templates", map[string]any{"name": "Deploy", "organization": organization})
+	slack := mock.addObject("api/v2/notification_templates", map[string]any{"name": "slack", "organization": organization})
+
+	p := newTestProvider(t, mock, nil)
+	association := p.resource("aap_notification_template_association")
+	message := association.tryApply(map[string]any{"resource_type": "job_template", "resource_id": template, "event": "approvals", "notification_template_id": slack})
+	if !strings.Contains(message, "Approval notifications are only sent for organizations and workflow job templates") {
+		t.Errorf("approval notifications of a job template give %q", message)
+	}
+
+	config := map[string]any{"resource_type": "workflow_job_template", "resource_id": workflow, "event": "approvals", "notification_template_id": slack}
+	association.apply(config)
+	if ids := mock.related("api/v2/workflow_job_templates", workflow, "notification_templates_approvals"); !slices.Equal(ids, []int64{slack}) {
+		t.Errorf("workflow sends %v on approvals, expected slack", ids)
+	}
+
+	if association.read() == nil {
+		t.Fatal("the attached notification template was removed from the state")
+	}
+	if changes := association.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	// changing the event attaches the notification template again
+	config["event"] = "error"
+	association.apply(config)
+	if ids := mock.related("api/v2/workflow_job_templates", workflow, "notification_templates_approvals"); len(ids) > 0 {
+		t.Errorf("workflow still sends %v on approvals", ids)
+	}
+	if ids := mock.related("api/v2/workflow_job_templates", workflow, "notification_templates_error"); !slices.Equal(ids, []int64{slack}) {
+		t.Errorf("workflow sends %v on error, expected slack", ids)
+	}
+
+	association.destroy()
+	if ids := mock.related("api/v2/workflow_job_templates", workflow, "notification_templates_error"); len(ids) > 0 {
+		t.Errorf("workflow sends %v on error after destroy", ids)
+	}
+}
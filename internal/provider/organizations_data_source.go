@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &organizationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &organizationsDataSource{}
+)
+
+// NewOrganizationsDataSource is a helper function to simplify the provider implementation.
+func NewOrganizationsDataSource() datasource.DataSource {
+	return &organizationsDataSource{}
+}
+
+// organizationsDataSource lists the controller organizations visible to the provider credentials.
+type organizationsDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *organizationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_organizations"
+}
+
+// Schema defines the schema for the data source.
+func (d *organizationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the automation controller organizations visible to the provider credentials, ordered by name, " +
+			"e.g. to create the same projects and templates in every organization with for_each.",
+		Attributes: map[string]schema.Attribute{
+			"name": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the organization with this exact name.",
+				Validators:  nameValidators(),
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the organizations whose name starts with this prefix.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"search": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the organizations matching this search term of the AAP API, looked up in names and descriptions.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Number of organizations requested at once, from 1 to %d (the default).", listPageSize),
+				Validators:  []validator.Int64{int64validator.Between(1, listPageSize)},
+			},
+			"max_results": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of organizations listed; all of them are listed when omitted.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ids": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Ids of the organizations, in the order of organizations.",
+			},
+			"organizations": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Organizations, ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the organization.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the organization.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the organization.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// organizationsDataSourceModel maps the data source schema data.
+type organizationsDataSourceModel struct {
+	Name          types.String        `tfsdk:"name"`
+	NamePrefix    types.String        `tfsdk:"name_prefix"`
+	Search        types.String        `tfsdk:"search"`
+	PageSize      types.Int64         `tfsdk:"page_size"`
+	MaxResults    types.Int64         `tfsdk:"max_results"`
+	Ids           []int64             `tfsdk:"ids"`
+	Organizations []organizationModel `tfsdk:"organizations"`
+}
+
+// organizationModel maps an organization.
+type organizationModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	Name        types.String `tfsdk:"name"`
+	Description types.String `tfsdk:"description"`
+}
+
+// listQuery returns the query parameters of a list endpoint ordering the results by name, with the given page size
+// and the filters, keyed by parameter, that are set.
+func listQuery(pageSize types.Int64, filters map[string]attr.Value) url.Values {
+	query := pageQuery()
+	query.Set("order_by", "name")
+	if !pageSize.IsNull() {
+		query.Set("page_size", strconv.FormatInt(pageSize.ValueInt64(), 10))
+	}
+	for parameter, value := range filters {
+		switch value := value.(type) {
+		case types.String:
+			if !value.IsNull() {
+				query.Set(parameter, value.ValueString())
+			}
+		case types.Int64:
+			if !value.IsNull() {
+				query.Set(parameter, strconv.FormatInt(value.ValueInt64(), 10))
+			}
+		case types.Bool:
+			if !value.IsNull() {
+				query.Set(parameter, strconv.FormatBool(value.ValueBool()))
+			}
+		}
+	}
+	return query
+}
+
+// query returns the filters and page size of the model as query parameters of the organizations endpoint.
+func (m *organizationsDataSourceModel) query() url.Values {
+	return listQuery(m.PageSize, map[string]attr.Value{
+		"name":             m.Name,
+		"name__startswith": m.NamePrefix,
+		"search":           m.Search,
+	})
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *organizationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_organizations", "read", &resp.Diagnostics)
+	var state organizationsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	organizations, err := d.client.GetOrganizations(state.query(), int(state.MaxResults.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read organizations", err.Error())
+		return
+	}
+
+	state.Ids = make([]int64, len(organizations))
+	state.Organizations = make([]organizationModel, len(organizations))
+	for i, organization := range organizations {
+		state.Ids[i] = organization.Id
+		state.Organizations[i] = organizationModel{
+			Id:          types.Int64Value(organization.Id),
+			Name:        types.StringValue(organization.Name),
+			Description: types.StringValue(organization.Description),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *organizationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
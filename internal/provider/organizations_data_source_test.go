@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestOrganizationsDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	ids := map[string]int64{}
+	for _, name := range []string{"Sales", "Default", "Security", "Engineering"} {
+		ids[name] = mock.addObject("api/v2/organizations", map[string]any{"name": name, "description": name + " department"})
+	}
+
+	p := newTestProvider(t, mock, nil)
+	state := p.readDataSource("aap_organizations", nil)
+	testExpect(t, state, map[string]any{"ids": []any{ids["Default"], ids["Engineering"], ids["Sales"], ids["Security"]}})
+	if organizations, _ := state["organizations"].([]any); len(organizations) != 4 {
+		t.Fatalf("organizations = %v, expected every organization", state["organizations"])
+	} else {
+		testExpect(t, organizations[2].(map[string]any), map[string]any{"id": ids["Sales"], "name": "Sales", "description": "Sales department"})
+	}
+
+	testExpect(t, p.readDataSource("aap_organizations", map[string]any{"name_prefix": "Se"}), map[string]any{"ids": []any{ids["Security"]}})
+	testExpect(t, p.readDataSource("aap_organizations", map[string]any{"name": "Sales"}), map[string]any{"ids": []any{ids["Sales"]}})
+	testExpect(t, p.readDataSource("aap_organizations", map[string]any{"search": "engineering"}), map[string]any{"ids": []any{ids["Engineering"]}})
+	testExpect(t, p.readDataSource("aap_organizations", map[string]any{"name": "Marketing"}), map[string]any{"ids": []any{}, "organizations": []any{}})
+
+	// page_size sets the number of requests, max_results stops requesting once enough organizations were listed
+	before := mock.requestCount("GET", "api/v2/organizations")
+	state = p.readDataSource("aap_organizations", map[string]any{"page_size": 1})
+	testExpect(t, state, map[string]any{"ids": []any{ids["Default"], ids["Engineering"], ids["Sales"], ids["Security"]}})
+	if requests := mock.requestCount("GET", "api/v2/organizations") - before; requests != 4 {
+		t.Errorf("listing 4 organizations with page_size 1 took %d requests", requests)
+	}
+
+	before = mock.requestCount("GET", "api/v2/organizations")
+	state = p.readDataSource("aap_organizations", map[string]any{"page_size": 1, "max_results": 2})
+	testExpect(t, state, map[string]any{"ids": []any{ids["Default"], ids["Engineering"]}})
+	if requests := mock.requestCount("GET", "api/v2/organizations") - before; requests != 2 {
+		t.Errorf("listing 2 organizations with page_size 1 took %d requests", requests)
+	}
+
+	testExpect(t, p.readDataSource("aap_organizations", map[string]any{"max_results": 3}), map[string]any{
+		"ids": []any{ids["Default"], ids["Engineering"], ids["Sales"]},
+	})
+
+	if _, errors := p.tryReadDataSource("aap_organizations", map[string]any{"page_size": listPageSize + 1}); errors == "" {
+		t.Errorf("page_size %d was accepted", listPageSize+1)
+	}
+}
@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &projectsDataSource{}
+	_ datasource.DataSourceWithConfigure = &projectsDataSource{}
+)
+
+// NewProjectsDataSource is a helper function to simplify the provider implementation.
+func NewProjectsDataSource() datasource.DataSource {
+	return &projectsDataSource{}
+}
+
+// projectsDataSource lists the controller projects matching filters.
+type projectsDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *projectsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_projects"
+}
+
+// Schema defines the schema for the data source.
+func (d *projectsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the automation controller projects visible to the provider credentials, ordered by name.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only list the projects of this organization.",
+				Validators:  idValidators(),
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the projects whose name starts with this prefix.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"search": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the projects matching this search term of the AAP API, looked up in names and descriptions.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Number of projects requested at once, from 1 to %d (the default).", listPageSize),
+				Validators:  []validator.Int64{int64validator.Between(1, listPageSize)},
+			},
+			"max_results": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of projects listed; all of them are listed when omitted.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ids": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Ids of the projects, in the order of projects.",
+			},
+			"projects": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Projects, ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the project.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the project.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the project.",
+						},
+						"organization_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the organization of the project.",
+						},
+						"scm_type": schema.StringAttribute{
+							Computed:    true,
+							Description: "Source control type, e.g. git, empty for manual projects.",
+						},
+						"scm_url": schema.StringAttribute{
+							Computed:    true,
+							Description: "URL of the source control repository.",
+						},
+						"scm_branch": schema.StringAttribute{
+							Computed:    true,
+							Description: "Branch, tag or commit checked out.",
+						},
+						"status": schema.StringAttribute{
+							Computed:    true,
+							Description: "Status of the last update of the project, e.g. successful or failed.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// projectsDataSourceModel maps the data source schema data.
+type projectsDataSourceModel struct {
+	OrganizationId types.Int64    `tfsdk:"organization_id"`
+	NamePrefix     types.String   `tfsdk:"name_prefix"`
+	Search         types.String   `tfsdk:"search"`
+	PageSize       types.Int64    `tfsdk:"page_size"`
+	MaxResults     types.Int64    `tfsdk:"max_results"`
+	Ids            []int64        `tfsdk:"ids"`
+	Projects       []projectModel `tfsdk:"projects"`
+}
+
+// projectModel maps a project.
+type projectModel struct {
+	Id             types.Int64  `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	OrganizationId types.Int64  `tfsdk:"organization_id"`
+	ScmType        types.String `tfsdk:"scm_type"`
+	ScmUrl         types.String `tfsdk:"scm_url"`
+	ScmBranch      types.String `tfsdk:"scm_branch"`
+	Status         types.String `tfsdk:"status"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *projectsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_projects", "read", &resp.Diagnostics)
+	var state projectsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := listQuery(state.PageSize, map[string]attr.Value{
+		"organization":     state.OrganizationId,
+		"name__startswith": state.NamePrefix,
+		"search":           state.Search,
+	})
+	projects, err := d.client.GetProjects(query, int(state.MaxResults.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read projects", err.Error())
+		return
+	}
+
+	state.Ids = make([]int64, len(projects))
+	state.Projects = make([]projectModel, len(projects))
+	for i, project := range projects {
+		state.Ids[i] = project.Id
+		state.Projects[i] = projectModel{
+			Id:             types.Int64Value(project.Id),
+			Name:           types.StringValue(project.Name),
+			Description:    types.StringValue(project.Description),
+			OrganizationId: types.Int64PointerValue(project.Organization),
+			ScmType:        types.StringValue(project.ScmType),
+			ScmUrl:         types.StringValue(project.ScmUrl),
+			ScmBranch:      types.StringValue(project.ScmBranch),
+			Status:         types.StringValue(project.Status),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *projectsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
@@ -0,0 +1,35 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestProjectsDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	defaultOrganization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	salesOrganization := mock.addObject("api/v2/organizations", map[string]any{"name": "Sales"})
+	playbooks := mock.addObject("api/v2/projects", map[string]any{
+		"name": "Playbooks", "description": "Deployment playbooks", "organization": defaultOrganization,
+		"scm_type": "git", "scm_url": "https://github.com/example/playbooks.git", "scm_branch": "main",
+	})
+	manual := mock.addObject("api/v2/projects", map[string]any{"name": "Manual", "organization": defaultOrganization})
+	reports := mock.addObject("api/v2/projects", map[string]any{"name": "Reports", "organization": salesOrganization, "status": "failed"})
+
+	p := newTestProvider(t, mock, nil)
+	state := p.readDataSource("aap_projects", nil)
+	testExpect(t, state, map[string]any{"ids": []any{manual, playbooks, reports}})
+	if projects, _ := state["projects"].([]any); len(projects) != 3 {
+		t.Fatalf("projects = %v, expected every project", state["projects"])
+	} else {
+		testExpect(t, projects[1].(map[string]any), map[string]any{
+			"id": playbooks, "name": "Playbooks", "description": "Deployment playbooks", "organization_id": defaultOrganization,
+			"scm_type": "git", "scm_url": "https://github.com/example/playbooks.git", "scm_branch": "main", "status": "successful",
+		})
+		testExpect(t, projects[2].(map[string]any), map[string]any{"organization_id": salesOrganization, "scm_type": "", "status": "failed"})
+	}
+
+	testExpect(t, p.readDataSource("aap_projects", map[string]any{"organization_id": defaultOrganization}), map[string]any{"ids": []any{manual, playbooks}})
+	testExpect(t, p.readDataSource("aap_projects", map[string]any{"name_prefix": "Re"}), map[string]any{"ids": []any{reports}})
+	testExpect(t, p.readDataSource("aap_projects", map[string]any{"search": "deployment"}), map[string]any{"ids": []any{playbooks}})
+	testExpect(t, p.readDataSource("aap_projects", map[string]any{"page_size": 1, "max_results": 2}), map[string]any{"ids": []any{manual, playbooks}})
+}
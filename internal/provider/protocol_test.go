@@ -0,0 +1,560 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testProvider drives the provider through the plugin protocol like Terraform does, so that resources and data
+// sources can be created, read and destroyed against the mock AAP server without the Terraform CLI.
+type testProvider struct {
+	t      *testing.T
+	server tfprotov6.ProviderServer
+	schema *tfprotov6.GetProviderSchemaResponse
+	// dataSourceWarnings are the summaries of the warnings of the last data source read
+	dataSourceWarnings []string
+}
+
+// newTestProvider configures the provider against the mock AAP server with the given extra provider settings.
+func newTestProvider(t *testing.T, mock *mockAAP, settings map[string]any) *testProvider {
+	t.Helper()
+
+	p := &testProvider{t: t, server: providerserver.NewProtocol6(New("test")())()}
+	var err error
+	p.schema, err = p.server.GetProviderSchema(context.Background(), &tfprotov6.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.failOnErrors("GetProviderSchema", p.schema.Diagnostics)
+
+	config := map[string]any{"host": mock.URL(), "username": mockUsername, "password": "password"}
+	for key, value := range settings {
+		config[key] = value
+	}
+	resp, err := p.server.ConfigureProvider(context.Background(), &tfprotov6.ConfigureProviderRequest{
+		TerraformVersion: "1.8.0",
+		Config:           p.dynamicValue(p.schema.Provider, config),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.failOnErrors("ConfigureProvider", resp.Diagnostics)
+	return p
+}
+
+// failOnErrors fails the test when the diagnostics of a call hold errors.
+func (p *testProvider) failOnErrors(call string, diagnostics []*tfprotov6.Diagnostic) {
+	p.t.Helper()
+	if message := testErrors(diagnostics); message != "" {
+		p.t.Fatalf("%s: %s", call, message)
+	}
+}
+
+// testErrors returns the summaries and details of the error diagnostics, empty when there are none.
+func testErrors(diagnostics []*tfprotov6.Diagnostic) string {
+	var errors []string
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityError {
+			errors = append(errors, diagnostic.Summary+": "+diagnostic.Detail)
+		}
+	}
+	return strings.Join(errors, "; ")
+}
+
+// dynamicValue encodes the attributes as a value of the schema, attributes left out being null.
+func (p *testProvider) dynamicValue(schema *tfprotov6.Schema, attributes map[string]any) *tfprotov6.DynamicValue {
+	p.t.Helper()
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	return p.encode(schema, testValue(p.t, schema.ValueType(), attributes))
+}
+
+func (p *testProvider) encode(schema *tfprotov6.Schema, value tftypes.Value) *tfprotov6.DynamicValue {
+	p.t.Helper()
+	dynamicValue, err := tfprotov6.NewDynamicValue(schema.ValueType(), value)
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	return &dynamicValue
+}
+
+func (p *testProvider) decode(schema *tfprotov6.Schema, dynamicValue *tfprotov6.DynamicValue) tftypes.Value {
+	p.t.Helper()
+	if dynamicValue == nil {
+		return tftypes.NewValue(schema.ValueType(), nil)
+	}
+	value, err := dynamicValue.Unmarshal(schema.ValueType())
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	return value
+}
+
+// readDataSource reads the data source with the given configuration and returns its state.
+func (p *testProvider) readDataSource(typeName string, config map[string]any) map[string]any {
+	p.t.Helper()
+	state, message := p.tryReadDataSource(typeName, config)
+	if message != "" {
+		p.t.Fatalf("read %s: %s", typeName, message)
+	}
+	return state
+}
+
+// tryReadDataSource reads the data source and returns its state, or the errors of validating or reading it.
+func (p *testProvider) tryReadDataSource(typeName string, config map[string]any) (map[string]any, string) {
+	p.t.Helper()
+	schema, ok := p.schema.DataSourceSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("unknown data source %s", typeName)
+	}
+	encoded := p.dynamicValue(schema, config)
+
+	validated, err := p.server.ValidateDataResourceConfig(context.Background(), &tfprotov6.ValidateDataResourceConfigRequest{TypeName: typeName, Config: encoded})
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	if message := testErrors(validated.Diagnostics); message != "" {
+		return nil, message
+	}
+	read, err := p.server.ReadDataSource(context.Background(), &tfprotov6.ReadDataSourceRequest{TypeName: typeName, Config: encoded})
+	if err != nil {
+		p.t.Fatal(err)
+	}
+	p.dataSourceWarnings = testWarnings(read.Diagnostics)
+	if message := testErrors(read.Diagnostics); message != "" {
+		return nil, message
+	}
+	return testGoValue(p.decode(schema, read.State)).(map[string]any), ""
+}
+
+// testResource is a resource managed through the plugin protocol, holding its state between operations.
+type testResource struct {
+	p        *testProvider
+	typeName string
+	schema   *tfprotov6.Schema
+	state    tftypes.Value
+	private  []byte
+	// warnings are the summaries of the warnings of the last plan or operation
+	warnings []string
+}
+
+// resource returns a resource of the given type that does not exist yet.
+func (p *testProvider) resource(typeName string) *testResource {
+	p.t.Helper()
+	schema, ok := p.schema.ResourceSchemas[typeName]
+	if !ok {
+		p.t.Fatalf("unknown resource %s", typeName)
+	}
+	return &testResource{p: p, typeName: typeName, schema: schema, state: tftypes.NewValue(schema.ValueType(), nil)}
+}
+
+// State returns the attributes of the resource in the Terraform state, nil when it does not exist.
+func (r *testResource) State() map[string]any {
+	if r.state.IsNull() {
+		return nil
+	}
+	return testGoValue(r.state).(map[string]any)
+}
+
+// apply creates or updates the resource to match the configuration and returns its new state.
+func (r *testResource) apply(config map[string]any) map[string]any {
+	r.p.t.Helper()
+	if message := r.tryApply(config); message != "" {
+		r.p.t.Fatalf("apply %s: %s", r.typeName, message)
+	}
+	return r.State()
+}
+
+// tryApply plans and applies the configuration like terraform apply and returns the errors, empty on success.
+// A change requiring the replacement of the resource destroys it before creating it again.
+func (r *testResource) tryApply(config map[string]any) string {
+	r.p.t.Helper()
+	configValue := testValue(r.p.t, r.schema.ValueType(), config)
+	encodedConfig := r.p.encode(r.schema, configValue)
+
+	validated, err := r.p.server.ValidateResourceConfig(context.Background(), &tfprotov6.ValidateResourceConfigRequest{TypeName: r.typeName, Config: encodedConfig})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	if message := testErrors(validated.Diagnostics); message != "" {
+		return message
+	}
+
+	planned, requiresReplace, message := r.plan(configValue)
+	if message != "" {
+		return message
+	}
+	if len(requiresReplace) > 0 && !r.state.IsNull() {
+		if message := r.tryDestroy(); message != "" {
+			return message
+		}
+		if planned, _, message = r.plan(configValue); message != "" {
+			return message
+		}
+	}
+
+	applied, err := r.p.server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     r.p.encode(r.schema, r.state),
+		PlannedState:   r.p.encode(r.schema, planned),
+		Config:         encodedConfig,
+		PlannedPrivate: r.private,
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	r.warnings = testWarnings(applied.Diagnostics)
+	if message := testErrors(applied.Diagnostics); message != "" {
+		return message
+	}
+	newState := r.p.decode(r.schema, applied.NewState)
+	if !newState.IsFullyKnown() {
+		r.p.t.Fatalf("apply %s: the new state holds unknown values: %s", r.typeName, newState)
+	}
+	// Terraform rejects applies producing values other than the known planned ones
+	if inconsistent := testInconsistencies(planned, newState); len(inconsistent) > 0 {
+		r.p.t.Fatalf("apply %s: provider produced inconsistent result: %s", r.typeName, strings.Join(inconsistent, ", "))
+	}
+	r.state, r.private = newState, applied.Private
+	return ""
+}
+
+// plan plans the configuration against the current state and returns the planned state with the paths of the
+// attributes requiring the replacement of the resource, or the errors of the plan.
+func (r *testResource) plan(configValue tftypes.Value) (tftypes.Value, []*tftypes.AttributePath, string) {
+	r.p.t.Helper()
+	proposed := testProposedNewState(r.schema.Block, r.state, configValue)
+	planned, err := r.p.server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       r.p.encode(r.schema, r.state),
+		ProposedNewState: r.p.encode(r.schema, proposed),
+		Config:           r.p.encode(r.schema, configValue),
+		PriorPrivate:     r.private,
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	r.warnings = testWarnings(planned.Diagnostics)
+	if message := testErrors(planned.Diagnostics); message != "" {
+		return tftypes.Value{}, nil, message
+	}
+	return r.p.decode(r.schema, planned.PlannedState), planned.RequiresReplace, ""
+}
+
+// planChanges returns the attributes the configuration would change, empty when the plan is empty.
+func (r *testResource) planChanges(config map[string]any) []string {
+	r.p.t.Helper()
+	planned, _, message := r.plan(testValue(r.p.t, r.schema.ValueType(), config))
+	if message != "" {
+		r.p.t.Fatalf("plan %s: %s", r.typeName, message)
+	}
+	return testInconsistencies(r.state, planned)
+}
+
+// read refreshes the state of the resource like terraform refresh and returns it, nil when the resource is gone.
+func (r *testResource) read() map[string]any {
+	r.p.t.Helper()
+	read, err := r.p.server.ReadResource(context.Background(), &tfprotov6.ReadResourceRequest{
+		TypeName:     r.typeName,
+		CurrentState: r.p.encode(r.schema, r.state),
+		Private:      r.private,
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	r.warnings = testWarnings(read.Diagnostics)
+	r.p.failOnErrors("read "+r.typeName, read.Diagnostics)
+	r.state, r.private = r.p.decode(r.schema, read.NewState), read.Private
+	return r.State()
+}
+
+// importState imports the resource with the given id and reads it like terraform import, returning its state.
+func (r *testResource) importState(id string) map[string]any {
+	r.p.t.Helper()
+	if message := r.tryImportState(id); message != "" {
+		r.p.t.Fatalf("import %s: %s", r.typeName, message)
+	}
+	return r.read()
+}
+
+// tryImportState imports the resource with the given id and returns the errors, empty on success.
+func (r *testResource) tryImportState(id string) string {
+	r.p.t.Helper()
+	imported, err := r.p.server.ImportResourceState(context.Background(), &tfprotov6.ImportResourceStateRequest{TypeName: r.typeName, ID: id})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	if message := testErrors(imported.Diagnostics); message != "" {
+		return message
+	}
+	if len(imported.ImportedResources) != 1 {
+		r.p.t.Fatalf("import %s returned %d resources", r.typeName, len(imported.ImportedResources))
+	}
+	r.state = r.p.decode(r.schema, imported.ImportedResources[0].State)
+	r.private = imported.ImportedResources[0].Private
+	return ""
+}
+
+// destroy destroys the resource like terraform destroy.
+func (r *testResource) destroy() {
+	r.p.t.Helper()
+	if message := r.tryDestroy(); message != "" {
+		r.p.t.Fatalf("destroy %s: %s", r.typeName, message)
+	}
+}
+
+func (r *testResource) tryDestroy() string {
+	r.p.t.Helper()
+	null := r.p.encode(r.schema, tftypes.NewValue(r.schema.ValueType(), nil))
+	planned, err := r.p.server.PlanResourceChange(context.Background(), &tfprotov6.PlanResourceChangeRequest{
+		TypeName:         r.typeName,
+		PriorState:       r.p.encode(r.schema, r.state),
+		ProposedNewState: null,
+		Config:           null,
+		PriorPrivate:     r.private,
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	if message := testErrors(planned.Diagnostics); message != "" {
+		return message
+	}
+	applied, err := r.p.server.ApplyResourceChange(context.Background(), &tfprotov6.ApplyResourceChangeRequest{
+		TypeName:       r.typeName,
+		PriorState:     r.p.encode(r.schema, r.state),
+		PlannedState:   null,
+		Config:         null,
+		PlannedPrivate: planned.PlannedPrivate,
+	})
+	if err != nil {
+		r.p.t.Fatal(err)
+	}
+	r.warnings = testWarnings(applied.Diagnostics)
+	if message := testErrors(applied.Diagnostics); message != "" {
+		return message
+	}
+	r.state, r.private = tftypes.NewValue(r.schema.ValueType(), nil), nil
+	return ""
+}
+
+// testWarnings returns the summaries and details of the warning diagnostics.
+func testWarnings(diagnostics []*tfprotov6.Diagnostic) []string {
+	var warnings []string
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == tfprotov6.DiagnosticSeverityWarning {
+			warnings = append(warnings, diagnostic.Summary+": "+diagnostic.Detail)
+		}
+	}
+	return warnings
+}
+
+// testProposedNewState merges the configuration with the prior state like Terraform does before planning: computed
+// attributes left out of the configuration keep their prior value.
+func testProposedNewState(block *tfprotov6.SchemaBlock, prior tftypes.Value, config tftypes.Value) tftypes.Value {
+	if prior.IsNull() || config.IsNull() || !config.IsKnown() {
+		return config
+	}
+	var priorAttributes, configAttributes map[string]tftypes.Value
+	if prior.As(&priorAttributes) != nil || config.As(&configAttributes) != nil {
+		return config
+	}
+
+	proposed := make(map[string]tftypes.Value, len(configAttributes))
+	for name, value := range configAttributes {
+		proposed[name] = value
+	}
+	for _, attribute := range block.Attributes {
+		configValue := configAttributes[attribute.Name]
+		switch {
+		case attribute.Computed && configValue.IsNull():
+			proposed[attribute.Name] = priorAttributes[attribute.Name]
+		case attribute.NestedType != nil && attribute.NestedType.Nesting == tfprotov6.SchemaObjectNestingModeSingle:
+			nested := &tfprotov6.SchemaBlock{Attributes: attribute.NestedType.Attributes}
+			proposed[attribute.Name] = testProposedNewState(nested, priorAttributes[attribute.Name], configValue)
+		}
+	}
+	return tftypes.NewValue(config.Type(), proposed)
+}
+
+// testInconsistencies returns the outermost paths where the new value differs from a known expected value.
+func testInconsistencies(expected tftypes.Value, actual tftypes.Value) []string {
+	diffs, err := expected.Diff(actual)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	differing := make(map[string]bool)
+	for _, diff := range diffs {
+		if diff.Value1 != nil && diff.Value1.IsFullyKnown() && len(diff.Path.Steps()) > 0 {
+			differing[diff.Path.String()] = true
+		}
+	}
+	var paths []string
+	for _, diff := range diffs {
+		path := diff.Path.String()
+		if !differing[path] {
+			continue
+		}
+		differing[path] = false
+		outermost := true
+		for parent := diff.Path.WithoutLastStep(); parent != nil && len(parent.Steps()) > 0; parent = parent.WithoutLastStep() {
+			if _, ok := differing[parent.String()]; ok {
+				outermost = false
+			}
+		}
+		if outermost {
+			paths = append(paths, path)
+		}
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+// testValue converts a Go value, e.g. map[string]any{"name": "web", "count": 2}, into a value of the given type.
+// Attributes left out of objects are null; testUnknown stands for a value known after apply.
+func testValue(t *testing.T, typ tftypes.Type, value any) tftypes.Value {
+	t.Helper()
+	if value == nil {
+		return tftypes.NewValue(typ, nil)
+	}
+	if value == testUnknown {
+		return tftypes.NewValue(typ, tftypes.UnknownValue)
+	}
+
+	switch {
+	case typ.Is(tftypes.String):
+		return tftypes.NewValue(typ, value)
+	case typ.Is(tftypes.Bool):
+		return tftypes.NewValue(typ, value)
+	case typ.Is(tftypes.Number):
+		switch v := value.(type) {
+		case int:
+			return tftypes.NewValue(typ, big.NewFloat(float64(v)))
+		case int64:
+			return tftypes.NewValue(typ, new(big.Float).SetInt64(v))
+		case float64:
+			return tftypes.NewValue(typ, big.NewFloat(v))
+		}
+	case typ.Is(tftypes.List{}) || typ.Is(tftypes.Set{}):
+		var elementType tftypes.Type
+		if list, ok := typ.(tftypes.List); ok {
+			elementType = list.ElementType
+		} else {
+			elementType = typ.(tftypes.Set).ElementType
+		}
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Slice {
+			break
+		}
+		elements := make([]tftypes.Value, items.Len())
+		for i := range elements {
+			elements[i] = testValue(t, elementType, items.Index(i).Interface())
+		}
+		return tftypes.NewValue(typ, elements)
+	case typ.Is(tftypes.Map{}):
+		items := reflect.ValueOf(value)
+		if items.Kind() != reflect.Map {
+			break
+		}
+		elements := make(map[string]tftypes.Value, items.Len())
+		for _, key := range items.MapKeys() {
+			elements[key.String()] = testValue(t, typ.(tftypes.Map).ElementType, items.MapIndex(key).Interface())
+		}
+		return tftypes.NewValue(typ, elements)
+	case typ.Is(tftypes.Object{}):
+		attributes, ok := value.(map[string]any)
+		if !ok {
+			break
+		}
+		objectType := typ.(tftypes.Object)
+		for name := range attributes {
+			if _, ok := objectType.AttributeTypes[name]; !ok {
+				t.Fatalf("unsupported attribute %s", name)
+			}
+		}
+		values := make(map[string]tftypes.Value, len(objectType.AttributeTypes))
+		for name, attributeType := range objectType.AttributeTypes {
+			values[name] = testValue(t, attributeType, attributes[name])
+		}
+		return tftypes.NewValue(typ, values)
+	}
+	t.Fatalf("cannot convert %#v to %s", value, typ)
+	return tftypes.Value{}
+}
+
+// testUnknown stands for a value known after apply in testValue.
+var testUnknown = &struct{ unknown bool }{true}
+
+// testGoValue converts a value into Go values: strings, int64 or float64 numbers, booleans, []any and map[string]any.
+func testGoValue(value tftypes.Value) any {
+	if value.IsNull() {
+		return nil
+	}
+	if !value.IsKnown() {
+		return testUnknown
+	}
+
+	typ := value.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		_ = value.As(&s)
+		return s
+	case typ.Is(tftypes.Bool):
+		var b bool
+		_ = value.As(&b)
+		return b
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		_ = value.As(&n)
+		if n.IsInt() {
+			i, _ := n.Int64()
+			return i
+		}
+		f, _ := n.Float64()
+		return f
+	case typ.Is(tftypes.List{}) || typ.Is(tftypes.Set{}) || typ.Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		_ = value.As(&elements)
+		items := make([]any, len(elements))
+		for i, element := range elements {
+			items[i] = testGoValue(element)
+		}
+		return items
+	default:
+		var elements map[string]tftypes.Value
+		_ = value.As(&elements)
+		items := make(map[string]any, len(elements))
+		for key, element := range elements {
+			items[key] = testGoValue(element)
+		}
+		return items
+	}
+}
+
+// testExpect fails the test when the attributes of a state differ from the expected ones, e.g.
+// testExpect(t, state, map[string]any{"name": "web", "node_ids": map[string]any{"a": int64(3)}}).
+func testExpect(t *testing.T, state map[string]any, expected map[string]any) {
+	t.Helper()
+	for _, name := range sortedKeys(expected) {
+		if !reflect.DeepEqual(state[name], expected[name]) {
+			t.Errorf("%s = %s, expected %s", name, testFormat(state[name]), testFormat(expected[name]))
+		}
+	}
+}
+
+func testFormat(value any) string {
+	if value == testUnknown {
+		return "(known after apply)"
+	}
+	return fmt.Sprintf("%#v", value)
+}
@@ -2,20 +2,32 @@ package provider
 
 import (
 	"context"
+	"crypto/x509"
+	"fmt"
+	"net/url"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ provider.Provider = &aapProvider{}
+	_ provider.Provider              = &aapProvider{}
+	_ provider.ProviderWithFunctions = &aapProvider{}
 )
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -46,17 +58,86 @@ func (p *aapProvider) Schema(_ context.Context, _ provider.SchemaRequest, resp *
 	resp.Schema = schema.Schema{
 		Attributes: map[string]schema.Attribute{
 			"host": schema.StringAttribute{
-				Required: true,
+				Optional:    true,
+				Description: "URL of AAP, e.g. https://aap.example.com. May also be set with the AAP_HOST environment variable.",
+				Validators:  urlValidators("http", "https"),
 			},
 			"username": schema.StringAttribute{
-				Optional: true,
+				Optional:    true,
+				Description: "Username to authenticate with. May also be set with the AAP_USERNAME environment variable.",
 			},
 			"password": schema.StringAttribute{
+				Optional:    true,
+				Sensitive:   true,
+				Description: "Password to authenticate with. May also be set with the AAP_PASSWORD environment variable.",
+			},
+			"token": schema.StringAttribute{
 				Optional:  true,
 				Sensitive: true,
+				Description: "OAuth2 personal access token sent as a bearer token instead of the username and password, " +
+					"which are then not required. May also be set with the AAP_TOKEN environment variable.",
 			},
 			"insecure_skip_verify": schema.BoolAttribute{
 				Optional: true,
+				Description: "Whether to skip the verification of the TLS certificate of AAP. Defaults to false. " +
+					"The certificates of the remote Terraform state sources of aap_state_inventory are always verified. " +
+					"May also be set with the AAP_INSECURE_SKIP_VERIFY environment variable.",
+			},
+			"ca_cert_file": schema.StringAttribute{
+				Optional: true,
+				Description: "Path of a PEM file with the certificates of the authorities the TLS certificates of AAP and of the remote " +
+					"Terraform state sources of aap_state_inventory are verified against, " +
+					"in addition to the system ones, e.g. for an internal CA. Conflicts with ca_cert_pem. " +
+					"May also be set with the AAP_CA_CERT_FILE environment variable.",
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Optional: true,
+				Description: "PEM encoded certificates of the authorities the TLS certificates of AAP and of the remote " +
+					"Terraform state sources of aap_state_inventory are verified against, " +
+					"in addition to the system ones. Conflicts with ca_cert_file. May also be set with the AAP_CA_CERT_PEM environment variable.",
+			},
+			"proxy_url": schema.StringAttribute{
+				Optional: true,
+				Description: "URL of the proxy requests to AAP and to the remote Terraform state sources of aap_state_inventory " +
+					"are sent through, e.g. http://proxy.example.com:3128. " +
+					"May also be set with the AAP_PROXY_URL environment variable. Defaults to the proxy set with the " +
+					"HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables, if any.",
+				Validators: urlValidators("http", "https", "socks5"),
+			},
+			"parallelism": schema.Int64Attribute{
+				Optional: true,
+				Description: fmt.Sprintf("Maximum number of requests sent to AAP at once when a resource reads or changes many "+
+					"related objects, e.g. the hosts and groups of aap_state_inventory. At least 1, defaults to %d. "+
+					"May also be set with the AAP_PARALLELISM environment variable.", defaultParallelism),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
+			"poll_interval": schema.StringAttribute{
+				Optional: true,
+				Description: "Delay between two reads of a running task, e.g. a repository sync. Defaults to 2s; " +
+					"each delay varies by up to 20% so that concurrent applies spread their requests. " +
+					"May also be set with the AAP_POLL_INTERVAL environment variable.",
+				Validators: durationValidators(),
+			},
+			"check_existing_names": schema.BoolAttribute{
+				Optional: true,
+				Description: "Look for an object with the same name before creating inventories and EDA projects, " +
+					"and fail with the id to import it with when there is one. " +
+					"May also be set with the AAP_CHECK_EXISTING_NAMES environment variable.",
+			},
+			"report_api_usage": schema.BoolAttribute{
+				Optional: true,
+				Description: "Report the number of requests sent to AAP and the time spent waiting for them at the end of each operation " +
+					"of a resource or data source as a warning. The same summary is always logged at the INFO level, e.g. with TF_LOG=INFO. " +
+					"May also be set with the AAP_REPORT_API_USAGE environment variable.",
+			},
+			"health_check": schema.StringAttribute{
+				Optional: true,
+				Description: "Check the execution capacity of the controller when the provider is configured: " +
+					"off (the default), warn to report execution nodes without capacity, or fail to stop the run, " +
+					"rather than launching jobs that stay pending. May also be set with the AAP_HEALTH_CHECK environment variable.",
+				Validators: []validator.String{stringvalidator.OneOf("off", "warn", "fail")},
 			},
 		},
 	}
@@ -101,6 +182,15 @@ func (p *aapProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		)
 	}
 
+	if config.Token.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("token"),
+			"Unknown AAP API Token",
+			"The provider cannot create the AAP API client as there is an unknown configuration value for the AAP API token. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the AAP_TOKEN environment variable.",
+		)
+	}
+
 	if config.InsecureSkipVerify.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("insecure_skip_verify"),
@@ -110,6 +200,33 @@ func (p *aapProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		)
 	}
 
+	if config.CACertFile.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_file"),
+			"Unknown AAP API ca_cert_file",
+			"The provider cannot create the AAP API client as there is an unknown configuration value for the AAP API ca_cert_file. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the AAP_CA_CERT_FILE environment variable.",
+		)
+	}
+
+	if config.CACertPEM.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Unknown AAP API ca_cert_pem",
+			"The provider cannot create the AAP API client as there is an unknown configuration value for the AAP API ca_cert_pem. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the AAP_CA_CERT_PEM environment variable.",
+		)
+	}
+
+	if config.ProxyURL.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("proxy_url"),
+			"Unknown AAP API proxy_url",
+			"The provider cannot create the AAP API client as there is an unknown configuration value for the AAP API proxy_url. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the AAP_PROXY_URL environment variable.",
+		)
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -120,6 +237,10 @@ func (p *aapProvider) Configure(ctx context.Context, req provider.ConfigureReque
 	host := os.Getenv("AAP_HOST")
 	username := os.Getenv("AAP_USERNAME")
 	password := os.Getenv("AAP_PASSWORD")
+	token := os.Getenv("AAP_TOKEN")
+	ca_cert_file := os.Getenv("AAP_CA_CERT_FILE")
+	ca_cert_pem := os.Getenv("AAP_CA_CERT_PEM")
+	proxy_url := os.Getenv("AAP_PROXY_URL")
 	var insecure_skip_verify bool = false
 	var err error
 	raw_insecure_skip_verify := os.Getenv("AAP_INSECURE_SKIP_VERIFY")
@@ -135,6 +256,76 @@ func (p *aapProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		}
 	}
 
+	var parallelism int64 = defaultParallelism
+	raw_parallelism := os.Getenv("AAP_PARALLELISM")
+	if raw_parallelism != "" {
+		parallelism, err = strconv.ParseInt(raw_parallelism, 10, 64)
+		if err != nil || parallelism < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("parallelism"),
+				"Invalid value for parallelism",
+				"The provider cannot create the AAP API client as the value provided for parallelism is not a positive integer.",
+			)
+			return
+		}
+	}
+
+	poll_interval := defaultPollInterval
+	raw_poll_interval := os.Getenv("AAP_POLL_INTERVAL")
+	if raw_poll_interval != "" {
+		poll_interval, err = time.ParseDuration(raw_poll_interval)
+		if err != nil || poll_interval <= 0 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("poll_interval"),
+				"Invalid value for poll_interval",
+				"The provider cannot create the AAP API client as the value provided for poll_interval is not a positive duration.",
+			)
+			return
+		}
+	}
+
+	var check_existing_names bool = false
+	raw_check_existing_names := os.Getenv("AAP_CHECK_EXISTING_NAMES")
+	if raw_check_existing_names != "" {
+		check_existing_names, err = strconv.ParseBool(raw_check_existing_names)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("check_existing_names"),
+				"Invalid value for check_existing_names",
+				"The provider cannot create the AAP API client as the value provided for check_existing_names is not a valid boolean.",
+			)
+			return
+		}
+	}
+
+	var report_api_usage bool = false
+	raw_report_api_usage := os.Getenv("AAP_REPORT_API_USAGE")
+	if raw_report_api_usage != "" {
+		report_api_usage, err = strconv.ParseBool(raw_report_api_usage)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("report_api_usage"),
+				"Invalid value for report_api_usage",
+				"The provider cannot create the AAP API client as the value provided for report_api_usage is not a valid boolean.",
+			)
+			return
+		}
+	}
+
+	health_check := "off"
+	raw_health_check := os.Getenv("AAP_HEALTH_CHECK")
+	if raw_health_check != "" {
+		if !slices.Contains([]string{"off", "warn", "fail"}, raw_health_check) {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("health_check"),
+				"Invalid value for health_check",
+				"The provider cannot create the AAP API client as the value provided for health_check is not one of off, warn or fail.",
+			)
+			return
+		}
+		health_check = raw_health_check
+	}
+
 	if !config.Host.IsNull() {
 		host = config.Host.ValueString()
 	}
@@ -147,10 +338,47 @@ func (p *aapProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		password = config.Password.ValueString()
 	}
 
+	if !config.Token.IsNull() {
+		token = config.Token.ValueString()
+	}
+
+	if !config.CACertFile.IsNull() {
+		ca_cert_file = config.CACertFile.ValueString()
+	}
+
+	if !config.CACertPEM.IsNull() {
+		ca_cert_pem = config.CACertPEM.ValueString()
+	}
+
+	if !config.ProxyURL.IsNull() {
+		proxy_url = config.ProxyURL.ValueString()
+	}
+
 	if !config.InsecureSkipVerify.IsNull() {
 		insecure_skip_verify = config.InsecureSkipVerify.ValueBool()
 	}
 
+	if !config.Parallelism.IsNull() {
+		parallelism = config.Parallelism.ValueInt64()
+	}
+
+	if !config.PollInterval.IsNull() {
+		// validated by the schema
+		poll_interval, _ = time.ParseDuration(config.PollInterval.ValueString())
+	}
+
+	if !config.CheckExistingNames.IsNull() {
+		check_existing_names = config.CheckExistingNames.ValueBool()
+	}
+
+	if !config.ReportAPIUsage.IsNull() {
+		report_api_usage = config.ReportAPIUsage.ValueBool()
+	}
+
+	if !config.HealthCheck.IsNull() {
+		health_check = config.HealthCheck.ValueString()
+	}
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -162,28 +390,86 @@ func (p *aapProvider) Configure(ctx context.Context, req provider.ConfigureReque
 				"Set the host value in the configuration or use the AAP_HOST environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
+	} else if hostURL, err := url.Parse(host); err != nil || (hostURL.Scheme != "http" && hostURL.Scheme != "https") || hostURL.Host == "" {
+		// values from the environment are not checked by the schema validators
+		resp.Diagnostics.AddAttributeError(
+			path.Root("host"),
+			"Invalid AAP API Host",
+			fmt.Sprintf("The provider cannot create the AAP API client as the AAP API host %q is not an http or https URL.", host),
+		)
 	}
 
-	if username == "" {
+	// a token replaces the username and password
+	if username == "" && token == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("username"),
 			"Missing AAP API Username",
 			"The provider cannot create the AAP API client as there is a missing or empty value for the AAP API username. "+
-				"Set the username value in the configuration or use the AAP_USERNAME environment variable. "+
+				"Set the username value in the configuration or use the AAP_USERNAME environment variable, or authenticate with a token instead. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
-	if password == "" {
+	if password == "" && token == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("password"),
 			"Missing AAP API Password",
 			"The provider cannot create the AAP API client as there is a missing or empty value for the AAP API password. "+
-				"Set the password value in the configuration or use the AAP_PASSWORD environment variable. "+
+				"Set the password value in the configuration or use the AAP_PASSWORD environment variable, or authenticate with a token instead. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
+	var proxy *url.URL
+	if proxy_url != "" {
+		proxy, err = url.Parse(proxy_url)
+		// values from the environment are not checked by the schema validators
+		if err != nil || !slices.Contains([]string{"http", "https", "socks5"}, proxy.Scheme) || proxy.Host == "" {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid AAP API proxy_url",
+				fmt.Sprintf("The provider cannot create the AAP API client as the proxy URL %q is not an http, https or socks5 URL.", proxy_url),
+			)
+		}
+	}
+
+	if ca_cert_file != "" && ca_cert_pem != "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("ca_cert_pem"),
+			"Conflicting AAP API CA certificates",
+			"The provider cannot create the AAP API client as both ca_cert_file and ca_cert_pem are set. "+
+				"Set only one of them, in the configuration or with the AAP_CA_CERT_FILE and AAP_CA_CERT_PEM environment variables.",
+		)
+	}
+
+	var root_cas *x509.CertPool
+	if ca_cert_file != "" {
+		pem, err := os.ReadFile(ca_cert_file)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("ca_cert_file"),
+				"Unable to read AAP API CA certificates",
+				"The provider cannot create the AAP API client as the file provided for ca_cert_file cannot be read: "+err.Error(),
+			)
+		} else {
+			ca_cert_pem = string(pem)
+		}
+	}
+	if ca_cert_pem != "" && !resp.Diagnostics.HasError() {
+		root_cas, err = NewCertPool([]byte(ca_cert_pem))
+		if err != nil {
+			attribute := "ca_cert_pem"
+			if ca_cert_file != "" {
+				attribute = "ca_cert_file"
+			}
+			resp.Diagnostics.AddAttributeError(
+				path.Root(attribute),
+				"Invalid AAP API CA certificates",
+				"The provider cannot create the AAP API client as the value provided for "+attribute+" holds no valid certificate: "+err.Error(),
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
@@ -200,6 +486,23 @@ func (p *aapProvider) Configure(ctx context.Context, req provider.ConfigureReque
 		return
 	}
 
+	if token != "" {
+		client.Token = &token
+	}
+	client.RootCAs = root_cas
+	client.Proxy = proxy
+	client.Parallelism = int(parallelism)
+	client.PollInterval = poll_interval
+	client.CheckExistingNames = check_existing_names
+	client.ReportAPIUsage = report_api_usage
+
+	if health_check != "off" {
+		resp.Diagnostics.Append(checkControllerHealth(client, health_check == "fail")...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
 	// Make the http client available during DataSource and Resource
 	// type Configure methods.
 	resp.DataSourceData = client
@@ -210,12 +513,65 @@ func (p *aapProvider) Configure(ctx context.Context, req provider.ConfigureReque
 func (p *aapProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return []func() datasource.DataSource{
 		NewInventoryDataSource,
+		NewEDARulebookDataSource,
+		NewEDADecisionEnvironmentDataSource,
+		NewHostMetricsDataSource,
+		NewGatewayTokensDataSource,
+		NewInventorySourcesDataSource,
+		NewOrganizationsDataSource,
+		NewProjectsDataSource,
+		NewJobTemplatesDataSource,
+		NewUsersDataSource,
+		NewTeamsDataSource,
+		NewInstanceGroupsDataSource,
+		NewHostFilterDataSource,
+		NewMeshTopologyDataSource,
+		NewAWXExportDataSource,
+		NewSchedulePreviewDataSource,
 	}
 }
 
 // Resources defines the resources implemented in the provider.
 func (p *aapProvider) Resources(_ context.Context) []func() resource.Resource {
-	return nil
+	return []func() resource.Resource{
+		NewStateInventoryResource,
+		NewInstanceGroupResource,
+		NewExecutionEnvironmentResource,
+		NewJobTemplateInstanceGroupResource,
+		NewJobTemplateLabelResource,
+		NewWorkflowNodeLinksResource,
+		NewWorkflowNodesResource,
+		NewAWXImportResource,
+		NewTemplateCopyResource,
+		NewNotificationTemplateAssociationResource,
+		NewTeamRolesResource,
+		NewHostMetricsCleanupResource,
+		NewEDAProjectResource,
+		NewEDAEventStreamResource,
+		NewEDACredentialResource,
+		NewHubRemoteResource,
+		NewHubRepositoryResource,
+		NewHubCollectionApprovalResource,
+		NewHubGroupResource,
+		NewHubGroupRoleResource,
+		NewHubNamespaceGroupResource,
+		NewGatewayOrganizationResource,
+		NewGatewayTeamResource,
+		NewGatewayUserResource,
+		NewGatewayServiceAccountResource,
+		NewGatewayRoleDefinitionResource,
+		NewGatewayRoleUserAssignmentResource,
+		NewGatewayRoleTeamAssignmentResource,
+	}
+}
+
+// Functions defines the functions implemented in the provider.
+func (p *aapProvider) Functions(_ context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewToVarsFunction,
+		NewHostFilterFunction,
+		NewHostsDiffFunction,
+	}
 }
 
 // aapProviderModel maps provider schema data to a Go type.
@@ -223,5 +579,36 @@ type aapProviderModel struct {
 	Host               types.String `tfsdk:"host"`
 	Username           types.String `tfsdk:"username"`
 	Password           types.String `tfsdk:"password"`
+	Token              types.String `tfsdk:"token"`
+	CACertFile         types.String `tfsdk:"ca_cert_file"`
+	CACertPEM          types.String `tfsdk:"ca_cert_pem"`
+	ProxyURL           types.String `tfsdk:"proxy_url"`
 	InsecureSkipVerify types.Bool   `tfsdk:"insecure_skip_verify"`
+	Parallelism        types.Int64  `tfsdk:"parallelism"`
+	PollInterval       types.String `tfsdk:"poll_interval"`
+	CheckExistingNames types.Bool   `tfsdk:"check_existing_names"`
+	ReportAPIUsage     types.Bool   `tfsdk:"report_api_usage"`
+	HealthCheck        types.String `tfsdk:"health_check"`
+}
+
+// checkControllerHealth reports the execution nodes of the controller that have no capacity left,
+// as errors when failOnUnhealthy is set and as warnings otherwise.
+func checkControllerHealth(client *AAPClient, failOnUnhealthy bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	report := diags.AddWarning
+	if failOnUnhealthy {
+		report = diags.AddError
+	}
+
+	ping, err := client.Ping()
+	if err != nil {
+		report("Unable to check AAP controller health", err.Error())
+		return diags
+	}
+	if unavailable := ping.unavailableNodes(); len(unavailable) > 0 {
+		report("AAP controller nodes unavailable",
+			fmt.Sprintf("The execution nodes %s report no capacity, jobs launched on them stay pending until they recover. "+
+				"Set health_check to off to apply anyway.", strings.Join(unavailable, ", ")))
+	}
+	return diags
 }
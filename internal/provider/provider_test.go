@@ -0,0 +1,352 @@
+package provider
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// testAccPrefix prefixes the names of the objects created by acceptance tests.
+const testAccPrefix = "tf-acc-"
+
+// testAccProtoV6ProviderFactories are used to instantiate the provider during acceptance testing.
+var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
+	"aap": providerserver.NewProtocol6WithError(New("test")()),
+}
+
+// testAccAAP returns the provider configuration of an acceptance test and a client to inspect
+// the objects it creates. Tests run against the controller set in the AAP_HOST, AAP_USERNAME and
+// AAP_PASSWORD environment variables, or against an embedded mock AAP server when AAP_HOST is not
+// set. The mock is returned so that tests can seed it, it is nil when a real controller is used.
+func testAccAAP(t *testing.T) (string, *AAPClient, *mockAAP) {
+	t.Helper()
+
+	if client := testAccEnvClient(); client != nil {
+		return fmt.Sprintf("provider \"aap\" {\n  host = %q\n}\n", client.HostURL), client, nil
+	}
+
+	mock := newMockAAP(t)
+	username, password := "admin", "password"
+	client, _ := NewClient(mock.URL(), &username, &password, false)
+	config := fmt.Sprintf("provider \"aap\" {\n  host     = %q\n  username = %q\n  password = %q\n}\n", mock.URL(), username, password)
+	return config, client, mock
+}
+
+// testAccEnvClient returns a client for the controller set in the AAP_HOST, AAP_USERNAME and
+// AAP_PASSWORD, or AAP_TOKEN, environment variables, or nil when AAP_HOST is not set.
+func testAccEnvClient() *AAPClient {
+	host := os.Getenv("AAP_HOST")
+	if host == "" {
+		return nil
+	}
+	username := os.Getenv("AAP_USERNAME")
+	password := os.Getenv("AAP_PASSWORD")
+	client, _ := NewClient(host, &username, &password, os.Getenv("AAP_INSECURE_SKIP_VERIFY") == "true")
+	if token := os.Getenv("AAP_TOKEN"); token != "" {
+		client.Token = &token
+	}
+	return client
+}
+
+// testAccOrganization returns the id of the organization acceptance tests create objects in.
+func testAccOrganization() string {
+	if organization := os.Getenv("AAP_TEST_ORGANIZATION_ID"); organization != "" {
+		return organization
+	}
+	return "1"
+}
+
+// testConfigure configures the provider with the attributes, the others being null, and the AAP_* environment
+// variables, the others being cleared. It returns the client it makes, nil when it reports errors, with its diagnostics.
+func testConfigure(t *testing.T, attributes map[string]any, environment map[string]string) (*AAPClient, diag.Diagnostics) {
+	t.Helper()
+	ctx := context.Background()
+	for _, variable := range os.Environ() {
+		if name, _, _ := strings.Cut(variable, "="); strings.HasPrefix(name, "AAP_") {
+			t.Setenv(name, "")
+		}
+	}
+	for name, value := range environment {
+		t.Setenv(name, value)
+	}
+
+	p := New("test")()
+	var schema provider.SchemaResponse
+	p.Schema(ctx, provider.SchemaRequest{}, &schema)
+	config := tfsdk.Config{Schema: schema.Schema, Raw: testValue(t, schema.Schema.Type().TerraformType(ctx), attributes)}
+
+	var resp provider.ConfigureResponse
+	p.Configure(ctx, provider.ConfigureRequest{Config: config}, &resp)
+	client, _ := resp.ResourceData.(*AAPClient)
+	return client, resp.Diagnostics
+}
+
+// testDiagnostics returns the summaries and details of the diagnostics, one per line.
+func testDiagnostics(diags diag.Diagnostics) string {
+	var lines []string
+	for _, d := range diags {
+		lines = append(lines, d.Summary()+": "+d.Detail())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// testPingServer starts a server answering the ping of AAP that records the Authorization header of the last request.
+func testPingServer(t *testing.T, authorization *string) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*authorization = r.Header.Get("Authorization")
+		writeJSON(w, http.StatusOK, AAPPing{})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestProviderConfigureAuthentication(t *testing.T) {
+	var authorization string
+	server := testPingServer(t, &authorization)
+
+	client, diags := testConfigure(t, map[string]any{"host": server.URL, "username": "admin", "password": "secret"}, nil)
+	if diags.HasError() {
+		t.Fatal(testDiagnostics(diags))
+	}
+	if _, err := client.Ping(); err != nil || !strings.HasPrefix(authorization, "Basic ") {
+		t.Errorf("username and password authenticate with %q, %v", authorization, err)
+	}
+
+	// a token replaces the username and password
+	client, diags = testConfigure(t, map[string]any{"host": server.URL, "token": "token-1"}, nil)
+	if diags.HasError() {
+		t.Fatal(testDiagnostics(diags))
+	}
+	if _, err := client.Ping(); err != nil || authorization != "Bearer token-1" {
+		t.Errorf("a token authenticates with %q, %v", authorization, err)
+	}
+	client, _ = testConfigure(t, map[string]any{"host": server.URL, "username": "admin", "password": "secret", "token": "token-1"}, nil)
+	if _, err := client.Ping(); err != nil || authorization != "Bearer token-1" {
+		t.Errorf("a token with a username and password authenticates with %q, %v", authorization, err)
+	}
+
+	client, diags = testConfigure(t, map[string]any{"host": server.URL}, nil)
+	if !strings.Contains(testDiagnostics(diags), "Missing AAP API Username") || !strings.Contains(testDiagnostics(diags), "Missing AAP API Password") {
+		t.Errorf("no credentials give %s", testDiagnostics(diags))
+	}
+	if client != nil {
+		t.Error("a client is made without credentials")
+	}
+
+	// the environment variables are used when the configuration does not set the values
+	client, diags = testConfigure(t, map[string]any{}, map[string]string{"AAP_HOST": server.URL, "AAP_USERNAME": "admin", "AAP_PASSWORD": "secret"})
+	if diags.HasError() {
+		t.Fatal(testDiagnostics(diags))
+	}
+	if _, err := client.Ping(); err != nil || !strings.HasPrefix(authorization, "Basic ") {
+		t.Errorf("AAP_USERNAME and AAP_PASSWORD authenticate with %q, %v", authorization, err)
+	}
+	client, _ = testConfigure(t, map[string]any{}, map[string]string{"AAP_HOST": server.URL, "AAP_TOKEN": "token-2"})
+	if _, err := client.Ping(); err != nil || authorization != "Bearer token-2" {
+		t.Errorf("AAP_TOKEN authenticates with %q, %v", authorization, err)
+	}
+	client, _ = testConfigure(t, map[string]any{"token": "token-1"}, map[string]string{"AAP_HOST": server.URL, "AAP_TOKEN": "token-2"})
+	if _, err := client.Ping(); err != nil || authorization != "Bearer token-1" {
+		t.Errorf("a token set in the configuration and AAP_TOKEN authenticates with %q, %v", authorization, err)
+	}
+}
+
+func TestProviderConfigureCACertificates(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, AAPPing{})
+	}))
+	t.Cleanup(server.Close)
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	certificateFile := filepath.Join(t.TempDir(), "ca.pem")
+	invalidFile := filepath.Join(t.TempDir(), "invalid.pem")
+	if os.WriteFile(certificateFile, []byte(certificate), 0o600) != nil || os.WriteFile(invalidFile, []byte("not a certificate"), 0o600) != nil {
+		t.Fatal("unable to write the certificate files")
+	}
+	credentials := map[string]any{"host": server.URL, "username": "admin", "password": "secret"}
+	with := func(settings map[string]any) map[string]any {
+		attributes := map[string]any{}
+		for _, values := range []map[string]any{credentials, settings} {
+			for name, value := range values {
+				attributes[name] = value
+			}
+		}
+		return attributes
+	}
+
+	client, _ := testConfigure(t, with(map[string]any{}), nil)
+	if _, err := client.Ping(); err == nil || !strings.Contains(err.Error(), "certificate") {
+		t.Errorf("the server certificate is trusted without CA certificates: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		attributes  map[string]any
+		environment map[string]string
+		err         string
+	}{
+		{name: "pem", attributes: map[string]any{"ca_cert_pem": certificate}},
+		{name: "file", attributes: map[string]any{"ca_cert_file": certificateFile}},
+		{name: "pem environment", environment: map[string]string{"AAP_CA_CERT_PEM": certificate}},
+		{name: "file environment", environment: map[string]string{"AAP_CA_CERT_FILE": certificateFile}},
+		{name: "pem and file", attributes: map[string]any{"ca_cert_pem": certificate, "ca_cert_file": certificateFile}, err: "Conflicting AAP API CA certificates"},
+		{name: "pem and file environment", attributes: map[string]any{"ca_cert_pem": certificate}, environment: map[string]string{"AAP_CA_CERT_FILE": certificateFile},
+			err: "Conflicting AAP API CA certificates"},
+		{name: "invalid pem", attributes: map[string]any{"ca_cert_pem": "not a certificate"}, err: "the value provided for ca_cert_pem holds no valid certificate"},
+		{name: "invalid file", attributes: map[string]any{"ca_cert_file": invalidFile}, err: "the value provided for ca_cert_file holds no valid certificate"},
+		{name: "missing file", attributes: map[string]any{"ca_cert_file": filepath.Join(t.TempDir(), "missing.pem")}, err: "Unable to read AAP API CA certificates"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			client, diags := testConfigure(t, with(test.attributes), test.environment)
+			if test.err != "" {
+				if !strings.Contains(testDiagnostics(diags), test.err) || client != nil {
+					t.Fatalf("diagnostics = %s, expected %q", testDiagnostics(diags), test.err)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatal(testDiagnostics(diags))
+			}
+			if _, err := client.Ping(); err != nil {
+				t.Errorf("the server certificate is not trusted: %v", err)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureProxy(t *testing.T) {
+	var proxied []string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied = append(proxied, r.Host)
+		writeJSON(w, http.StatusOK, AAPPing{})
+	}))
+	t.Cleanup(proxy.Close)
+	credentials := map[string]any{"host": "http://aap.example.invalid", "username": "admin", "password": "secret"}
+
+	tests := []struct {
+		name        string
+		proxyURL    any
+		environment map[string]string
+		err         string
+	}{
+		{name: "configuration", proxyURL: proxy.URL},
+		{name: "environment", environment: map[string]string{"AAP_PROXY_URL": proxy.URL}},
+		{name: "configuration over environment", proxyURL: proxy.URL, environment: map[string]string{"AAP_PROXY_URL": "http://other.example.invalid:3128"}},
+		{name: "unsupported scheme", proxyURL: "ftp://proxy.example.com", err: `the proxy URL "ftp://proxy.example.com" is not an http, https or socks5 URL`},
+		{name: "no scheme in environment", environment: map[string]string{"AAP_PROXY_URL": "proxy.example.com:3128"}, err: "Invalid AAP API proxy_url"},
+		{name: "no host", proxyURL: "socks5://", err: "Invalid AAP API proxy_url"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			attributes := map[string]any{"proxy_url": test.proxyURL}
+			for name, value := range credentials {
+				attributes[name] = value
+			}
+			client, diags := testConfigure(t, attributes, test.environment)
+			if test.err != "" {
+				if !strings.Contains(testDiagnostics(diags), test.err) || client != nil {
+					t.Fatalf("diagnostics = %s, expected %q", testDiagnostics(diags), test.err)
+				}
+				return
+			}
+			if diags.HasError() {
+				t.Fatal(testDiagnostics(diags))
+			}
+			if client.Proxy == nil || client.Proxy.String() != proxy.URL {
+				t.Errorf("proxy = %v, expected %s", client.Proxy, proxy.URL)
+			}
+			proxied = nil
+			if _, err := client.Ping(); err != nil || len(proxied) != 1 || proxied[0] != "aap.example.invalid" {
+				t.Errorf("requests to AAP were proxied for %v: %v", proxied, err)
+			}
+		})
+	}
+}
+
+func TestProviderConfigureHealthCheck(t *testing.T) {
+	var status int
+	var ping AAPPing
+	pings := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pings++
+		if status != http.StatusOK {
+			writeJSON(w, status, map[string]string{"detail": "A server error occurred."})
+			return
+		}
+		writeJSON(w, status, ping)
+	}))
+	t.Cleanup(server.Close)
+	healthy := AAPPing{Instances: []AAPPingInstance{
+		{Node: "controller1", NodeType: "control", Capacity: 0},
+		{Node: "exec1", NodeType: "execution", Capacity: 50},
+	}}
+	unhealthy := AAPPing{Instances: []AAPPingInstance{
+		{Node: "exec2", NodeType: "execution", Capacity: 0},
+		{Node: "exec1", NodeType: "execution", Capacity: 50},
+		{Node: "hybrid1", NodeType: "hybrid", Capacity: 0},
+	}}
+
+	tests := []struct {
+		name        string
+		healthCheck any
+		environment map[string]string
+		status      int
+		ping        AAPPing
+		pings       int
+		warning     string
+		err         string
+	}{
+		{name: "off by default", status: http.StatusInternalServerError},
+		{name: "off", healthCheck: "off", status: http.StatusInternalServerError},
+		{name: "healthy", healthCheck: "fail", status: http.StatusOK, ping: healthy, pings: 1},
+		{name: "server error warns", healthCheck: "warn", status: http.StatusInternalServerError, pings: 1, warning: "Unable to check AAP controller health"},
+		{name: "server error fails", healthCheck: "fail", status: http.StatusInternalServerError, pings: 1, err: "Unable to check AAP controller health"},
+		{name: "unavailable nodes warn", healthCheck: "warn", status: http.StatusOK, ping: unhealthy, pings: 1,
+			warning: "The execution nodes exec2, hybrid1 report no capacity"},
+		{name: "unavailable nodes fail", healthCheck: "fail", status: http.StatusOK, ping: unhealthy, pings: 1, err: "AAP controller nodes unavailable"},
+		{name: "environment", environment: map[string]string{"AAP_HEALTH_CHECK": "fail"}, status: http.StatusInternalServerError, pings: 1,
+			err: "Unable to check AAP controller health"},
+		{name: "configuration over environment", healthCheck: "off", environment: map[string]string{"AAP_HEALTH_CHECK": "fail"}, status: http.StatusInternalServerError},
+		{name: "invalid environment", environment: map[string]string{"AAP_HEALTH_CHECK": "always"}, err: "is not one of off, warn or fail"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			status, ping, pings = test.status, test.ping, 0
+			client, diags := testConfigure(t, map[string]any{
+				"host": server.URL, "username": "admin", "password": "secret", "health_check": test.healthCheck,
+			}, test.environment)
+			if pings != test.pings {
+				t.Errorf("the controller was pinged %d times, expected %d", pings, test.pings)
+			}
+			if test.err != "" {
+				if !strings.Contains(testDiagnostics(diags), test.err) || client != nil {
+					t.Fatalf("diagnostics = %s, expected %q", testDiagnostics(diags), test.err)
+				}
+				return
+			}
+			if diags.HasError() || client == nil {
+				t.Fatal(testDiagnostics(diags))
+			}
+			if warnings := diags.Warnings(); test.warning == "" && len(warnings) > 0 ||
+				test.warning != "" && (len(warnings) != 1 || !strings.Contains(testDiagnostics(warnings), test.warning)) {
+				t.Errorf("warnings = %s, expected %q", testDiagnostics(warnings), test.warning)
+			}
+		})
+	}
+}
@@ -0,0 +1,124 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &schedulePreviewDataSource{}
+	_ datasource.DataSourceWithConfigure = &schedulePreviewDataSource{}
+)
+
+// NewSchedulePreviewDataSource is a helper function to simplify the provider implementation.
+func NewSchedulePreviewDataSource() datasource.DataSource {
+	return &schedulePreviewDataSource{}
+}
+
+// schedulePreviewDataSource lists the next occurrences of a schedule rule as computed by AAP.
+type schedulePreviewDataSource struct {
+	client *AAPClient
+}
+
+// schedulePreviewMaxCount is the number of occurrences AAP previews.
+const schedulePreviewMaxCount = 10
+
+// Metadata returns the data source type name.
+func (d *schedulePreviewDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_schedule_preview"
+}
+
+// Schema defines the schema for the data source.
+func (d *schedulePreviewDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Previews the next occurrences of a schedule rule, as AAP computes them. " +
+			"Rules AAP rejects fail the plan, so schedules can be checked before they are created.",
+		Attributes: map[string]schema.Attribute{
+			"rrule": schema.StringAttribute{
+				Required:    true,
+				Description: "Schedule rule, e.g. DTSTART;TZID=Europe/Paris:20250101T020000 RRULE:FREQ=DAILY;INTERVAL=1.",
+			},
+			"max_occurrences": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Number of occurrences to return, at most and by default %d.", schedulePreviewMaxCount),
+				Validators: []validator.Int64{
+					int64validator.Between(1, schedulePreviewMaxCount),
+				},
+			},
+			"occurrences": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Next occurrences in UTC, in RFC 3339 format. Rules that end soon return fewer occurrences.",
+			},
+			"local_occurrences": schema.ListAttribute{
+				ElementType: types.StringType,
+				Computed:    true,
+				Description: "Next occurrences in the time zone of the rule.",
+			},
+		},
+	}
+}
+
+// schedulePreviewDataSourceModel maps the data source schema data.
+type schedulePreviewDataSourceModel struct {
+	RRule            types.String `tfsdk:"rrule"`
+	MaxOccurrences   types.Int64  `tfsdk:"max_occurrences"`
+	Occurrences      []string     `tfsdk:"occurrences"`
+	LocalOccurrences []string     `tfsdk:"local_occurrences"`
+}
+
+// schedulePreviewAPIFields maps the fields of the schedule preview API to the attributes they are set from.
+var schedulePreviewAPIFields = map[string]string{
+	"rrule": "rrule",
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *schedulePreviewDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_schedule_preview", "read", &resp.Diagnostics)
+	var state schedulePreviewDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	preview, err := d.client.PreviewSchedule(state.RRule.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Invalid schedule rule", err, schedulePreviewAPIFields)...)
+		return
+	}
+
+	count := schedulePreviewMaxCount
+	if !state.MaxOccurrences.IsNull() {
+		count = int(state.MaxOccurrences.ValueInt64())
+	}
+	state.Occurrences = append([]string{}, preview.UTC[:min(count, len(preview.UTC))]...)
+	state.LocalOccurrences = append([]string{}, preview.Local[:min(count, len(preview.Local))]...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *schedulePreviewDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
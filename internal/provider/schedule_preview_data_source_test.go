@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSchedulePreviewDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	p := newTestProvider(t, mock, nil)
+
+	rrule := "DTSTART;TZID=Europe/Paris:20250101T020000 RRULE:FREQ=DAILY;INTERVAL=2"
+	state := p.readDataSource("aap_schedule_preview", map[string]any{"rrule": rrule})
+	if occurrences, _ := state["occurrences"].([]any); len(occurrences) != schedulePreviewMaxCount {
+		t.Errorf("occurrences = %v, expected %d by default", state["occurrences"], schedulePreviewMaxCount)
+	}
+
+	testExpect(t, p.readDataSource("aap_schedule_preview", map[string]any{"rrule": rrule, "max_occurrences": 3}), map[string]any{
+		"rrule": rrule, "max_occurrences": int64(3),
+		"occurrences":       []any{"2025-01-01T01:00:00Z", "2025-01-03T01:00:00Z", "2025-01-05T01:00:00Z"},
+		"local_occurrences": []any{"2025-01-01T02:00:00+01:00", "2025-01-03T02:00:00+01:00", "2025-01-05T02:00:00+01:00"},
+	})
+
+	// rules ending before max_occurrences return fewer occurrences
+	testExpect(t, p.readDataSource("aap_schedule_preview", map[string]any{"rrule": "DTSTART:20250101T000000Z RRULE:FREQ=HOURLY;COUNT=2", "max_occurrences": 5}), map[string]any{
+		"occurrences":       []any{"2025-01-01T00:00:00Z", "2025-01-01T01:00:00Z"},
+		"local_occurrences": []any{"2025-01-01T00:00:00Z", "2025-01-01T01:00:00Z"},
+	})
+
+	if _, errors := p.tryReadDataSource("aap_schedule_preview", map[string]any{"rrule": "RRULE:FREQ=DAILY"}); !strings.Contains(errors, "DTSTART") {
+		t.Errorf("errors = %q, expected the error AAP returns for the rule", errors)
+	}
+	if _, errors := p.tryReadDataSource("aap_schedule_preview", map[string]any{"rrule": rrule, "max_occurrences": schedulePreviewMaxCount + 1}); errors == "" {
+		t.Error("more occurrences than AAP previews were accepted")
+	}
+}
@@ -0,0 +1,1596 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &stateInventoryResource{}
+	_ resource.ResourceWithConfigure      = &stateInventoryResource{}
+	_ resource.ResourceWithImportState    = &stateInventoryResource{}
+	_ resource.ResourceWithModifyPlan     = &stateInventoryResource{}
+	_ resource.ResourceWithMoveState      = &stateInventoryResource{}
+	_ resource.ResourceWithValidateConfig = &stateInventoryResource{}
+)
+
+// NewStateInventoryResource is a helper function to simplify the provider implementation.
+func NewStateInventoryResource() resource.Resource {
+	return &stateInventoryResource{}
+}
+
+// stateInventoryResource materializes the ansible hosts found in a Terraform state as an AAP inventory.
+type stateInventoryResource struct {
+	client *AAPClient
+}
+
+// removalWarningThreshold is the number of deleted hosts and groups from which a plan warns about the deletion.
+const removalWarningThreshold = 10
+
+var inventoryHostObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"variables": types.StringType,
+	},
+}
+
+var inventoryGroupObjectType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"hosts":     types.ListType{ElemType: types.StringType},
+		"children":  types.ListType{ElemType: types.StringType},
+		"variables": types.StringType,
+	},
+}
+
+// Metadata returns the resource type name.
+func (r *stateInventoryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_state_inventory"
+}
+
+// Schema defines the schema for the resource.
+func (r *stateInventoryResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages an AAP inventory whose hosts and groups are read from the ansible resources of a Terraform state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the AAP inventory.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the AAP inventory.",
+				Validators:  nameValidators(),
+			},
+			"organization": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the organization owning the inventory. Changing it recreates the inventory.",
+				Validators:  idValidators(),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Description: "Description of the AAP inventory.",
+			},
+			"state_file": schema.StringAttribute{
+				Optional:    true,
+				Description: "Path to a local Terraform state file.",
+			},
+			"state_url": schema.StringAttribute{
+				Optional:    true,
+				Description: "URL returning a Terraform state document, e.g. the address of an http backend.",
+				Validators:  urlValidators("http", "https"),
+			},
+			"state_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Id of a Terraform state stored in AAP.",
+				Validators:  idValidators(),
+			},
+			"tfe_workspace": schema.SingleNestedAttribute{
+				Optional:    true,
+				Description: "HCP Terraform or Terraform Enterprise workspace whose current state is read.",
+				Attributes: map[string]schema.Attribute{
+					"hostname": schema.StringAttribute{
+						Optional:    true,
+						Description: "Hostname of the Terraform Enterprise instance, defaults to app.terraform.io.",
+					},
+					"organization": schema.StringAttribute{
+						Required:    true,
+						Description: "Organization owning the workspace.",
+					},
+					"name": schema.StringAttribute{
+						Required:    true,
+						Description: "Name of the workspace.",
+					},
+					"token": schema.StringAttribute{
+						Optional:    true,
+						Sensitive:   true,
+						Description: "API token, defaults to the TF_TOKEN_<hostname> or TFE_TOKEN environment variables.",
+					},
+				},
+			},
+			"sensitive_host_variables": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "JSON variables documents, keyed by host name, merged into the variables of hosts found in the state, " +
+					"e.g. join tokens. They are masked in plans and their keys are left out of the hosts attribute, " +
+					"so changes made to them in AAP are not detected.",
+			},
+			"sensitive_group_variables": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "JSON variables documents, keyed by group name, merged into the variables of groups found in the state. " +
+					"They are masked in plans and their keys are left out of the groups attribute.",
+			},
+			"external_groups": schema.SetAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "Names of groups managed outside of the Terraform state, e.g. by an inventory source. " +
+					"They are left out of the groups attribute and never deleted; only their memberships in groups " +
+					"of the state are removed when the state no longer lists them. Their hosts that the state does not list " +
+					"are not deleted either, and are left out of the hosts attribute.",
+			},
+			"host_name_pattern": schema.StringAttribute{
+				Optional: true,
+				Description: "Regular expression the whole name of every host added to the inventory must match, " +
+					`e.g. ^([a-z0-9-]+\.)+[a-z]{2,}$ for fully qualified domain names. Plans adding other hosts fail; ` +
+					"hosts already in the inventory are not checked.",
+				Validators: regexpValidators(),
+			},
+			"host_variables_schema": schema.StringAttribute{
+				Optional: true,
+				Description: "JSON Schema document the variables of every host must match, checked when planning, " +
+					"e.g. to require the keys playbooks depend on. The type, properties, required, additionalProperties, items, enum, " +
+					"const, pattern, minimum, maximum, minLength, maxLength, minItems and maxItems keywords are supported.",
+			},
+			"group_variables_schema": schema.StringAttribute{
+				Optional:    true,
+				Description: "JSON Schema document the variables of every group must match, checked when planning, see host_variables_schema.",
+			},
+			"read_only": schema.BoolAttribute{
+				Optional: true,
+				Computed: true,
+				Default:  booldefault.StaticBool(false),
+				Description: "Only report the differences between AAP and the Terraform state as plan changes, without changing AAP. " +
+					"The inventory must already exist, applying records the configuration without creating, updating or deleting anything, " +
+					"so the differences show again on the next plan. Destroying the resource leaves the inventory in AAP.",
+			},
+			"total_hosts": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of hosts in the inventory, as counted by AAP.",
+			},
+			"total_groups": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of groups in the inventory, as counted by AAP.",
+			},
+			"hosts_with_active_failures": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Number of hosts whose last job failed.",
+			},
+			"has_inventory_sources": schema.BoolAttribute{
+				Computed:    true,
+				Description: "Whether inventory sources also add hosts to the inventory.",
+			},
+			"created": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the inventory was created in AAP.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"modified": schema.StringAttribute{
+				Computed:    true,
+				Description: "Time the inventory was last modified in AAP.",
+			},
+			"created_by": schema.StringAttribute{
+				Computed:    true,
+				Description: "Username of the user who created the inventory, empty when AAP does not report one.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"hosts": schema.MapNestedAttribute{
+				Computed: true,
+				Description: "Hosts of the inventory, keyed by name. They hold what the Terraform state sets, " +
+					"so AAP audit fields such as created, modified and created_by are only reported for the inventory.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"variables": schema.StringAttribute{
+							Computed:    true,
+							Description: "Host variables as a JSON document.",
+						},
+					},
+				},
+			},
+			"groups": schema.MapNestedAttribute{
+				Computed:    true,
+				Description: "Groups of the inventory, keyed by name. Like hosts, they have no created, modified or created_by fields.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"hosts": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Names of the hosts directly in the group.",
+						},
+						"children": schema.ListAttribute{
+							ElementType: types.StringType,
+							Computed:    true,
+							Description: "Names of the child groups.",
+						},
+						"variables": schema.StringAttribute{
+							Computed:    true,
+							Description: "Group variables as a JSON document.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// stateInventoryResourceModel maps the resource schema data.
+type stateInventoryResourceModel struct {
+	Id                      types.Int64  `tfsdk:"id"`
+	Name                    types.String `tfsdk:"name"`
+	Organization            types.Int64  `tfsdk:"organization"`
+	Description             types.String `tfsdk:"description"`
+	StateFile               types.String `tfsdk:"state_file"`
+	StateURL                types.String `tfsdk:"state_url"`
+	StateId                 types.Int64  `tfsdk:"state_id"`
+	TFEWorkspace            types.Object `tfsdk:"tfe_workspace"`
+	SensitiveHostVariables  types.Map    `tfsdk:"sensitive_host_variables"`
+	SensitiveGroupVariables types.Map    `tfsdk:"sensitive_group_variables"`
+	ExternalGroups          types.Set    `tfsdk:"external_groups"`
+	HostNamePattern         types.String `tfsdk:"host_name_pattern"`
+	HostVariablesSchema     types.String `tfsdk:"host_variables_schema"`
+	GroupVariablesSchema    types.String `tfsdk:"group_variables_schema"`
+	ReadOnly                types.Bool   `tfsdk:"read_only"`
+	TotalHosts              types.Int64  `tfsdk:"total_hosts"`
+	TotalGroups             types.Int64  `tfsdk:"total_groups"`
+	HostsWithActiveFailures types.Int64  `tfsdk:"hosts_with_active_failures"`
+	HasInventorySources     types.Bool   `tfsdk:"has_inventory_sources"`
+	Created                 types.String `tfsdk:"created"`
+	Modified                types.String `tfsdk:"modified"`
+	CreatedBy               types.String `tfsdk:"created_by"`
+	Hosts                   types.Map    `tfsdk:"hosts"`
+	Groups                  types.Map    `tfsdk:"groups"`
+}
+
+// stateInventoryAPIFields maps the fields of the AAP inventory API to the attributes they are set from.
+var stateInventoryAPIFields = map[string]string{
+	"name":         "name",
+	"organization": "organization",
+	"description":  "description",
+}
+
+func (m *stateInventoryResourceModel) source() stateSourceModel {
+	return stateSourceModel{
+		StateFile:    m.StateFile,
+		StateURL:     m.StateURL,
+		StateId:      m.StateId,
+		TFEWorkspace: m.TFEWorkspace,
+	}
+}
+
+func (m *stateInventoryResourceModel) inventory() AAPInventory {
+	return AAPInventory{
+		Name:         m.Name.ValueString(),
+		Organization: m.Organization.ValueInt64(),
+		Description:  m.Description.ValueString(),
+	}
+}
+
+// setSummary updates the summary counts and audit fields of the model from the inventory read from AAP.
+func (m *stateInventoryResourceModel) setSummary(inventory *AAPInventory) {
+	m.TotalHosts = types.Int64Value(inventory.TotalHosts)
+	m.TotalGroups = types.Int64Value(inventory.TotalGroups)
+	m.HostsWithActiveFailures = types.Int64Value(inventory.HostsWithActiveFailures)
+	m.HasInventorySources = types.BoolValue(inventory.HasInventorySources)
+	m.Created = types.StringValue(inventory.Created)
+	m.Modified = types.StringValue(inventory.Modified)
+	m.CreatedBy = types.StringValue(inventory.SummaryFields.createdBy())
+}
+
+// sensitiveVariables holds the variables merged into hosts and groups that are kept out of the Terraform state, keyed by name.
+type sensitiveVariables struct {
+	Hosts  map[string]map[string]interface{}
+	Groups map[string]map[string]interface{}
+}
+
+// sensitiveVariables decodes the sensitive variables documents of the model.
+func (m *stateInventoryResourceModel) sensitiveVariables(ctx context.Context) (sensitiveVariables, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	sensitive := sensitiveVariables{
+		Hosts:  make(map[string]map[string]interface{}),
+		Groups: make(map[string]map[string]interface{}),
+	}
+	decode := func(attribute string, documents types.Map, result map[string]map[string]interface{}) {
+		if documents.IsNull() || documents.IsUnknown() {
+			return
+		}
+		var raw map[string]types.String
+		diags.Append(documents.ElementsAs(ctx, &raw, false)...)
+		for name, document := range raw {
+			if document.IsUnknown() {
+				continue
+			}
+			variables, err := decodeVariables(document.ValueString())
+			if err != nil {
+				// the document itself is not reported as it holds secrets
+				diags.AddAttributeError(path.Root(attribute).AtMapKey(name), "Invalid sensitive variables",
+					fmt.Sprintf("The sensitive variables of %q must be a JSON object.", name))
+				continue
+			}
+			result[name] = variables
+		}
+	}
+	decode("sensitive_host_variables", m.SensitiveHostVariables, sensitive.Hosts)
+	decode("sensitive_group_variables", m.SensitiveGroupVariables, sensitive.Groups)
+	return sensitive, diags
+}
+
+// merged returns a copy of the contents built from the Terraform state with the sensitive variables added,
+// the contents sent to AAP.
+func (s sensitiveVariables) merged(contents inventoryContents) (inventoryContents, error) {
+	result := newInventoryContents()
+	for name, host := range contents.Hosts {
+		result.Hosts[name] = host
+	}
+	for name, group := range contents.Groups {
+		result.Groups[name] = group
+	}
+
+	for name, variables := range s.Hosts {
+		host, ok := result.Hosts[name]
+		if !ok {
+			return result, fmt.Errorf("sensitive variables are set for host %q, which is not in the Terraform state", name)
+		}
+		merged, err := mergeVariablesDocument(host.Variables, variables)
+		if err != nil {
+			return result, fmt.Errorf("host %q: %w", name, err)
+		}
+		host.Variables = merged
+		result.Hosts[name] = host
+	}
+	for name, variables := range s.Groups {
+		group, ok := result.Groups[name]
+		if !ok {
+			return result, fmt.Errorf("sensitive variables are set for group %q, which is not in the Terraform state", name)
+		}
+		merged, err := mergeVariablesDocument(group.Variables, variables)
+		if err != nil {
+			return result, fmt.Errorf("group %q: %w", name, err)
+		}
+		group.Variables = merged
+		result.Groups[name] = group
+	}
+	return result, nil
+}
+
+// strip removes the sensitive variables from the contents read from AAP.
+func (s sensitiveVariables) strip(contents inventoryContents) {
+	for name, variables := range s.Hosts {
+		if host, ok := contents.Hosts[name]; ok {
+			host.Variables = stripVariablesDocument(host.Variables, variables)
+			contents.Hosts[name] = host
+		}
+	}
+	for name, variables := range s.Groups {
+		if group, ok := contents.Groups[name]; ok {
+			group.Variables = stripVariablesDocument(group.Variables, variables)
+			contents.Groups[name] = group
+		}
+	}
+}
+
+// mergeVariablesDocument deep-merges variables into a JSON variables document.
+func mergeVariablesDocument(document string, variables map[string]interface{}) (string, error) {
+	merged, err := decodeVariables(document)
+	if err != nil {
+		return "", err
+	}
+	mergeVariables(merged, variables)
+	return encodeVariables(merged)
+}
+
+// mergeVariables merges src into dst, recursing into objects present on both sides.
+func mergeVariables(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcObject, srcIsObject := value.(map[string]interface{})
+		dstObject, dstIsObject := dst[key].(map[string]interface{})
+		if srcIsObject && dstIsObject {
+			mergeVariables(dstObject, srcObject)
+			continue
+		}
+		dst[key] = value
+	}
+}
+
+// stripVariables removes the values mergeVariables would set from src out of dst. Objects present on both sides
+// are recursed into and removed once nothing else is left in them.
+func stripVariables(dst, src map[string]interface{}) {
+	for key, value := range src {
+		srcObject, srcIsObject := value.(map[string]interface{})
+		dstObject, dstIsObject := dst[key].(map[string]interface{})
+		if srcIsObject && dstIsObject {
+			stripVariables(dstObject, srcObject)
+			if len(dstObject) > 0 {
+				continue
+			}
+		}
+		delete(dst, key)
+	}
+}
+
+// stripVariablesDocument removes the values deep-merged by mergeVariablesDocument from a JSON variables document.
+// Documents that are not JSON are returned as-is.
+func stripVariablesDocument(document string, variables map[string]interface{}) string {
+	decoded, err := decodeVariables(document)
+	if err != nil {
+		return document
+	}
+	stripVariables(decoded, variables)
+	stripped, err := encodeVariables(decoded)
+	if err != nil {
+		return document
+	}
+	return stripped
+}
+
+// externalGroups returns the names of the groups managed outside of the Terraform state.
+func (m *stateInventoryResourceModel) externalGroups(ctx context.Context) ([]string, diag.Diagnostics) {
+	var names []string
+	if m.ExternalGroups.IsNull() || m.ExternalGroups.IsUnknown() {
+		return names, nil
+	}
+	diags := m.ExternalGroups.ElementsAs(ctx, &names, false)
+	return names, diags
+}
+
+// inventoryHostModel describes a host in the inventory contents.
+type inventoryHostModel struct {
+	Variables string `tfsdk:"variables"`
+}
+
+// inventoryGroupModel describes a group in the inventory contents.
+type inventoryGroupModel struct {
+	Hosts     []string `tfsdk:"hosts"`
+	Children  []string `tfsdk:"children"`
+	Variables string   `tfsdk:"variables"`
+}
+
+// inventoryContents holds the hosts and groups of an inventory, keyed by name.
+type inventoryContents struct {
+	Hosts  map[string]inventoryHostModel
+	Groups map[string]inventoryGroupModel
+}
+
+func newInventoryContents() inventoryContents {
+	return inventoryContents{
+		Hosts:  make(map[string]inventoryHostModel),
+		Groups: make(map[string]inventoryGroupModel),
+	}
+}
+
+// addHostToGroup records the host as a direct member of the group, creating the group if needed.
+func (c *inventoryContents) addHostToGroup(groupName string, hostName string) {
+	group := c.Groups[groupName]
+	if !slices.Contains(group.Hosts, hostName) {
+		group.Hosts = append(group.Hosts, hostName)
+	}
+	c.Groups[groupName] = group
+}
+
+// externalHosts returns the names of the hosts that are members of any of the external groups.
+func (c *inventoryContents) externalHosts(external []string) map[string]bool {
+	hosts := make(map[string]bool)
+	for _, name := range external {
+		for _, host := range c.Groups[name].Hosts {
+			hosts[host] = true
+		}
+	}
+	return hosts
+}
+
+// sortMembers sorts group members and replaces nil slices so that the
+// contents compare equal however they were built.
+func (c *inventoryContents) sortMembers() {
+	for name, group := range c.Groups {
+		if group.Hosts == nil {
+			group.Hosts = []string{}
+		}
+		if group.Children == nil {
+			group.Children = []string{}
+		}
+		sort.Strings(group.Hosts)
+		sort.Strings(group.Children)
+		c.Groups[name] = group
+	}
+}
+
+// toTerraform converts the contents into values for the hosts and groups attributes.
+func (c inventoryContents) toTerraform(ctx context.Context) (types.Map, types.Map, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	c.sortMembers()
+	hosts, d := types.MapValueFrom(ctx, inventoryHostObjectType, c.Hosts)
+	diags.Append(d...)
+	groups, d := types.MapValueFrom(ctx, inventoryGroupObjectType, c.Groups)
+	diags.Append(d...)
+	return hosts, groups, diags
+}
+
+// inventoryContentsFromTerraform converts the values of the hosts and groups attributes back into contents.
+func inventoryContentsFromTerraform(ctx context.Context, hosts types.Map, groups types.Map) (inventoryContents, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	contents := newInventoryContents()
+	if !hosts.IsNull() && !hosts.IsUnknown() {
+		diags.Append(hosts.ElementsAs(ctx, &contents.Hosts, false)...)
+	}
+	if !groups.IsNull() && !groups.IsUnknown() {
+		diags.Append(groups.ElementsAs(ctx, &contents.Groups, false)...)
+	}
+	return contents, diags
+}
+
+// hash returns a digest of the contents that is equal for equal contents.
+func (c inventoryContents) hash() string {
+	c.sortMembers()
+	// maps are encoded with sorted keys, making the encoding canonical
+	data, _ := json.Marshal(c)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// inventoryContentsFromState builds the desired inventory contents from a Terraform state document.
+func inventoryContentsFromState(body []byte) (inventoryContents, error) {
+	contents := newInventoryContents()
+	hosts, err := GetAnsibleHost(body)
+	if err != nil {
+		return contents, err
+	}
+
+	for _, host := range hosts.Hosts {
+		variables, err := encodeVariables(host.Variables)
+		if err != nil {
+			return contents, fmt.Errorf("host %q: %w", host.Name, err)
+		}
+		contents.Hosts[host.Name] = inventoryHostModel{Variables: variables}
+		for _, group := range host.Groups {
+			contents.addHostToGroup(group, host.Name)
+		}
+	}
+
+	for _, group := range hosts.Groups {
+		variables, err := encodeVariables(group.Variables)
+		if err != nil {
+			return contents, fmt.Errorf("group %q: %w", group.Name, err)
+		}
+		model := contents.Groups[group.Name]
+		model.Variables = variables
+		for _, child := range group.Children {
+			if !slices.Contains(model.Children, child) {
+				model.Children = append(model.Children, child)
+			}
+			// children only referenced by name still need to exist in the inventory
+			if _, ok := contents.Groups[child]; !ok {
+				contents.Groups[child] = inventoryGroupModel{}
+			}
+		}
+		contents.Groups[group.Name] = model
+	}
+	contents.sortMembers()
+	return contents, nil
+}
+
+// encodeVariables renders a variables map as the JSON document sent to AAP, keeping
+// numbers, booleans and nested values as they are.
+// An empty map is rendered as an empty string, which is how AAP reports no variables.
+func encodeVariables(variables map[string]interface{}) (string, error) {
+	if len(variables) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(variables)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// decodeVariables parses a JSON variables document, keeping numbers as they are written.
+// An empty document decodes to an empty map.
+func decodeVariables(raw string) (map[string]interface{}, error) {
+	variables := map[string]interface{}{}
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || trimmed == "---" {
+		return variables, nil
+	}
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+	if err := decoder.Decode(&variables); err != nil {
+		return nil, err
+	}
+	if variables == nil {
+		return nil, fmt.Errorf("variables must be a JSON object")
+	}
+	return variables, nil
+}
+
+// normalizeVariables re-encodes a JSON variables document returned by AAP so that
+// it compares equal to the output of encodeVariables. Non-JSON documents are returned as-is.
+func normalizeVariables(raw string) string {
+	trimmed := strings.TrimSpace(raw)
+	if trimmed == "" || trimmed == "---" || trimmed == "{}" {
+		return ""
+	}
+	var variables map[string]interface{}
+	decoder := json.NewDecoder(strings.NewReader(trimmed))
+	decoder.UseNumber()
+	if err := decoder.Decode(&variables); err != nil {
+		return raw
+	}
+	if len(variables) == 0 {
+		return ""
+	}
+	data, err := json.Marshal(variables)
+	if err != nil {
+		return raw
+	}
+	return string(data)
+}
+
+// aapInventoryContents is the content of an inventory as found in AAP, along with the ids of its objects.
+type aapInventoryContents struct {
+	inventoryContents
+	hostIds  map[string]int64
+	groupIds map[string]int64
+}
+
+// readInventoryContents fetches the hosts and groups of an AAP inventory.
+func readInventoryContents(client *AAPClient, inventoryId int64) (*aapInventoryContents, error) {
+	result := &aapInventoryContents{
+		inventoryContents: newInventoryContents(),
+		hostIds:           make(map[string]int64),
+		groupIds:          make(map[string]int64),
+	}
+
+	groups, err := client.GetInventoryGroups(inventoryId)
+	if err != nil {
+		return nil, err
+	}
+
+	// hosts are processed page by page, only those whose group summary was truncated are kept for a lookup
+	var truncated []AAPHost
+	err = client.ForEachInventoryHost(inventoryId, func(host AAPHost) error {
+		result.hostIds[host.Name] = host.Id
+		result.Hosts[host.Name] = inventoryHostModel{Variables: normalizeVariables(host.Variables)}
+		if host.SummaryFields == nil || !host.SummaryFields.Groups.complete() {
+			truncated = append(truncated, AAPHost{Id: host.Id, Name: host.Name})
+			return nil
+		}
+		for _, group := range host.SummaryFields.Groups.Results {
+			result.addHostToGroup(group.Name, host.Name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// memberships need one request per object, send them concurrently
+	children := make([][]AAPGroup, len(groups))
+	hostGroups := make([][]AAPGroup, len(truncated))
+	var g errgroup.Group
+	g.SetLimit(client.parallelism())
+	for i, group := range groups {
+		i, group := i, group
+		g.Go(func() (err error) {
+			children[i], err = client.GetGroupChildren(group.Id)
+			return err
+		})
+	}
+	for i, host := range truncated {
+		i, host := i, host
+		g.Go(func() (err error) {
+			hostGroups[i], err = client.GetHostGroups(host.Id)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	for i, group := range groups {
+		result.groupIds[group.Name] = group.Id
+		model := result.Groups[group.Name]
+		model.Variables = normalizeVariables(group.Variables)
+		for _, child := range children[i] {
+			model.Children = append(model.Children, child.Name)
+		}
+		result.Groups[group.Name] = model
+	}
+
+	for i, host := range truncated {
+		for _, group := range hostGroups[i] {
+			result.addHostToGroup(group.Name, host.Name)
+		}
+	}
+
+	result.sortMembers()
+	return result, nil
+}
+
+// membershipChange adds a host or a child group to a group, or removes it.
+type membershipChange struct {
+	groupId      int64
+	memberId     int64
+	child        bool
+	disassociate bool
+}
+
+func (m membershipChange) apply(client *AAPClient) error {
+	switch {
+	case m.child && m.disassociate:
+		return client.DisassociateGroupChild(m.groupId, m.memberId)
+	case m.child:
+		return client.AssociateGroupChild(m.groupId, m.memberId)
+	case m.disassociate:
+		return client.DisassociateGroupHost(m.groupId, m.memberId)
+	default:
+		return client.AssociateGroupHost(m.groupId, m.memberId)
+	}
+}
+
+// syncInventoryContents creates, updates, associates and deletes hosts and groups
+// until the AAP inventory matches the desired contents. External groups and their hosts are not deleted.
+// Relationships of deleted groups with the remaining hosts and groups are removed first,
+// so that the deletion does not take anything else with it.
+func syncInventoryContents(client *AAPClient, inventoryId int64, desired inventoryContents, external []string) error {
+	current, err := readInventoryContents(client, inventoryId)
+	if err != nil {
+		return err
+	}
+
+	groupNames := sortedKeys(desired.Groups)
+	hostNames := sortedKeys(desired.Hosts)
+	externalHosts := current.externalHosts(external)
+	kept := func(host string) bool {
+		_, keep := desired.Hosts[host]
+		return keep || externalHosts[host]
+	}
+
+	for _, name := range groupNames {
+		group := desired.Groups[name]
+		payload := AAPGroup{Name: name, Inventory: inventoryId, Variables: group.Variables}
+		groupId, ok := current.groupIds[name]
+		if !ok {
+			created, err := client.CreateGroup(payload)
+			if err != nil {
+				return err
+			}
+			current.groupIds[name] = created.Id
+		} else if current.Groups[name].Variables != group.Variables {
+			if _, err := client.UpdateGroup(groupId, payload); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range hostNames {
+		host := desired.Hosts[name]
+		payload := AAPHost{Name: name, Inventory: inventoryId, Variables: host.Variables}
+		hostId, ok := current.hostIds[name]
+		if !ok {
+			created, err := client.CreateHost(payload)
+			if err != nil {
+				return err
+			}
+			current.hostIds[name] = created.Id
+		} else if current.Hosts[name].Variables != host.Variables {
+			if _, err := client.UpdateHost(hostId, payload); err != nil {
+				return err
+			}
+		}
+	}
+
+	// memberships are compared with the ones read above, only the differences are sent, concurrently
+	changes := make(map[membershipChange]bool)
+	for _, name := range groupNames {
+		group := desired.Groups[name]
+		existing := current.Groups[name]
+		groupId := current.groupIds[name]
+
+		for _, host := range group.Hosts {
+			if !slices.Contains(existing.Hosts, host) {
+				changes[membershipChange{groupId: groupId, memberId: current.hostIds[host]}] = true
+			}
+		}
+		for _, host := range existing.Hosts {
+			// hosts that are no longer desired lose their memberships when deleted
+			if kept(host) && !slices.Contains(group.Hosts, host) {
+				changes[membershipChange{groupId: groupId, memberId: current.hostIds[host], disassociate: true}] = true
+			}
+		}
+
+		for _, child := range group.Children {
+			if !slices.Contains(existing.Children, child) {
+				changes[membershipChange{groupId: groupId, memberId: current.groupIds[child], child: true}] = true
+			}
+		}
+		for _, child := range existing.Children {
+			if !slices.Contains(group.Children, child) {
+				changes[membershipChange{groupId: groupId, memberId: current.groupIds[child], child: true, disassociate: true}] = true
+			}
+		}
+	}
+
+	// groups about to be deleted are detached from the hosts and groups that remain
+	deleted := func(name string) bool {
+		_, keep := desired.Groups[name]
+		return !keep && !slices.Contains(external, name)
+	}
+	for _, name := range sortedKeys(current.Groups) {
+		existing := current.Groups[name]
+		groupId := current.groupIds[name]
+		if deleted(name) {
+			for _, host := range existing.Hosts {
+				if kept(host) {
+					changes[membershipChange{groupId: groupId, memberId: current.hostIds[host], disassociate: true}] = true
+				}
+			}
+		}
+		for _, child := range existing.Children {
+			if deleted(name) != deleted(child) {
+				changes[membershipChange{groupId: groupId, memberId: current.groupIds[child], child: true, disassociate: true}] = true
+			}
+		}
+	}
+
+	var g errgroup.Group
+	g.SetLimit(client.parallelism())
+	for change := range changes {
+		change := change
+		g.Go(func() error {
+			return change.apply(client)
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	for _, name := range sortedKeys(current.Hosts) {
+		if !kept(name) {
+			if err := client.DeleteHost(current.hostIds[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(current.Groups) {
+		if deleted(name) {
+			if err := client.DeleteGroup(current.groupIds[name]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortedKeys returns the keys of a map in sorted order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// ValidateConfig ensures exactly one state source is configured and the variables schemas can be used.
+func (r *stateInventoryResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config stateInventoryResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	for attribute, document := range map[string]types.String{
+		"host_variables_schema":  config.HostVariablesSchema,
+		"group_variables_schema": config.GroupVariablesSchema,
+	} {
+		if document.IsNull() || document.IsUnknown() {
+			continue
+		}
+		if _, err := parseVariablesSchema(document.ValueString()); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root(attribute), "Invalid variables schema", err.Error())
+		}
+	}
+
+	source := config.source()
+	if source.isUnknown() {
+		return
+	}
+	switch count := source.count(); {
+	case count == 0:
+		// configuration generated for an imported inventory has no state source, AAP does not record it
+		resp.Diagnostics.AddAttributeError(
+			path.Root("state_file"),
+			"Missing state source",
+			"Exactly one of state_file, state_url, state_id or tfe_workspace must be set. "+
+				"Configuration generated when importing an inventory leaves them all null as AAP does not record "+
+				"where the hosts come from; set the one the inventory is read from.",
+		)
+	case count > 1:
+		resp.Diagnostics.AddAttributeError(
+			path.Root("state_file"),
+			"Invalid state source",
+			"Exactly one of state_file, state_url, state_id or tfe_workspace must be set.",
+		)
+	}
+}
+
+// ModifyPlan reads the configured state so that the planned hosts and groups reflect it.
+func (r *stateInventoryResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compute when the resource is being destroyed.
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	defer r.client.reportStats(ctx, "aap_state_inventory", "plan", &resp.Diagnostics)
+
+	var plan stateInventoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() || plan.source().isUnknown() {
+		return
+	}
+
+	desired, diags := r.desiredContents(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the merged contents are not planned, building them reports sensitive variables of unknown hosts and groups
+	sent, diags := r.sentContents(ctx, plan, desired)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(checkVariablesSchemas(plan, sent)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	hosts, groups, diags := desired.toTerraform(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	plan.Hosts = hosts
+	plan.Groups = groups
+
+	state := stateInventoryResourceModel{Hosts: types.MapNull(inventoryHostObjectType)}
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		// nothing is deleted from read only inventories
+		if !plan.ReadOnly.ValueBool() {
+			resp.Diagnostics.Append(warnRemovedContents(state, desired)...)
+		}
+		// the counts and modification time of AAP are only known once the hosts and groups are updated
+		if !plan.Hosts.Equal(state.Hosts) || !plan.Groups.Equal(state.Groups) {
+			plan.Modified = types.StringUnknown()
+			plan.TotalHosts = types.Int64Unknown()
+			plan.TotalGroups = types.Int64Unknown()
+			plan.HostsWithActiveFailures = types.Int64Unknown()
+			plan.HasInventorySources = types.BoolUnknown()
+		}
+	}
+	resp.Diagnostics.Append(checkHostNames(plan, state.Hosts, desired)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// checkVariablesSchemas reports the hosts and groups whose variables, sensitive ones included, do not match the schemas of the model.
+func checkVariablesSchemas(model stateInventoryResourceModel, contents inventoryContents) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	check := func(attribute string, document types.String, kind string, variables map[string]string) {
+		if document.IsNull() || document.IsUnknown() {
+			return
+		}
+		schema, err := parseVariablesSchema(document.ValueString())
+		if err != nil {
+			// reported by ValidateConfig
+			return
+		}
+		for _, name := range sortedKeys(variables) {
+			violations, err := schema.validateDocument(variables[name])
+			if err != nil {
+				diags.AddAttributeError(path.Root(attribute), "Invalid variables",
+					fmt.Sprintf("The variables of %s %q are not a JSON object: %s", kind, name, err.Error()))
+				continue
+			}
+			if len(violations) > 0 {
+				diags.AddAttributeError(path.Root(attribute), "Variables do not match schema",
+					fmt.Sprintf("The variables of %s %q do not match %s:\n%s", kind, name, attribute, strings.Join(violations, "\n")))
+			}
+		}
+	}
+
+	hosts := make(map[string]string, len(contents.Hosts))
+	for name, host := range contents.Hosts {
+		hosts[name] = host.Variables
+	}
+	groups := make(map[string]string, len(contents.Groups))
+	for name, group := range contents.Groups {
+		groups[name] = group.Variables
+	}
+	check("host_variables_schema", model.HostVariablesSchema, "host", hosts)
+	check("group_variables_schema", model.GroupVariablesSchema, "group", groups)
+	return diags
+}
+
+// checkHostNames fails when hosts not in the current hosts would be added with a name that does not match the pattern of the model.
+// Read only inventories never get hosts added.
+func checkHostNames(model stateInventoryResourceModel, current types.Map, desired inventoryContents) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if model.HostNamePattern.IsNull() || model.HostNamePattern.IsUnknown() || model.ReadOnly.ValueBool() {
+		return diags
+	}
+	pattern, err := regexp.Compile(`^(?:` + model.HostNamePattern.ValueString() + `)$`)
+	if err != nil {
+		// reported by the attribute validator
+		return diags
+	}
+
+	var invalid []string
+	for _, name := range sortedKeys(desired.Hosts) {
+		if _, ok := current.Elements()[name]; !ok && !pattern.MatchString(name) {
+			invalid = append(invalid, strconv.Quote(name))
+		}
+	}
+	if len(invalid) > 0 {
+		diags.AddAttributeError(path.Root("host_name_pattern"), "Invalid host names",
+			fmt.Sprintf("%d hosts of the Terraform state would be added with a name not matching %q: %s.",
+				len(invalid), model.HostNamePattern.ValueString(), strings.Join(truncate(invalid, 5), ", ")))
+	}
+	return diags
+}
+
+// warnRemovedContents warns when applying the plan would delete many hosts and groups
+// from the inventory, e.g. because they were added by an inventory source.
+func warnRemovedContents(state stateInventoryResourceModel, desired inventoryContents) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	removedHosts := removedKeys(state.Hosts, desired.Hosts)
+	removedGroups := removedKeys(state.Groups, desired.Groups)
+	if len(removedHosts)+len(removedGroups) < removalWarningThreshold {
+		return diags
+	}
+
+	diags.AddWarning(
+		"Inventory contents will be deleted",
+		fmt.Sprintf("Applying this plan deletes %d hosts and %d groups of inventory %q that are not in the Terraform state, including: %s. "+
+			"Hosts and groups added to the inventory outside of Terraform, for instance by an inventory source, are removed as well.",
+			len(removedHosts), len(removedGroups), state.Name.ValueString(),
+			strings.Join(truncate(append(removedHosts, removedGroups...), 5), ", ")),
+	)
+	return diags
+}
+
+// removedKeys returns the sorted keys of the current map that are missing from the desired one.
+func removedKeys[V any](current types.Map, desired map[string]V) []string {
+	var removed []string
+	if current.IsNull() || current.IsUnknown() {
+		return removed
+	}
+	for name := range current.Elements() {
+		if _, ok := desired[name]; !ok {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(removed)
+	return removed
+}
+
+// truncate returns at most n values, followed by an ellipsis when values were dropped.
+func truncate(values []string, n int) []string {
+	if len(values) <= n {
+		return values
+	}
+	return append(values[:n:n], "...")
+}
+
+// desiredContents loads the state source of the model and builds the inventory contents from it.
+func (r *stateInventoryResource) desiredContents(ctx context.Context, model stateInventoryResourceModel) (inventoryContents, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	body, err := model.source().load(ctx, r.client)
+	if err != nil {
+		diags.AddError("Unable to read Terraform state", err.Error())
+		return inventoryContents{}, diags
+	}
+
+	contents, err := inventoryContentsFromState(body)
+	if err != nil {
+		diags.AddError("Unable to parse Terraform state", err.Error())
+		return contents, diags
+	}
+
+	external, d := model.externalGroups(ctx)
+	diags.Append(d...)
+	for _, name := range external {
+		if _, ok := contents.Groups[name]; ok {
+			diags.AddAttributeError(path.Root("external_groups"), "Invalid external group",
+				fmt.Sprintf("Group %q is in the Terraform state, it cannot be managed outside of it.", name))
+		}
+	}
+	return contents, diags
+}
+
+// sentContents adds the sensitive variables of the model to the desired contents.
+func (r *stateInventoryResource) sentContents(ctx context.Context, model stateInventoryResourceModel, desired inventoryContents) (inventoryContents, diag.Diagnostics) {
+	sensitive, diags := model.sensitiveVariables(ctx)
+	if diags.HasError() {
+		return desired, diags
+	}
+	sent, err := sensitive.merged(desired)
+	if err != nil {
+		diags.AddError("Invalid sensitive variables", err.Error())
+	}
+	return sent, diags
+}
+
+// Create creates the inventory and populates it from the configured state.
+func (r *stateInventoryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_state_inventory", "create", &resp.Diagnostics)
+
+	var plan stateInventoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := r.desiredContents(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sent, diags := r.sentContents(ctx, plan, desired)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if plan.ReadOnly.ValueBool() {
+		resp.Diagnostics.Append(r.adoptInventory(&plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	if r.client.CheckExistingNames {
+		existing, err := r.client.GetOrganizationInventoryByName(plan.Organization.ValueInt64(), plan.Name.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddError("Unable to read AAP inventories", err.Error())
+			return
+		}
+		if existing != nil {
+			resp.Diagnostics.Append(alreadyExistsDiagnostics("AAP inventory", existing.Name, strconv.FormatInt(existing.Id, 10))...)
+			return
+		}
+	}
+
+	resp.Diagnostics.Append(r.checkHostLimit(plan.Organization.ValueInt64(), len(sent.Hosts))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inventory, err := r.client.CreateInventory(plan.inventory())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to create AAP inventory", err, stateInventoryAPIFields)...)
+		return
+	}
+	plan.Id = types.Int64Value(inventory.Id)
+
+	// Save the inventory id first so a failed sync does not leak the inventory.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.Id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	external, diags := plan.externalGroups(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := syncInventoryContents(r.client, inventory.Id, sent, external); err != nil {
+		resp.Diagnostics.AddError("Unable to populate AAP inventory", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.readSummary(&plan)...)
+	resp.Diagnostics.Append(r.readContents(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the hosts and groups currently in AAP.
+func (r *stateInventoryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_state_inventory", "read", &resp.Diagnostics)
+
+	var state stateInventoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	inventory, err := r.client.GetInventory(state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read AAP inventory", err.Error())
+		return
+	}
+	if inventory == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.Name = types.StringValue(inventory.Name)
+	state.Organization = types.Int64Value(inventory.Organization)
+	state.Description = types.StringValue(inventory.Description)
+	state.setSummary(inventory)
+	// imported and moved states only hold the inventory, the attributes with defaults take them
+	if state.ReadOnly.IsNull() {
+		state.ReadOnly = types.BoolValue(false)
+	}
+
+	resp.Diagnostics.Append(r.readContents(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update applies inventory changes and reconciles its hosts and groups with the configured state.
+func (r *stateInventoryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_state_inventory", "update", &resp.Diagnostics)
+
+	var plan stateInventoryResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, diags := r.desiredContents(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	sent, diags := r.sentContents(ctx, plan, desired)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the planned changes are recorded without being applied, the next refresh finds the differences again
+	if plan.ReadOnly.ValueBool() {
+		resp.Diagnostics.Append(r.readSummary(&plan)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.AddWarning("AAP inventory not changed",
+			fmt.Sprintf("Inventory %q is read only, the differences between AAP and the Terraform state were not applied "+
+				"and show again on the next plan. Set read_only to false to apply them.", plan.Name.ValueString()))
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	inventory, err := r.client.UpdateInventory(plan.Id.ValueInt64(), plan.inventory())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update AAP inventory", err, stateInventoryAPIFields)...)
+		return
+	}
+	plan.setSummary(inventory)
+
+	var state stateInventoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	current, diags := inventoryContentsFromTerraform(ctx, state.Hosts, state.Groups)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// skip the reconciliation, and the reads it starts with, when the hosts, groups and sensitive variables did not change
+	if current.hash() == desired.hash() &&
+		plan.SensitiveHostVariables.Equal(state.SensitiveHostVariables) &&
+		plan.SensitiveGroupVariables.Equal(state.SensitiveGroupVariables) &&
+		plan.ExternalGroups.Equal(state.ExternalGroups) {
+		plan.Hosts = state.Hosts
+		plan.Groups = state.Groups
+		resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+		return
+	}
+
+	added := 0
+	for name := range desired.Hosts {
+		if _, ok := current.Hosts[name]; !ok {
+			added++
+		}
+	}
+	resp.Diagnostics.Append(r.checkHostLimit(plan.Organization.ValueInt64(), added)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	external, diags := plan.externalGroups(ctx)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if err := syncInventoryContents(r.client, plan.Id.ValueInt64(), sent, external); err != nil {
+		resp.Diagnostics.AddError("Unable to synchronize AAP inventory", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(r.readSummary(&plan)...)
+	resp.Diagnostics.Append(r.readContents(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes the inventory together with its hosts and groups. Read only inventories are only removed from the state.
+func (r *stateInventoryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_state_inventory", "delete", &resp.Diagnostics)
+
+	var state stateInventoryResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.ReadOnly.ValueBool() {
+		return
+	}
+
+	if err := r.client.DeleteInventory(state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete AAP inventory", err.Error())
+	}
+}
+
+// ImportState imports an AAP inventory by id or as Organization/Name. The following Read fills in its hosts, groups,
+// group children and variables, so that the first plan only sets the state source.
+func (r *stateInventoryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	id, err := resolveImportID(req.ID, "AAP inventory", func(name string) (*int64, error) {
+		organization, inventory, err := splitScopedName(name, "AAP inventory")
+		if err != nil {
+			return nil, err
+		}
+		found, err := r.client.GetInventoryByName(organization, inventory)
+		if err != nil || found == nil {
+			return nil, err
+		}
+		return &found.Id, nil
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Invalid import id", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// awxInventoryState holds the attributes of the awx_inventory resource of the community AWX
+// providers, e.g. denouncer/awx, that carry over to an aap_state_inventory.
+type awxInventoryState struct {
+	Id             string `json:"id"`
+	Name           string `json:"name"`
+	OrganizationId int64  `json:"organization_id"`
+	Description    string `json:"description"`
+	Kind           string `json:"kind"`
+}
+
+// MoveState lets moved blocks adopt the inventories managed by the community AWX providers.
+func (r *stateInventoryResource) MoveState(_ context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				if req.SourceTypeName != "awx_inventory" || !strings.HasSuffix(req.SourceProviderAddress, "/awx") {
+					return
+				}
+				if req.SourceRawState == nil {
+					resp.Diagnostics.AddError("Unable to move AWX inventory", "The source state is empty.")
+					return
+				}
+
+				var source awxInventoryState
+				if err := json.Unmarshal(req.SourceRawState.JSON, &source); err != nil {
+					resp.Diagnostics.AddError("Unable to move AWX inventory", err.Error())
+					return
+				}
+				if source.Kind != "" {
+					resp.Diagnostics.AddError("Unable to move AWX inventory",
+						fmt.Sprintf("Inventory %q is a %s inventory, only regular inventories can be moved to aap_state_inventory.", source.Name, source.Kind))
+					return
+				}
+				id, err := strconv.ParseInt(source.Id, 10, 64)
+				if err != nil {
+					resp.Diagnostics.AddError("Unable to move AWX inventory", fmt.Sprintf("Invalid inventory id %q.", source.Id))
+					return
+				}
+
+				// the state source comes from the configuration, hosts and groups are read on the next refresh
+				resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("id"), id)...)
+				resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("name"), source.Name)...)
+				resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("organization"), source.OrganizationId)...)
+				resp.Diagnostics.Append(resp.TargetState.SetAttribute(ctx, path.Root("description"), source.Description)...)
+			},
+		},
+	}
+}
+
+// checkHostLimit fails when adding hosts to the inventories of the organization would exceed its max_hosts,
+// before any host is created. AAP counts hosts sharing a name once, so the check may be stricter than AAP.
+func (r *stateInventoryResource) checkHostLimit(organizationId int64, added int) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if added == 0 {
+		return diags
+	}
+
+	organization, err := r.client.GetOrganizationSettings(organizationId)
+	if err != nil {
+		diags.AddError("Unable to read organization", err.Error())
+		return diags
+	}
+	if organization == nil || organization.MaxHosts == 0 {
+		return diags
+	}
+
+	count, err := r.client.CountOrganizationHosts(organizationId)
+	if err != nil {
+		diags.AddError("Unable to count organization hosts", err.Error())
+		return diags
+	}
+	if count+int64(added) > organization.MaxHosts {
+		diags.AddAttributeError(path.Root("organization"), "Organization host limit exceeded",
+			fmt.Sprintf("Organization %d allows at most %d hosts and has %d; adding the %d hosts of the Terraform state "+
+				"that are not in the inventory yet would exceed max_hosts. Raise the limit of the organization or remove hosts.",
+				organizationId, organization.MaxHosts, count, added))
+	}
+	return diags
+}
+
+// adoptInventory sets the id of the model to the existing inventory of the organization with the same name,
+// which read only inventories are never created in place of.
+func (r *stateInventoryResource) adoptInventory(model *stateInventoryResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	inventory, err := r.client.GetOrganizationInventoryByName(model.Organization.ValueInt64(), model.Name.ValueString())
+	if err != nil {
+		diags.AddError("Unable to read AAP inventories", err.Error())
+		return diags
+	}
+	if inventory == nil {
+		diags.AddAttributeError(path.Root("read_only"), "AAP inventory not found",
+			fmt.Sprintf("Organization %d has no inventory named %q; read only inventories are not created. "+
+				"Set read_only to false to create it.", model.Organization.ValueInt64(), model.Name.ValueString()))
+		return diags
+	}
+	model.Id = types.Int64Value(inventory.Id)
+	model.setSummary(inventory)
+	return diags
+}
+
+// readSummary sets the summary counts and audit fields of the model, which change as hosts and groups are added and removed.
+func (r *stateInventoryResource) readSummary(model *stateInventoryResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	inventory, err := r.client.GetInventory(model.Id.ValueInt64())
+	if err != nil {
+		diags.AddError("Unable to read AAP inventory", err.Error())
+		return diags
+	}
+	if inventory == nil {
+		diags.AddError("Unable to read AAP inventory", fmt.Sprintf("Inventory %s was deleted while it was being populated.", model.Id.String()))
+		return diags
+	}
+	model.setSummary(inventory)
+	return diags
+}
+
+// readContents sets the hosts and groups of the model from the AAP inventory.
+func (r *stateInventoryResource) readContents(ctx context.Context, model *stateInventoryResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	contents, err := readInventoryContents(r.client, model.Id.ValueInt64())
+	if err != nil {
+		diags.AddError("Unable to read AAP inventory hosts and groups", err.Error())
+		return diags
+	}
+
+	sensitive, d := model.sensitiveVariables(ctx)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+	sensitive.strip(contents.inventoryContents)
+
+	// hosts of external groups are left out unless the model already holds them, as they are not deleted
+	external, d := model.externalGroups(ctx)
+	diags.Append(d...)
+	managed := model.Hosts.Elements()
+	for host := range contents.externalHosts(external) {
+		if _, ok := managed[host]; !ok {
+			delete(contents.Hosts, host)
+		}
+	}
+	for _, name := range external {
+		delete(contents.Groups, name)
+	}
+
+	hosts, groups, d := contents.toTerraform(ctx)
+	diags.Append(d...)
+	model.Hosts = hosts
+	model.Groups = groups
+	return diags
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *stateInventoryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	// resources are configured for each operation, the copy counts the requests of one operation
+	r.client = client.withStats()
+}
@@ -0,0 +1,667 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+// testAccStateDocument returns a Terraform state document holding the given ansible resources.
+func testAccStateDocument(t *testing.T, resources ...map[string]any) []byte {
+	t.Helper()
+
+	state := map[string]any{
+		"version":           4,
+		"terraform_version": "1.5.7",
+		"resources":         []any{},
+	}
+	for _, attributes := range resources {
+		resourceType := "ansible_host"
+		if _, ok := attributes["children"]; ok {
+			resourceType = "ansible_group"
+		}
+		state["resources"] = append(state["resources"].([]any), map[string]any{
+			"mode":      "managed",
+			"type":      resourceType,
+			"name":      attributes["name"],
+			"provider":  "provider[\"registry.terraform.io/ansible/ansible\"]",
+			"instances": []any{map[string]any{"attributes": attributes}},
+		})
+	}
+
+	body, err := json.Marshal(state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return body
+}
+
+func testAccWriteState(t *testing.T, path string, resources ...map[string]any) func() {
+	return func() {
+		if err := os.WriteFile(path, testAccStateDocument(t, resources...), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestAccStateInventoryResource(t *testing.T) {
+	providerConfig, client, _ := testAccAAP(t)
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	name := testAccPrefix + "state-inventory"
+
+	config := providerConfig + fmt.Sprintf(`
+resource "aap_state_inventory" "test" {
+  name         = %q
+  organization = %s
+  state_file   = %q
+}
+`, name, testAccOrganization(), stateFile)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		CheckDestroy:             testAccCheckStateInventoryDestroy(client),
+		Steps: []resource.TestStep{
+			{
+				PreConfig: testAccWriteState(t, stateFile,
+					map[string]any{"name": "web1", "groups": []string{"web"}, "variables": map[string]any{"ansible_user": "centos"}},
+					map[string]any{"name": "web2", "groups": []string{"web"}},
+					map[string]any{"name": "db1", "groups": []string{"db"}},
+					map[string]any{"name": "servers", "children": []string{"web", "db"}, "variables": map[string]any{"region": "eu"}},
+				),
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_state_inventory.test", "name", name),
+					resource.TestCheckResourceAttr("aap_state_inventory.test", "hosts.%", "3"),
+					resource.TestCheckResourceAttr("aap_state_inventory.test", "groups.%", "3"),
+					resource.TestCheckResourceAttr("aap_state_inventory.test", "groups.web.hosts.#", "2"),
+					resource.TestCheckResourceAttr("aap_state_inventory.test", "groups.servers.children.#", "2"),
+					resource.TestCheckResourceAttrSet("aap_state_inventory.test", "created"),
+					resource.TestCheckResourceAttrSet("aap_state_inventory.test", "created_by"),
+					testAccCheckStateInventoryContents(client, "aap_state_inventory.test"),
+				),
+			},
+			{
+				PreConfig: testAccWriteState(t, stateFile,
+					map[string]any{"name": "web1", "groups": []string{"web"}, "variables": map[string]any{"ansible_user": "rhel"}},
+					map[string]any{"name": "web3", "groups": []string{"web"}},
+				),
+				Config: config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("aap_state_inventory.test", "hosts.%", "2"),
+					resource.TestCheckResourceAttr("aap_state_inventory.test", "groups.%", "1"),
+					resource.TestCheckResourceAttr("aap_state_inventory.test", "groups.web.hosts.0", "web1"),
+					resource.TestCheckResourceAttr("aap_state_inventory.test", "groups.web.hosts.1", "web3"),
+					testAccCheckStateInventoryContents(client, "aap_state_inventory.test"),
+				),
+			},
+			{
+				ResourceName:            "aap_state_inventory.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"state_file"},
+			},
+		},
+	})
+}
+
+// testAccCheckStateInventoryContents verifies that the hosts and groups in AAP match the resource state.
+func testAccCheckStateInventoryContents(client *AAPClient, address string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[address]
+		if !ok {
+			return fmt.Errorf("%s not found in state", address)
+		}
+
+		id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+		contents, err := readInventoryContents(client, id)
+		if err != nil {
+			return err
+		}
+
+		if hosts := rs.Primary.Attributes["hosts.%"]; fmt.Sprint(len(contents.Hosts)) != hosts {
+			return fmt.Errorf("inventory has %d hosts, expected %s", len(contents.Hosts), hosts)
+		}
+		for name, group := range contents.Groups {
+			for i, host := range group.Hosts {
+				expected := rs.Primary.Attributes[fmt.Sprintf("groups.%s.hosts.%d", name, i)]
+				if host != expected {
+					return fmt.Errorf("group %s has host %q at position %d, expected %q", name, host, i, expected)
+				}
+			}
+			if !slices.Equal(group.Children, testAccAttributeList(rs.Primary.Attributes, "groups."+name+".children")) {
+				return fmt.Errorf("group %s has children %v", name, group.Children)
+			}
+		}
+		return nil
+	}
+}
+
+func testAccAttributeList(attributes map[string]string, prefix string) []string {
+	values := []string{}
+	for i := 0; ; i++ {
+		value, ok := attributes[fmt.Sprintf("%s.%d", prefix, i)]
+		if !ok {
+			return values
+		}
+		values = append(values, value)
+	}
+}
+
+func testAccCheckStateInventoryDestroy(client *AAPClient) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		for _, rs := range s.RootModule().Resources {
+			if rs.Type != "aap_state_inventory" {
+				continue
+			}
+			id, err := strconv.ParseInt(rs.Primary.ID, 10, 64)
+			if err != nil {
+				return err
+			}
+			inventory, err := client.GetInventory(id)
+			if err != nil {
+				return err
+			}
+			if inventory != nil {
+				return fmt.Errorf("inventory %s still exists", rs.Primary.ID)
+			}
+		}
+		return nil
+	}
+}
+
+func TestStateInventoryResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	config := map[string]any{"name": "servers", "organization": organization, "state_file": stateFile}
+
+	p := newTestProvider(t, mock, map[string]any{"token": "secret"})
+	inventory := p.resource("aap_state_inventory")
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}, "variables": map[string]any{"ansible_user": "centos"}},
+		map[string]any{"name": "web2", "groups": []string{"web"}},
+		map[string]any{"name": "db1", "groups": []string{"db"}},
+		map[string]any{"name": "servers", "children": []string{"web", "db"}, "variables": map[string]any{"region": "eu"}},
+	)()
+	state := inventory.apply(config)
+
+	hosts, _ := state["hosts"].(map[string]any)
+	groups, _ := state["groups"].(map[string]any)
+	if len(hosts) != 3 || len(groups) != 3 {
+		t.Fatalf("inventory has hosts %v and groups %v", hosts, groups)
+	}
+	testExpect(t, groups["web"].(map[string]any), map[string]any{"hosts": []any{"web1", "web2"}})
+	testExpect(t, groups["servers"].(map[string]any), map[string]any{"children": []any{"db", "web"}})
+	if state["created_by"] != mockUsername {
+		t.Errorf("created_by = %v", state["created_by"])
+	}
+	if len(mock.inventories) != 1 || len(mock.hosts) != 3 || len(mock.groups) != 3 {
+		t.Errorf("AAP holds %d inventories, %d hosts and %d groups", len(mock.inventories), len(mock.hosts), len(mock.groups))
+	}
+	if changes := inventory.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after apply changes %v", changes)
+	}
+
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}, "variables": map[string]any{"ansible_user": "rhel"}},
+		map[string]any{"name": "web3", "groups": []string{"web"}},
+	)()
+	state = inventory.apply(config)
+	testExpect(t, state["groups"].(map[string]any)["web"].(map[string]any), map[string]any{"hosts": []any{"web1", "web3"}})
+	if len(mock.hosts) != 2 || len(mock.groups) != 1 {
+		t.Errorf("AAP holds %d hosts and %d groups after the update", len(mock.hosts), len(mock.groups))
+	}
+
+	imported := p.resource("aap_state_inventory")
+	importedState := imported.importState(fmt.Sprint(state["id"]))
+	for _, name := range []string{"name", "organization", "hosts", "groups", "total_hosts", "read_only"} {
+		if !reflect.DeepEqual(importedState[name], state[name]) {
+			t.Errorf("imported %s = %v, expected %v", name, importedState[name], state[name])
+		}
+	}
+
+	inventory.destroy()
+	if len(mock.inventories) != 0 || len(mock.hosts) != 0 {
+		t.Errorf("AAP holds %d inventories and %d hosts after destroy", len(mock.inventories), len(mock.hosts))
+	}
+}
+
+func TestStateInventoryResourceReadOnly(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	config := map[string]any{"name": "servers", "organization": organization, "state_file": stateFile, "read_only": true}
+
+	p := newTestProvider(t, mock, nil)
+	inventory := p.resource("aap_state_inventory")
+	testAccWriteState(t, stateFile, map[string]any{"name": "web1", "groups": []string{"web"}})()
+	if message := inventory.tryApply(config); !strings.Contains(message, "AAP inventory not found") {
+		t.Errorf("a read only inventory missing from AAP gives %q", message)
+	}
+	if writes := mock.methodCount(http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete); writes != 0 {
+		t.Errorf("a read only inventory missing from AAP made %d write requests", writes)
+	}
+
+	existing := p.resource("aap_state_inventory")
+	existing.apply(map[string]any{"name": "servers", "organization": organization, "state_file": stateFile})
+	writes := mock.methodCount(http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete)
+
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web2", "groups": []string{"db"}, "variables": map[string]any{"ansible_user": "rhel"}},
+	)()
+	inventory.apply(config)
+	testExpect(t, inventory.read(), map[string]any{"hosts": map[string]any{"web1": map[string]any{"variables": ""}}})
+	testAccWriteState(t, stateFile, map[string]any{"name": "web3"})()
+	inventory.apply(config)
+	if len(inventory.warnings) != 1 || !strings.Contains(inventory.warnings[0], "AAP inventory not changed") {
+		t.Errorf("updating a read only inventory warned %v", inventory.warnings)
+	}
+	inventory.destroy()
+
+	if after := mock.methodCount(http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete); after != writes {
+		t.Errorf("the read only inventory made %d write requests", after-writes)
+	}
+	if len(mock.inventories) != 1 || len(mock.hosts) != 1 || len(mock.groups) != 1 {
+		t.Errorf("AAP holds %d inventories, %d hosts and %d groups", len(mock.inventories), len(mock.hosts), len(mock.groups))
+	}
+}
+
+func TestStateInventoryResourceExternalGroups(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	config := map[string]any{"name": "servers", "organization": organization, "state_file": stateFile, "external_groups": []string{"cloud"}}
+
+	p := newTestProvider(t, mock, nil)
+	inventory := p.resource("aap_state_inventory")
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web", "old"}},
+		map[string]any{"name": "web2", "groups": []string{"old"}},
+		map[string]any{"name": "db1", "groups": []string{"old"}},
+		map[string]any{"name": "old", "children": []string{"web"}},
+		map[string]any{"name": "servers", "children": []string{"old"}},
+	)()
+	state := inventory.apply(config)
+	id := state["id"].(int64)
+
+	// an inventory source adds a group with a host of its own and one of the state
+	ids := map[string]int64{}
+	for _, host := range mock.hosts {
+		ids[host.Name] = host.Id
+	}
+	for _, group := range mock.groups {
+		ids[group.Name] = group.Id
+	}
+	mock.nextId++
+	mock.hosts[mock.nextId] = &AAPHost{Id: mock.nextId, Name: "ec2", Inventory: id}
+	ids["ec2"] = mock.nextId
+	mock.nextId++
+	mock.groups[mock.nextId] = &AAPGroup{Id: mock.nextId, Name: "cloud", Inventory: id}
+	mock.groupHosts[mock.nextId] = []int64{ids["ec2"], ids["web2"]}
+	ids["cloud"] = mock.nextId
+	if changes := inventory.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after the inventory source added hosts changes %v", changes)
+	}
+
+	// deleting old detaches it first, so that AAP does not delete db1 with it
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}},
+		map[string]any{"name": "db1"},
+		map[string]any{"name": "servers", "children": []string{"web"}},
+	)()
+	state = inventory.apply(config)
+	testExpect(t, state, map[string]any{"hosts": map[string]any{"web1": map[string]any{"variables": ""}, "db1": map[string]any{"variables": ""}}})
+	groups, _ := state["groups"].(map[string]any)
+	if len(groups) != 2 {
+		t.Fatalf("groups = %v", groups)
+	}
+	testExpect(t, groups["servers"].(map[string]any), map[string]any{"children": []any{"web"}})
+	testExpect(t, groups["web"].(map[string]any), map[string]any{"hosts": []any{"web1"}})
+	for _, name := range []string{"web1", "db1", "web2", "ec2"} {
+		if host := mock.hosts[ids[name]]; host == nil {
+			t.Errorf("host %s was deleted", name)
+		}
+	}
+	for _, name := range []string{"web", "servers", "cloud"} {
+		if group := mock.groups[ids[name]]; group == nil {
+			t.Errorf("group %s was deleted", name)
+		}
+	}
+	if len(mock.hosts) != 4 || len(mock.groups) != 3 {
+		t.Errorf("AAP holds %d hosts and %d groups", len(mock.hosts), len(mock.groups))
+	}
+	if changes := inventory.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after apply changes %v", changes)
+	}
+}
+
+func TestStateInventoryResourceHostNamePattern(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	config := map[string]any{"name": "servers", "organization": organization, "state_file": stateFile, "host_name_pattern": "["}
+
+	p := newTestProvider(t, mock, nil)
+	inventory := p.resource("aap_state_inventory")
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}},
+		map[string]any{"name": "db1.example.com", "groups": []string{"db"}},
+	)()
+	if message := inventory.tryApply(config); !strings.Contains(message, "Invalid regular expression") {
+		t.Errorf("an invalid host_name_pattern gives %q", message)
+	}
+
+	// the pattern matches whole names
+	config["host_name_pattern"] = "web[0-9]+"
+	if message := inventory.tryApply(config); !strings.Contains(message, "Invalid host names") || !strings.Contains(message, `"db1.example.com"`) || strings.Contains(message, `"web1"`) {
+		t.Errorf("a host not matching host_name_pattern gives %q", message)
+	}
+	if len(mock.inventories) != 0 {
+		t.Errorf("AAP holds %d inventories after the plan failed", len(mock.inventories))
+	}
+
+	config["host_name_pattern"] = `web[0-9]+|([a-z0-9-]+\.)+[a-z]{2,}`
+	inventory.apply(config)
+
+	// hosts already in the inventory are not checked
+	config["host_name_pattern"] = `([a-z0-9-]+\.)+[a-z]{2,}`
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}},
+		map[string]any{"name": "db1.example.com", "groups": []string{"db"}},
+		map[string]any{"name": "db2.example.com", "groups": []string{"db"}},
+	)()
+	inventory.apply(config)
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}},
+		map[string]any{"name": "web2", "groups": []string{"web"}},
+	)()
+	if message := inventory.tryApply(config); !strings.Contains(message, `1 hosts of the Terraform state would be added`) || !strings.Contains(message, `"web2"`) {
+		t.Errorf("a new host not matching host_name_pattern gives %q", message)
+	}
+	if len(mock.hosts) != 3 {
+		t.Errorf("AAP holds %d hosts after the plan failed", len(mock.hosts))
+	}
+
+	// read only inventories never get hosts added
+	config["read_only"] = true
+	inventory.apply(config)
+}
+
+func TestStateInventoryResourceHostLimit(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default", "max_hosts": 3})
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	otherFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+
+	p := newTestProvider(t, mock, nil)
+	other := p.resource("aap_state_inventory")
+	testAccWriteState(t, otherFile, map[string]any{"name": "db1", "groups": []string{"db"}})()
+	other.apply(map[string]any{"name": "databases", "organization": organization, "state_file": otherFile})
+
+	inventory := p.resource("aap_state_inventory")
+	config := map[string]any{"name": "servers", "organization": organization, "state_file": stateFile}
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}},
+		map[string]any{"name": "web2", "groups": []string{"web"}},
+		map[string]any{"name": "web3", "groups": []string{"web"}},
+	)()
+	if message := inventory.tryApply(config); !strings.Contains(message, "Organization host limit exceeded") ||
+		!strings.Contains(message, "allows at most 3 hosts and has 1; adding the 3 hosts") {
+		t.Errorf("exceeding max_hosts gives %q", message)
+	}
+	if len(mock.inventories) != 1 {
+		t.Errorf("AAP holds %d inventories after exceeding max_hosts", len(mock.inventories))
+	}
+
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}},
+		map[string]any{"name": "web2", "groups": []string{"web"}},
+	)()
+	inventory.apply(config)
+
+	// only the hosts that are not in the inventory yet count
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}, "variables": map[string]any{"http_port": 8080}},
+		map[string]any{"name": "web2", "groups": []string{"web"}},
+		map[string]any{"name": "web3", "groups": []string{"web"}},
+	)()
+	if message := inventory.tryApply(config); !strings.Contains(message, "has 3; adding the 1 hosts") {
+		t.Errorf("exceeding max_hosts on update gives %q", message)
+	}
+	if len(mock.hosts) != 3 {
+		t.Errorf("AAP holds %d hosts after exceeding max_hosts", len(mock.hosts))
+	}
+
+	// no limit is set with 0
+	mock.objects["api/v2/organizations"][organization]["max_hosts"] = 0
+	inventory.apply(config)
+	if len(mock.hosts) != 4 {
+		t.Errorf("AAP holds %d hosts without max_hosts", len(mock.hosts))
+	}
+}
+
+func TestStateInventoryResourceRemovalWarning(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	config := map[string]any{"name": "servers", "organization": organization, "state_file": stateFile}
+	hosts := func(n int) []map[string]any {
+		var resources []map[string]any
+		for i := 1; i <= n; i++ {
+			resources = append(resources, map[string]any{"name": fmt.Sprintf("web%d", i)})
+		}
+		return resources
+	}
+
+	p := newTestProvider(t, mock, nil)
+	inventory := p.resource("aap_state_inventory")
+	testAccWriteState(t, stateFile, hosts(12)...)()
+	inventory.apply(config)
+
+	// below the threshold nothing is reported
+	testAccWriteState(t, stateFile, hosts(3)...)()
+	inventory.planChanges(config)
+	if len(inventory.warnings) != 0 {
+		t.Errorf("removing 9 hosts warned %v", inventory.warnings)
+	}
+
+	testAccWriteState(t, stateFile, hosts(2)...)()
+	inventory.planChanges(config)
+	if len(inventory.warnings) != 1 || !strings.Contains(inventory.warnings[0], "deletes 10 hosts and 0 groups") ||
+		!strings.Contains(inventory.warnings[0], "web10, web11, web12, web3, web4, ...") {
+		t.Errorf("removing 10 hosts warned %v", inventory.warnings)
+	}
+
+	// nothing is deleted from read only inventories
+	config["read_only"] = true
+	inventory.planChanges(config)
+	if len(inventory.warnings) != 0 {
+		t.Errorf("planning a read only inventory warned %v", inventory.warnings)
+	}
+}
+
+func TestStateInventoryResourceUnchangedContents(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	config := map[string]any{"name": "servers", "organization": organization, "state_file": stateFile}
+
+	p := newTestProvider(t, mock, nil)
+	inventory := p.resource("aap_state_inventory")
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}},
+		map[string]any{"name": "web2", "groups": []string{"web"}},
+	)()
+	id := inventory.apply(config)["id"].(int64)
+	hostReads := fmt.Sprintf("api/v2/inventories/%d/hosts", id)
+
+	// only the inventory is updated when the hosts and groups did not change
+	reads := mock.requestCount(http.MethodGet, hostReads)
+	writes := mock.methodCount(http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete)
+	config["description"] = "web servers"
+	inventory.apply(config)
+	if mock.methodCount(http.MethodPatch) != 0 || mock.requestCount(http.MethodGet, hostReads) != reads {
+		t.Errorf("updating the description patched %d objects and read the hosts %d times",
+			mock.methodCount(http.MethodPatch), mock.requestCount(http.MethodGet, hostReads)-reads)
+	}
+	if after := mock.methodCount(http.MethodPost, http.MethodPatch, http.MethodPut, http.MethodDelete); after != writes+1 ||
+		mock.requestCount(http.MethodPut, fmt.Sprintf("api/v2/inventories/%d", id)) != 1 {
+		t.Errorf("updating the description made %d write requests, expected the update of the inventory", after-writes)
+	}
+	if changes := inventory.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after updating the description changes %v", changes)
+	}
+
+	testAccWriteState(t, stateFile,
+		map[string]any{"name": "web1", "groups": []string{"web"}, "variables": map[string]any{"http_port": 8080}},
+		map[string]any{"name": "web2", "groups": []string{"web"}},
+	)()
+	inventory.apply(config)
+	if mock.requestCount(http.MethodGet, hostReads) == reads || mock.methodCount(http.MethodPut) != 3 {
+		t.Errorf("changing the variables of a host did not update it")
+	}
+}
+
+func TestStateInventoryResourcePlaybookHost(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	stateFile := filepath.Join(t.TempDir(), "terraform.tfstate")
+	config := map[string]any{"name": "servers", "organization": organization, "state_file": stateFile}
+
+	// the playbook targets the host declared before it, adding its groups without resetting its variables
+	document := `{"version": 4, "resources": [
+		{"type": "ansible_host", "name": "db", "instances": [{"attributes": {"name": "db1", "groups": ["db"], "variables": {"ansible_user": "postgres"}}}]},
+		{"type": "ansible_playbook", "name": "deploy", "instances": [{"attributes": {"name": "db1", "groups": ["deploy"], "playbook": "deploy.yml"}}]}
+	]}`
+	if err := os.WriteFile(stateFile, []byte(document), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	p := newTestProvider(t, mock, nil)
+	inventory := p.resource("aap_state_inventory")
+	state := inventory.apply(config)
+	groups, _ := state["groups"].(map[string]any)
+	testExpect(t, groups, map[string]any{
+		"db":     map[string]any{"hosts": []any{"db1"}, "children": []any{}, "variables": ""},
+		"deploy": map[string]any{"hosts": []any{"db1"}, "children": []any{}, "variables": ""},
+	})
+	if len(mock.hosts) != 1 {
+		t.Fatalf("AAP holds %d hosts, expected the host once", len(mock.hosts))
+	}
+	for _, host := range mock.hosts {
+		if !strings.Contains(host.Variables, "postgres") {
+			t.Errorf("host variables = %q, expected the variables of the ansible_host", host.Variables)
+		}
+	}
+	if changes := inventory.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after apply changes %v", changes)
+	}
+}
+
+// testVariables decodes a JSON variables document like decodeVariables does.
+func testVariables(t *testing.T, document string) map[string]interface{} {
+	t.Helper()
+	variables, err := decodeVariables(document)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return variables
+}
+
+func TestMergeVariables(t *testing.T) {
+	tests := []struct {
+		name   string
+		dst    string
+		src    string
+		merged string
+	}{
+		{name: "new keys", dst: `{"a":1}`, src: `{"b":2}`, merged: `{"a":1,"b":2}`},
+		{name: "replaced value", dst: `{"a":1,"b":2}`, src: `{"a":"x"}`, merged: `{"a":"x","b":2}`},
+		{name: "nested objects", dst: `{"db":{"host":"db1","auth":{"user":"app"}}}`, src: `{"db":{"port":5432,"auth":{"password":"secret"}}}`,
+			merged: `{"db":{"auth":{"password":"secret","user":"app"},"host":"db1","port":5432}}`},
+		{name: "lists are replaced", dst: `{"ports":[80,443],"db":{"hosts":["db1"]}}`, src: `{"ports":[8080],"db":{"hosts":[]}}`,
+			merged: `{"db":{"hosts":[]},"ports":[8080]}`},
+		{name: "object replaces value", dst: `{"db":"db1"}`, src: `{"db":{"host":"db1"}}`, merged: `{"db":{"host":"db1"}}`},
+		{name: "value replaces object", dst: `{"db":{"host":"db1"}}`, src: `{"db":null}`, merged: `{"db":null}`},
+		{name: "empty source", dst: `{"a":1}`, src: ``, merged: `{"a":1}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dst := testVariables(t, test.dst)
+			mergeVariables(dst, testVariables(t, test.src))
+			if merged, _ := json.Marshal(dst); string(merged) != test.merged {
+				t.Errorf("merged = %s, expected %s", merged, test.merged)
+			}
+		})
+	}
+}
+
+func TestStripVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		dst      string
+		src      string
+		stripped string
+	}{
+		{name: "top level keys", dst: `{"a":1,"b":2}`, src: `{"b":"x"}`, stripped: `{"a":1}`},
+		{name: "nested keys", dst: `{"db":{"host":"db1","password":"secret"}}`, src: `{"db":{"password":"secret"}}`, stripped: `{"db":{"host":"db1"}}`},
+		{name: "emptied objects", dst: `{"a":1,"db":{"auth":{"password":"secret"}}}`, src: `{"db":{"auth":{"password":"secret"}}}`, stripped: `{"a":1}`},
+		{name: "lists", dst: `{"ports":[8080],"a":1}`, src: `{"ports":[80]}`, stripped: `{"a":1}`},
+		{name: "missing keys", dst: `{"a":1}`, src: `{"b":{"c":2}}`, stripped: `{"a":1}`},
+		{name: "object over value", dst: `{"db":"db1"}`, src: `{"db":{"host":"db1"}}`, stripped: `{}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			dst := testVariables(t, test.dst)
+			stripVariables(dst, testVariables(t, test.src))
+			if stripped, _ := json.Marshal(dst); string(stripped) != test.stripped {
+				t.Errorf("stripped = %s, expected %s", stripped, test.stripped)
+			}
+		})
+	}
+}
+
+func TestSensitiveVariablesRoundTrip(t *testing.T) {
+	tests := []struct {
+		name      string
+		variables string
+		sensitive string
+	}{
+		{name: "new keys", variables: `{"http_port":8080}`, sensitive: `{"password":"secret"}`},
+		{name: "no variables", variables: "", sensitive: `{"db":{"password":"secret"}}`},
+		{name: "nested key", variables: `{"db":{"host":"db1","port":5432}}`, sensitive: `{"db":{"password":"secret"}}`},
+		{name: "deeply nested key", variables: `{"db":{"auth":{"user":"app"},"host":"db1"}}`, sensitive: `{"db":{"auth":{"password":"secret"}}}`},
+		{name: "only nested key", variables: `{"db":{"auth":{"user":"app"}}}`, sensitive: `{"db":{"auth":{"password":"secret"},"token":"t"}}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var sensitive map[string]interface{}
+			if err := json.Unmarshal([]byte(test.sensitive), &sensitive); err != nil {
+				t.Fatal(err)
+			}
+			merged, err := mergeVariablesDocument(test.variables, sensitive)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if stripped := stripVariablesDocument(merged, sensitive); stripped != test.variables {
+				t.Errorf("stripping %s gives %q, expected %q", merged, stripped, test.variables)
+			}
+		})
+	}
+}
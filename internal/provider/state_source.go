@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// stateSourceModel holds the attributes describing where a Terraform state document is read from.
+// Exactly one of them is expected to be set.
+type stateSourceModel struct {
+	StateFile    types.String `tfsdk:"state_file"`
+	StateURL     types.String `tfsdk:"state_url"`
+	StateId      types.Int64  `tfsdk:"state_id"`
+	TFEWorkspace types.Object `tfsdk:"tfe_workspace"`
+}
+
+// tfeWorkspaceModel maps the tfe_workspace attribute.
+type tfeWorkspaceModel struct {
+	Hostname     types.String `tfsdk:"hostname"`
+	Organization types.String `tfsdk:"organization"`
+	Name         types.String `tfsdk:"name"`
+	Token        types.String `tfsdk:"token"`
+}
+
+// tfeWorkspace returns the tfe_workspace attribute as a model.
+func (s stateSourceModel) tfeWorkspace(ctx context.Context) (tfeWorkspaceModel, error) {
+	var workspace tfeWorkspaceModel
+	diags := s.TFEWorkspace.As(ctx, &workspace, basetypes.ObjectAsOptions{})
+	if diags.HasError() {
+		return workspace, fmt.Errorf("invalid tfe_workspace: %v", diags)
+	}
+	return workspace, nil
+}
+
+// isUnknown reports whether any of the source attributes is not yet known, e.g. during plan.
+func (s stateSourceModel) isUnknown() bool {
+	if s.StateFile.IsUnknown() || s.StateURL.IsUnknown() || s.StateId.IsUnknown() || s.TFEWorkspace.IsUnknown() {
+		return true
+	}
+	for _, value := range s.TFEWorkspace.Attributes() {
+		if value.IsUnknown() {
+			return true
+		}
+	}
+	return false
+}
+
+// count returns the number of source attributes that are set.
+func (s stateSourceModel) count() int {
+	count := 0
+	for _, isNull := range []bool{s.StateFile.IsNull(), s.StateURL.IsNull(), s.StateId.IsNull(), s.TFEWorkspace.IsNull()} {
+		if !isNull {
+			count++
+		}
+	}
+	return count
+}
+
+// load reads the raw state document from whichever source is configured. Remote sources are fetched
+// with the CA and proxy settings of the provider, but insecure_skip_verify only applies to AAP.
+func (s stateSourceModel) load(ctx context.Context, client *AAPClient) ([]byte, error) {
+	switch {
+	case !s.StateFile.IsNull():
+		return os.ReadFile(s.StateFile.ValueString())
+	case !s.StateURL.IsNull():
+		return fetchStateURL(ctx, client.externalHTTPClient(), s.StateURL.ValueString())
+	case !s.StateId.IsNull():
+		return client.GetState(s.StateId.ValueInt64())
+	case !s.TFEWorkspace.IsNull():
+		workspace, err := s.tfeWorkspace(ctx)
+		if err != nil {
+			return nil, err
+		}
+		tfe, err := NewTFEClient(workspace.Hostname.ValueString(), workspace.Token.ValueString())
+		if err != nil {
+			return nil, err
+		}
+		tfe.HTTPClient = client.externalHTTPClient()
+		return tfe.GetCurrentState(ctx, workspace.Organization.ValueString(), workspace.Name.ValueString())
+	}
+	return nil, fmt.Errorf("no state source configured")
+}
+
+// fetchStateURL downloads a state document from a remote backend URL, e.g. a Terraform http backend.
+func fetchStateURL(ctx context.Context, httpClient *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("status: %d, body: %s", resp.StatusCode, body)
+	}
+	return body, nil
+}
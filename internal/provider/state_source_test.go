@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestStateSourceTransport(t *testing.T) {
+	document := testAccStateDocument(t, map[string]any{"name": "web1", "groups": []string{"web"}})
+	var server *httptest.Server
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/v2/") && r.Header.Get("Authorization") != "Bearer tfe-token" {
+			writeJSON(w, http.StatusUnauthorized, nil)
+			return
+		}
+		switch r.URL.Path {
+		case "/state":
+			_, _ = w.Write(document)
+		case "/api/v2/organizations/acme/workspaces/servers":
+			writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"id": "ws-1"}})
+		case "/api/v2/workspaces/ws-1/current-state-version":
+			writeJSON(w, http.StatusOK, map[string]any{"data": map[string]any{"attributes": map[string]any{"hosted-state-download-url": server.URL + "/state"}}})
+		default:
+			writeJSON(w, http.StatusNotFound, nil)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	urlConfig := map[string]any{"name": "from url", "organization": organization, "state_url": server.URL + "/state"}
+	workspaceConfig := map[string]any{"name": "from workspace", "organization": organization, "tfe_workspace": map[string]any{
+		"hostname": strings.TrimPrefix(server.URL, "https://"), "organization": "acme", "name": "servers", "token": "tfe-token",
+	}}
+
+	// the certificate of the server is only trusted through the CA settings of the provider,
+	// insecure_skip_verify only applies to AAP
+	for name, settings := range map[string]map[string]any{
+		"no settings":          nil,
+		"insecure_skip_verify": {"insecure_skip_verify": true},
+	} {
+		p := newTestProvider(t, mock, settings)
+		for _, config := range []map[string]any{urlConfig, workspaceConfig} {
+			if message := p.resource("aap_state_inventory").tryApply(config); !strings.Contains(message, "certificate") {
+				t.Errorf("with %s, reading the state of %s from an untrusted server gives %q", name, config["name"], message)
+			}
+		}
+	}
+
+	certificate := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+	p := newTestProvider(t, mock, map[string]any{"ca_cert_pem": certificate})
+	for _, config := range []map[string]any{urlConfig, workspaceConfig} {
+		inventory := p.resource("aap_state_inventory")
+		state := inventory.apply(config)
+		if hosts, _ := state["hosts"].(map[string]any); len(hosts) != 1 {
+			t.Errorf("with ca_cert_pem, the state of %s gives hosts %v", config["name"], state["hosts"])
+		}
+		inventory.destroy()
+	}
+}
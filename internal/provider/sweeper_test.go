@@ -0,0 +1,61 @@
+package provider
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+// TestMain runs the sweepers when the tests are invoked with -sweep, e.g.
+// go test ./internal/provider -v -sweep=all
+func TestMain(m *testing.M) {
+	resource.TestMain(m)
+}
+
+func init() {
+	resource.AddTestSweepers("aap_job_template", &resource.Sweeper{
+		Name: "aap_job_template",
+		F:    sweepObjects("api/v2/job_templates/"),
+	})
+	resource.AddTestSweepers("aap_host", &resource.Sweeper{
+		Name: "aap_host",
+		F:    sweepObjects("api/v2/hosts/"),
+	})
+	resource.AddTestSweepers("aap_group", &resource.Sweeper{
+		Name: "aap_group",
+		F:    sweepObjects("api/v2/groups/"),
+	})
+	resource.AddTestSweepers("aap_inventory", &resource.Sweeper{
+		Name:         "aap_inventory",
+		F:            sweepObjects("api/v2/inventories/"),
+		Dependencies: []string{"aap_job_template", "aap_host", "aap_group"},
+	})
+}
+
+// sweepObjects returns a sweeper deleting the objects of the collection at endpoint
+// whose name starts with the prefix of acceptance tests.
+func sweepObjects(endpoint string) func(string) error {
+	return func(_ string) error {
+		client := testAccEnvClient()
+		if client == nil {
+			return fmt.Errorf("AAP_HOST must be set to run the sweepers")
+		}
+
+		objects, err := listAll[struct {
+			Id   int64  `json:"id"`
+			Name string `json:"name"`
+		}](client, endpoint+"?name__startswith="+url.QueryEscape(testAccPrefix))
+		if err != nil {
+			return err
+		}
+
+		for _, object := range objects {
+			if err := client.deleteObject(objectEndpoint(endpoint, object.Id)); err != nil {
+				return fmt.Errorf("unable to sweep %s: %w", object.Name, err)
+			}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,350 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"golang.org/x/sync/errgroup"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &teamRolesResource{}
+	_ resource.ResourceWithConfigure = &teamRolesResource{}
+)
+
+// teamRoleResourceTypes are the kinds of controller objects whose roles can be granted to a team.
+var teamRoleResourceTypes = []string{"organization", "project", "inventory", "credential", "job_template", "workflow_job_template", "instance_group"}
+
+// NewTeamRolesResource is a helper function to simplify the provider implementation.
+func NewTeamRolesResource() resource.Resource {
+	return &teamRolesResource{}
+}
+
+// teamRolesResource grants the same roles of many controller objects to a team.
+type teamRolesResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *teamRolesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_team_roles"
+}
+
+// Schema defines the schema for the resource.
+func (r *teamRolesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Grants a set of roles of many automation controller objects of the same type to a team, e.g. the execute and read " +
+			"roles of dozens of job templates. Every role is granted to the team on its own; other roles of the team are left untouched.",
+		Attributes: map[string]schema.Attribute{
+			"team_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the controller team.",
+				Validators:  idValidators(),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"resource_type": schema.StringAttribute{
+				Required: true,
+				Description: "Type of the objects: organization, project, inventory, credential, job_template, " +
+					"workflow_job_template or instance_group.",
+				Validators: []validator.String{stringvalidator.OneOf(teamRoleResourceTypes...)},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"resource_ids": schema.SetAttribute{
+				ElementType: types.Int64Type,
+				Required:    true,
+				Description: "Ids of the objects whose roles are granted.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueInt64sAre(idValidators()...),
+				},
+			},
+			"roles": schema.SetAttribute{
+				ElementType: types.StringType,
+				Required:    true,
+				Description: "Roles granted on every object, e.g. admin, execute, read, use or update. Each object must have all of them.",
+				Validators: []validator.Set{
+					setvalidator.SizeAtLeast(1),
+					setvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`^[a-z_]+$`),
+						"must be the name of a role in lowercase, e.g. execute")),
+				},
+			},
+		},
+	}
+}
+
+// teamRolesResourceModel maps the resource schema data.
+type teamRolesResourceModel struct {
+	TeamId       types.Int64  `tfsdk:"team_id"`
+	ResourceType types.String `tfsdk:"resource_type"`
+	ResourceIds  []int64      `tfsdk:"resource_ids"`
+	Roles        []string     `tfsdk:"roles"`
+}
+
+// expandRoles returns the ids of the roles of the model, keyed by object id. Objects that no longer exist are left out.
+func (r *teamRolesResource) expandRoles(model teamRolesResourceModel) (map[int64][]int64, error) {
+	kind := model.ResourceType.ValueString()
+
+	// the roles of an object are only found in its details, fetch them concurrently
+	objectRoles := make([]map[string]AAPRole, len(model.ResourceIds))
+	var g errgroup.Group
+	g.SetLimit(r.client.parallelism())
+	for i, id := range model.ResourceIds {
+		i, id := i, id
+		g.Go(func() (err error) {
+			objectRoles[i], err = r.client.GetObjectRoles(kind, id)
+			return err
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return nil, err
+	}
+
+	expanded := make(map[int64][]int64)
+	for i, id := range model.ResourceIds {
+		if objectRoles[i] == nil {
+			continue
+		}
+		for _, name := range model.Roles {
+			role, ok := objectRoles[i][name+"_role"]
+			if !ok {
+				return nil, fmt.Errorf("%s %d has no %s role", kind, id, name)
+			}
+			expanded[id] = append(expanded[id], role.Id)
+		}
+	}
+	return expanded, nil
+}
+
+// teamRoleIds returns the ids of the roles granted to the team.
+func (r *teamRolesResource) teamRoleIds(teamId int64) (map[int64]bool, error) {
+	roles, err := r.client.GetTeamRoles(teamId)
+	if err != nil {
+		return nil, err
+	}
+	granted := make(map[int64]bool, len(roles))
+	for _, role := range roles {
+		granted[role.Id] = true
+	}
+	return granted, nil
+}
+
+// setRoles grants the desired roles to the team and revokes the prior ones that are no longer desired.
+func (r *teamRolesResource) setRoles(teamId int64, desired map[int64][]int64, prior map[int64][]int64) error {
+	granted, err := r.teamRoleIds(teamId)
+	if err != nil {
+		return err
+	}
+
+	want := make(map[int64]bool)
+	for _, roles := range desired {
+		for _, role := range roles {
+			want[role] = true
+		}
+	}
+	changes := make(map[int64]bool)
+	for _, roles := range prior {
+		for _, role := range roles {
+			if !want[role] && granted[role] {
+				changes[role] = true
+			}
+		}
+	}
+	for role := range want {
+		if !granted[role] {
+			changes[role] = false
+		}
+	}
+
+	var g errgroup.Group
+	g.SetLimit(r.client.parallelism())
+	for role, revoke := range changes {
+		role, revoke := role, revoke
+		g.Go(func() error {
+			if revoke {
+				return r.client.DisassociateTeamRole(teamId, role)
+			}
+			return r.client.AssociateTeamRole(teamId, role)
+		})
+	}
+	return g.Wait()
+}
+
+// desiredRoles expands the roles of the model, failing when an object does not exist.
+func (r *teamRolesResource) desiredRoles(model teamRolesResourceModel) (map[int64][]int64, error) {
+	expanded, err := r.expandRoles(model)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range model.ResourceIds {
+		if _, ok := expanded[id]; !ok {
+			return nil, fmt.Errorf("%s %d does not exist", model.ResourceType.ValueString(), id)
+		}
+	}
+	return expanded, nil
+}
+
+// Create grants the roles to the team.
+func (r *teamRolesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_team_roles", "create", &resp.Diagnostics)
+	var plan teamRolesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, err := r.desiredRoles(plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read roles", err.Error())
+		return
+	}
+	if err := r.setRoles(plan.TeamId.ValueInt64(), desired, nil); err != nil {
+		resp.Diagnostics.AddError("Unable to grant team roles", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read keeps the objects whose roles are all still granted to the team, so that missing grants show as changes.
+func (r *teamRolesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_team_roles", "read", &resp.Diagnostics)
+	var state teamRolesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	team, err := r.client.GetTeam(state.TeamId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read team", err.Error())
+		return
+	}
+	if team == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	expanded, err := r.expandRoles(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read roles", err.Error())
+		return
+	}
+	granted, err := r.teamRoleIds(state.TeamId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read team roles", err.Error())
+		return
+	}
+
+	var kept []int64
+	for _, id := range state.ResourceIds {
+		roles, ok := expanded[id]
+		if !ok {
+			continue
+		}
+		all := true
+		for _, role := range roles {
+			all = all && granted[role]
+		}
+		if all {
+			kept = append(kept, id)
+		}
+	}
+	if len(kept) == 0 {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.ResourceIds = kept
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update grants the roles of the added objects and revokes those of the removed ones.
+func (r *teamRolesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_team_roles", "update", &resp.Diagnostics)
+	var plan, state teamRolesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	desired, err := r.desiredRoles(plan)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read roles", err.Error())
+		return
+	}
+	prior, err := r.expandRoles(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read roles", err.Error())
+		return
+	}
+	if err := r.setRoles(plan.TeamId.ValueInt64(), desired, prior); err != nil {
+		resp.Diagnostics.AddError("Unable to grant team roles", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete revokes the roles from the team.
+func (r *teamRolesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_team_roles", "delete", &resp.Diagnostics)
+	var state teamRolesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the roles are gone along with the team
+	team, err := r.client.GetTeam(state.TeamId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read team", err.Error())
+		return
+	}
+	if team == nil {
+		return
+	}
+
+	prior, err := r.expandRoles(state)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read roles", err.Error())
+		return
+	}
+	if err := r.setRoles(state.TeamId.ValueInt64(), nil, prior); err != nil {
+		resp.Diagnostics.AddError("Unable to revoke team roles", err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *teamRolesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTeamRolesResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	team := mock.addObject("api/v2/teams", map[string]any{"name": "operators", "organization": organization})
+	// addTemplate adds a job template with its execute and read roles
+	addTemplate := func(name string) (int64, int64, int64) {
+		execute := mock.addObject("api/v2/roles", map[string]any{"name": "Execute"})
+		read := mock.addObject("api/v2/roles", map[string]any{"name": "Read"})
+		template := mock.addObject("api/v2/job_templates", map[string]any{"name": name, "organization": organization,
+			"summary_fields": map[string]any{"object_roles": map[string]any{
+				"execute_role": map[string]any{"id": execute, "name": "Execute"},
+				"read_role":    map[string]any{"id": read, "name": "Read"},
+			}},
+		})
+		return template, execute, read
+	}
+	deploy, deployExecute, deployRead := addTemplate("Deploy")
+	rollback, rollbackExecute, rollbackRead := addTemplate("Rollback")
+	other := mock.addObject("api/v2/roles", map[string]any{"name": "Admin"})
+	mock.members[fmt.Sprintf("api/v2/teams/%d/roles", team)] = []int64{other}
+
+	p := newTestProvider(t, mock, nil)
+	roles := p.resource("aap_team_roles")
+	message := roles.tryApply(map[string]any{"team_id": team, "resource_type": "job_template", "resource_ids": []any{deploy}, "roles": []any{"admin"}})
+	if !strings.Contains(message, "has no admin role") {
+		t.Errorf("granting a role the job template does not have gives %q", message)
+	}
+
+	config := map[string]any{"team_id": team, "resource_type": "job_template", "resource_ids": []any{deploy, rollback}, "roles": []any{"execute", "read"}}
+	roles.apply(config)
+	granted := mock.related("api/v2/teams", team, "roles")
+	slices.Sort(granted)
+	if expected := []int64{deployExecute, deployRead, rollbackExecute, rollbackRead, other}; !slices.Equal(granted, expected) {
+		t.Errorf("team has roles %v, expected %v", granted, expected)
+	}
+
+	roles.read()
+	if changes := roles.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["resource_ids"] = []any{rollback}
+	roles.apply(config)
+	granted = mock.related("api/v2/teams", team, "roles")
+	slices.Sort(granted)
+	if expected := []int64{rollbackExecute, rollbackRead, other}; !slices.Equal(granted, expected) {
+		t.Errorf("team has roles %v after removing deploy, expected %v", granted, expected)
+	}
+
+	roles.destroy()
+	if granted := mock.related("api/v2/teams", team, "roles"); !slices.Equal(granted, []int64{other}) {
+		t.Errorf("team has roles %v after destroy, expected the role granted elsewhere", granted)
+	}
+
+	// inventories are read from the inventories collection
+	mock.nextId++
+	inventory := mock.nextId
+	mock.inventories[inventory] = &AAPInventory{Id: inventory, Name: "servers", Organization: organization}
+	use := mock.addObject("api/v2/roles", map[string]any{"name": "Use"})
+	mock.inventoryRoles[inventory] = map[string]any{"use_role": map[string]any{"id": use, "name": "Use"}}
+	inventoryRoles := p.resource("aap_team_roles")
+	inventoryConfig := map[string]any{"team_id": team, "resource_type": "inventory", "resource_ids": []any{inventory}, "roles": []any{"use"}}
+	inventoryRoles.apply(inventoryConfig)
+	granted = mock.related("api/v2/teams", team, "roles")
+	slices.Sort(granted)
+	if expected := []int64{other, use}; !slices.Equal(granted, expected) {
+		t.Errorf("team has roles %v after granting the inventory role, expected %v", granted, expected)
+	}
+	inventoryRoles.read()
+	if changes := inventoryRoles.planChanges(inventoryConfig); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+}
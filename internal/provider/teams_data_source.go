@@ -0,0 +1,172 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &teamsDataSource{}
+	_ datasource.DataSourceWithConfigure = &teamsDataSource{}
+)
+
+// NewTeamsDataSource is a helper function to simplify the provider implementation.
+func NewTeamsDataSource() datasource.DataSource {
+	return &teamsDataSource{}
+}
+
+// teamsDataSource lists the controller teams matching filters.
+type teamsDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *teamsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_teams"
+}
+
+// Schema defines the schema for the data source.
+func (d *teamsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the automation controller teams visible to the provider credentials, ordered by name, " +
+			"e.g. to grant roles to existing teams with for_each.",
+		Attributes: map[string]schema.Attribute{
+			"organization_id": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Only list the teams of this organization.",
+				Validators:  idValidators(),
+			},
+			"name_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the teams whose name starts with this prefix.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"search": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the teams matching this search term of the AAP API, looked up in names and descriptions.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Number of teams requested at once, from 1 to %d (the default).", listPageSize),
+				Validators:  []validator.Int64{int64validator.Between(1, listPageSize)},
+			},
+			"max_results": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of teams listed; all of them are listed when omitted.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ids": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Ids of the teams, in the order of teams.",
+			},
+			"teams": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Teams, ordered by name.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the team.",
+						},
+						"name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Name of the team.",
+						},
+						"description": schema.StringAttribute{
+							Computed:    true,
+							Description: "Description of the team.",
+						},
+						"organization_id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the organization of the team.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// teamsDataSourceModel maps the data source schema data.
+type teamsDataSourceModel struct {
+	OrganizationId types.Int64  `tfsdk:"organization_id"`
+	NamePrefix     types.String `tfsdk:"name_prefix"`
+	Search         types.String `tfsdk:"search"`
+	PageSize       types.Int64  `tfsdk:"page_size"`
+	MaxResults     types.Int64  `tfsdk:"max_results"`
+	Ids            []int64      `tfsdk:"ids"`
+	Teams          []teamModel  `tfsdk:"teams"`
+}
+
+// teamModel maps a team.
+type teamModel struct {
+	Id             types.Int64  `tfsdk:"id"`
+	Name           types.String `tfsdk:"name"`
+	Description    types.String `tfsdk:"description"`
+	OrganizationId types.Int64  `tfsdk:"organization_id"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *teamsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_teams", "read", &resp.Diagnostics)
+	var state teamsDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := listQuery(state.PageSize, map[string]attr.Value{
+		"organization":     state.OrganizationId,
+		"name__startswith": state.NamePrefix,
+		"search":           state.Search,
+	})
+	teams, err := d.client.GetTeams(query, int(state.MaxResults.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read teams", err.Error())
+		return
+	}
+
+	state.Ids = make([]int64, len(teams))
+	state.Teams = make([]teamModel, len(teams))
+	for i, team := range teams {
+		state.Ids[i] = team.Id
+		state.Teams[i] = teamModel{
+			Id:             types.Int64Value(team.Id),
+			Name:           types.StringValue(team.Name),
+			Description:    types.StringValue(team.Description),
+			OrganizationId: types.Int64Value(team.Organization),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *teamsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
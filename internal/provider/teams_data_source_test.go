@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestTeamsDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	defaultOrganization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	salesOrganization := mock.addObject("api/v2/organizations", map[string]any{"name": "Sales"})
+	operators := mock.addObject("api/v2/teams", map[string]any{"name": "operators", "description": "On-call operators", "organization": defaultOrganization})
+	auditors := mock.addObject("api/v2/teams", map[string]any{"name": "auditors", "description": "", "organization": defaultOrganization})
+	accounts := mock.addObject("api/v2/teams", map[string]any{"name": "accounts", "description": "", "organization": salesOrganization})
+
+	p := newTestProvider(t, mock, nil)
+	state := p.readDataSource("aap_teams", nil)
+	testExpect(t, state, map[string]any{"ids": []any{accounts, auditors, operators}})
+	if teams, _ := state["teams"].([]any); len(teams) != 3 {
+		t.Fatalf("teams = %v, expected every team", state["teams"])
+	} else {
+		testExpect(t, teams[2].(map[string]any), map[string]any{
+			"id": operators, "name": "operators", "description": "On-call operators", "organization_id": defaultOrganization,
+		})
+	}
+
+	testExpect(t, p.readDataSource("aap_teams", map[string]any{"organization_id": defaultOrganization}), map[string]any{"ids": []any{auditors, operators}})
+	testExpect(t, p.readDataSource("aap_teams", map[string]any{"name_prefix": "a"}), map[string]any{"ids": []any{accounts, auditors}})
+	testExpect(t, p.readDataSource("aap_teams", map[string]any{"search": "on-call"}), map[string]any{"ids": []any{operators}})
+	testExpect(t, p.readDataSource("aap_teams", map[string]any{"page_size": 1, "max_results": 1}), map[string]any{"ids": []any{accounts}})
+}
@@ -0,0 +1,236 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource              = &templateCopyResource{}
+	_ resource.ResourceWithConfigure = &templateCopyResource{}
+)
+
+// NewTemplateCopyResource is a helper function to simplify the provider implementation.
+func NewTemplateCopyResource() resource.Resource {
+	return &templateCopyResource{}
+}
+
+// templateCopyResource manages a copy of a job or workflow job template.
+type templateCopyResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *templateCopyResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_template_copy"
+}
+
+// Schema defines the schema for the resource.
+func (r *templateCopyResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Copies a job template or workflow job template, with its survey, workflow nodes and related objects, " +
+			"into a new template, e.g. a variant per environment. Later changes to the source template are not copied; " +
+			"destroying the resource deletes the copy.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Computed:    true,
+				Description: "Id of the copy.",
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"template_type": schema.StringAttribute{
+				Required:    true,
+				Description: "job_template or workflow_job_template. Changing it recreates the copy.",
+				Validators:  []validator.String{stringvalidator.OneOf("job_template", "workflow_job_template")},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"source_template_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the template to copy. Changing it recreates the copy.",
+				Validators:  idValidators(),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "Name of the copy.",
+				Validators:  nameValidators(),
+			},
+			"description": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Description: "Description of the copy, defaults to the description of the source template.",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// templateCopyResourceModel maps the resource schema data.
+type templateCopyResourceModel struct {
+	Id               types.Int64  `tfsdk:"id"`
+	TemplateType     types.String `tfsdk:"template_type"`
+	SourceTemplateId types.Int64  `tfsdk:"source_template_id"`
+	Name             types.String `tfsdk:"name"`
+	Description      types.String `tfsdk:"description"`
+}
+
+// templateCopyAPIFields maps the fields of the template API to the attributes they are set from.
+var templateCopyAPIFields = map[string]string{
+	"name":        "name",
+	"description": "description",
+}
+
+func (m *templateCopyResourceModel) template() AAPTemplate {
+	template := AAPTemplate{Name: m.Name.ValueString()}
+	if !m.Description.IsNull() && !m.Description.IsUnknown() {
+		template.Description = m.Description.ValueStringPointer()
+	}
+	return template
+}
+
+func (m *templateCopyResourceModel) setTemplate(template *AAPTemplate) {
+	m.Id = types.Int64Value(template.Id)
+	m.Name = types.StringValue(template.Name)
+	description := ""
+	if template.Description != nil {
+		description = *template.Description
+	}
+	m.Description = types.StringValue(description)
+}
+
+// Create copies the source template and renames the copy.
+func (r *templateCopyResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_template_copy", "create", &resp.Diagnostics)
+	var plan templateCopyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind := plan.TemplateType.ValueString()
+	source, err := r.client.GetTemplate(kind, plan.SourceTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read template", err.Error())
+		return
+	}
+	if source == nil {
+		resp.Diagnostics.AddAttributeError(path.Root("source_template_id"), "Template not found",
+			fmt.Sprintf("There is no %s with id %s.", kind, plan.SourceTemplateId.String()))
+		return
+	}
+
+	template, err := r.client.CopyTemplate(kind, plan.SourceTemplateId.ValueInt64(), plan.Name.ValueString())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to copy template", err, templateCopyAPIFields)...)
+		return
+	}
+	plan.Id = types.Int64Value(template.Id)
+
+	// Save the copy first so that a failed update does not leak it.
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), plan.Id)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err = r.client.UpdateTemplate(kind, plan.Id.ValueInt64(), plan.template())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update template copy", err, templateCopyAPIFields)...)
+		return
+	}
+	plan.setTemplate(template)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *templateCopyResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_template_copy", "read", &resp.Diagnostics)
+	var state templateCopyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := r.client.GetTemplate(state.TemplateType.ValueString(), state.Id.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read template copy", err.Error())
+		return
+	}
+	if template == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+	state.setTemplate(template)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update renames the copy or changes its description.
+func (r *templateCopyResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_template_copy", "update", &resp.Diagnostics)
+	var plan templateCopyResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	template, err := r.client.UpdateTemplate(plan.TemplateType.ValueString(), plan.Id.ValueInt64(), plan.template())
+	if err != nil {
+		resp.Diagnostics.Append(apiErrorDiagnostics("Unable to update template copy", err, templateCopyAPIFields)...)
+		return
+	}
+	plan.setTemplate(template)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete deletes the copy.
+func (r *templateCopyResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_template_copy", "delete", &resp.Diagnostics)
+	var state templateCopyResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.client.DeleteTemplate(state.TemplateType.ValueString(), state.Id.ValueInt64()); err != nil {
+		resp.Diagnostics.AddError("Unable to delete template copy", err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *templateCopyResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
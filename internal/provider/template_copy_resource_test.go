@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestTemplateCopyResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	source := mock.addObject("api/v2/job_templates", map[string]any{
+		"name": "Deploy", "description": "Deploys the application", "organization": organization, "playbook": "deploy.yml",
+	})
+
+	p := newTestProvider(t, mock, nil)
+	template := p.resource("aap_template_copy")
+	config := map[string]any{"template_type": "job_template", "source_template_id": source, "name": "Deploy staging"}
+	state := template.apply(config)
+	testExpect(t, state, map[string]any{"description": "Deploys the application"})
+	id := state["id"].(int64)
+	testExpect(t, mock.object("api/v2/job_templates", id), map[string]any{"name": "Deploy staging", "playbook": "deploy.yml"})
+
+	template.read()
+	if changes := template.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	config["description"] = "Deploys to staging"
+	template.apply(config)
+	testExpect(t, mock.object("api/v2/job_templates", id), map[string]any{"description": "Deploys to staging"})
+	testExpect(t, mock.object("api/v2/job_templates", source), map[string]any{"name": "Deploy", "description": "Deploys the application"})
+
+	template.destroy()
+	if mock.object("api/v2/job_templates", id) != nil {
+		t.Errorf("template copy %d still exists after destroy", id)
+	}
+	if mock.object("api/v2/job_templates", source) == nil {
+		t.Error("destroying the copy deleted the source template")
+	}
+}
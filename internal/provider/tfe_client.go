@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// defaultTFEHostname is the hostname of HCP Terraform.
+const defaultTFEHostname string = "app.terraform.io"
+
+// TFEClient reads state versions from HCP Terraform or Terraform Enterprise.
+type TFEClient struct {
+	Hostname string
+	Token    string
+	// HTTPClient sends the requests, http.DefaultClient when nil.
+	HTTPClient *http.Client
+}
+
+// NewTFEClient creates a client for the given hostname. When token is empty it falls back to
+// the TF_TOKEN_<hostname> variable used by the Terraform CLI, then to TFE_TOKEN.
+func NewTFEClient(hostname string, token string) (*TFEClient, error) {
+	if hostname == "" {
+		hostname = defaultTFEHostname
+	}
+	if token == "" {
+		token = os.Getenv("TF_TOKEN_" + strings.NewReplacer(".", "_", "-", "__").Replace(hostname))
+	}
+	if token == "" {
+		token = os.Getenv("TFE_TOKEN")
+	}
+	if token == "" {
+		return nil, fmt.Errorf("no API token for %s, set the token attribute or the TFE_TOKEN environment variable", hostname)
+	}
+
+	return &TFEClient{
+		Hostname: hostname,
+		Token:    token,
+	}, nil
+}
+
+// tfe JSON:API document
+type tfeDocument struct {
+	Data struct {
+		Id         string                 `json:"id"`
+		Attributes map[string]interface{} `json:"attributes"`
+	} `json:"data"`
+}
+
+// get sends an authenticated GET request and returns the response body.
+func (c *TFEClient) get(ctx context.Context, target string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Accept", "application/vnd.api+json")
+
+	httpClient := c.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s returned status: %d, body: %s", target, resp.StatusCode, body)
+	}
+	return body, nil
+}
+
+func (c *TFEClient) getDocument(ctx context.Context, endpoint string) (*tfeDocument, error) {
+	body, err := c.get(ctx, "https://"+c.Hostname+"/api/v2/"+endpoint)
+	if err != nil {
+		return nil, err
+	}
+	var document tfeDocument
+	if err := json.Unmarshal(body, &document); err != nil {
+		return nil, err
+	}
+	return &document, nil
+}
+
+// GetCurrentState downloads the latest state version of the named workspace.
+func (c *TFEClient) GetCurrentState(ctx context.Context, organization string, workspace string) ([]byte, error) {
+	ws, err := c.getDocument(ctx, "organizations/"+url.PathEscape(organization)+"/workspaces/"+url.PathEscape(workspace))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read workspace %s/%s: %w", organization, workspace, err)
+	}
+
+	stateVersion, err := c.getDocument(ctx, "workspaces/"+url.PathEscape(ws.Data.Id)+"/current-state-version")
+	if err != nil {
+		return nil, fmt.Errorf("unable to read current state version of workspace %s/%s: %w", organization, workspace, err)
+	}
+
+	downloadURL, ok := stateVersion.Data.Attributes["hosted-state-download-url"].(string)
+	if !ok || downloadURL == "" {
+		return nil, fmt.Errorf("current state version of workspace %s/%s has no download URL", organization, workspace)
+	}
+
+	return c.get(ctx, downloadURL)
+}
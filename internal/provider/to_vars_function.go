@@ -0,0 +1,148 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var _ function.Function = &toVarsFunction{}
+
+// NewToVarsFunction is a helper function to simplify the provider implementation.
+func NewToVarsFunction() function.Function {
+	return &toVarsFunction{}
+}
+
+// toVarsFunction renders an HCL object as an AAP variables document.
+type toVarsFunction struct{}
+
+// Metadata returns the function name.
+func (f *toVarsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "to_vars"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *toVarsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render Ansible variables",
+		Description: "Converts an object or map into the variables document AAP stores for inventories, hosts, groups and templates. " +
+			"Keys are sorted, numbers and booleans keep their type and an empty or null object renders as an empty string, " +
+			"so the result compares equal to the variables read back from AAP.",
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:           "variables",
+				Description:    "Object or map of variables; values may be nested objects, lists, numbers, booleans or strings.",
+				AllowNullValue: true,
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+// Run renders the variables.
+func (f *toVarsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var variables types.Dynamic
+	resp.Error = req.Arguments.Get(ctx, &variables)
+	if resp.Error != nil {
+		return
+	}
+
+	if variables.IsUnknown() {
+		resp.Error = function.NewArgumentFuncError(0, "variables must be known")
+		return
+	}
+
+	var rendered string
+	if !variables.IsNull() && !variables.IsUnderlyingValueNull() {
+		value, err := variables.UnderlyingValue().ToTerraformValue(ctx)
+		if err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+		if !value.Type().Is(tftypes.Object{}) && !value.Type().Is(tftypes.Map{}) {
+			resp.Error = function.NewArgumentFuncError(0, "variables must be an object or a map")
+			return
+		}
+		decoded, err := variablesValue(value)
+		if err != nil {
+			resp.Error = function.NewArgumentFuncError(0, err.Error())
+			return
+		}
+		rendered, err = encodeVariables(decoded.(map[string]interface{}))
+		if err != nil {
+			resp.Error = function.NewFuncError(err.Error())
+			return
+		}
+	}
+
+	resp.Error = resp.Result.Set(ctx, rendered)
+}
+
+// variablesValue converts a Terraform value into the value json.Marshal renders for AAP.
+// Numbers are kept as json.Number so that integers never gain a fractional part.
+func variablesValue(value tftypes.Value) (interface{}, error) {
+	if value.IsNull() {
+		return nil, nil
+	}
+	if !value.IsFullyKnown() {
+		return nil, fmt.Errorf("variables must be known")
+	}
+
+	typ := value.Type()
+	switch {
+	case typ.Is(tftypes.String):
+		var s string
+		err := value.As(&s)
+		return s, err
+	case typ.Is(tftypes.Bool):
+		var b bool
+		err := value.As(&b)
+		return b, err
+	case typ.Is(tftypes.Number):
+		n := new(big.Float)
+		if err := value.As(&n); err != nil {
+			return nil, err
+		}
+		if n.IsInt() {
+			i, _ := n.Int(nil)
+			return json.Number(i.String()), nil
+		}
+		return json.Number(n.Text('g', -1)), nil
+	case typ.Is(tftypes.Object{}) || typ.Is(tftypes.Map{}):
+		var elements map[string]tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		result := make(map[string]interface{}, len(elements))
+		for key, element := range elements {
+			v, err := variablesValue(element)
+			if err != nil {
+				return nil, err
+			}
+			result[key] = v
+		}
+		return result, nil
+	case typ.Is(tftypes.List{}) || typ.Is(tftypes.Set{}) || typ.Is(tftypes.Tuple{}):
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil, err
+		}
+		result := make([]interface{}, len(elements))
+		for i, element := range elements {
+			v, err := variablesValue(element)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = v
+		}
+		return result, nil
+	default:
+		return nil, fmt.Errorf("unsupported variables value of type %s", typ)
+	}
+}
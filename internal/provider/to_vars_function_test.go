@@ -0,0 +1,123 @@
+package provider
+
+import (
+	"context"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testRunFunction runs the function with the arguments and returns its result, or the error it returned.
+func testRunFunction(t *testing.T, f function.Function, arguments ...attr.Value) (attr.Value, *function.FuncError) {
+	t.Helper()
+	ctx := context.Background()
+
+	var definition function.DefinitionResponse
+	f.Definition(ctx, function.DefinitionRequest{}, &definition)
+	result, err := definition.Definition.Return.NewResultData(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resp := function.RunResponse{Result: result}
+	f.Run(ctx, function.RunRequest{Arguments: function.NewArgumentsData(arguments)}, &resp)
+	if resp.Error != nil {
+		return nil, resp.Error
+	}
+	return resp.Result.Value(), nil
+}
+
+// testDynamicValue returns the value as a dynamic argument receives it.
+func testDynamicValue(t *testing.T, value tftypes.Value) attr.Value {
+	t.Helper()
+	dynamic, err := types.DynamicType.ValueFromTerraform(context.Background(), value)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dynamic
+}
+
+func TestToVarsFunction(t *testing.T) {
+	object := func(attributes map[string]tftypes.Value) tftypes.Value {
+		types := map[string]tftypes.Type{}
+		for name, attribute := range attributes {
+			types[name] = attribute.Type()
+		}
+		return tftypes.NewValue(tftypes.Object{AttributeTypes: types}, attributes)
+	}
+	number := func(n float64) tftypes.Value { return tftypes.NewValue(tftypes.Number, n) }
+	str := func(s string) tftypes.Value { return tftypes.NewValue(tftypes.String, s) }
+
+	tests := []struct {
+		name      string
+		variables tftypes.Value
+		rendered  string
+		err       string
+	}{
+		{
+			name: "numbers",
+			variables: object(map[string]tftypes.Value{
+				"port":     number(8080),
+				"count":    number(0),
+				"negative": number(-3),
+				"ratio":    number(0.5),
+				// above 2^53, float64 would round it
+				"big": tftypes.NewValue(tftypes.Number, new(big.Float).SetInt64(9007199254740993)),
+			}),
+			rendered: `{"big":9007199254740993,"count":0,"negative":-3,"port":8080,"ratio":0.5}`,
+		},
+		{
+			name: "nested",
+			variables: object(map[string]tftypes.Value{
+				"db": object(map[string]tftypes.Value{
+					"host":  str("db1"),
+					"ports": tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{number(5432), number(5433)}),
+				}),
+				"tags":    tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{str("web")}),
+				"mixed":   tftypes.NewValue(tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.String, tftypes.Bool, tftypes.Number}}, []tftypes.Value{str("x"), tftypes.NewValue(tftypes.Bool, true), number(1)}),
+				"enabled": tftypes.NewValue(tftypes.Bool, false),
+				"unset":   tftypes.NewValue(tftypes.String, nil),
+			}),
+			rendered: `{"db":{"host":"db1","ports":[5432,5433]},"enabled":false,"mixed":["x",true,1],"tags":["web"],"unset":null}`,
+		},
+		{
+			name:      "map",
+			variables: tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{"b": str("2"), "a": str("1")}),
+			rendered:  `{"a":"1","b":"2"}`,
+		},
+		{name: "null", variables: tftypes.NewValue(tftypes.DynamicPseudoType, nil), rendered: ""},
+		{name: "null map", variables: tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, nil), rendered: ""},
+		{name: "empty object", variables: object(map[string]tftypes.Value{}), rendered: ""},
+		{name: "string", variables: str(`{"a":1}`), err: "variables must be an object or a map"},
+		{name: "list", variables: tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{str("a")}), err: "variables must be an object or a map"},
+		{name: "unknown", variables: tftypes.NewValue(tftypes.DynamicPseudoType, tftypes.UnknownValue), err: "variables must be known"},
+		{
+			name:      "unknown attribute",
+			variables: object(map[string]tftypes.Value{"a": str("1"), "b": tftypes.NewValue(tftypes.String, tftypes.UnknownValue)}),
+			err:       "variables must be known",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result, err := testRunFunction(t, NewToVarsFunction(), testDynamicValue(t, test.variables))
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Text, test.err) || err.FunctionArgument == nil || *err.FunctionArgument != 0 {
+					t.Fatalf("error = %v, expected %q for the variables argument", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if rendered := result.(types.String).ValueString(); rendered != test.rendered {
+				t.Errorf("rendered = %s, expected %s", rendered, test.rendered)
+			}
+		})
+	}
+}
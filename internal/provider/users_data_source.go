@@ -0,0 +1,203 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &usersDataSource{}
+	_ datasource.DataSourceWithConfigure = &usersDataSource{}
+)
+
+// NewUsersDataSource is a helper function to simplify the provider implementation.
+func NewUsersDataSource() datasource.DataSource {
+	return &usersDataSource{}
+}
+
+// usersDataSource lists the controller users matching filters.
+type usersDataSource struct {
+	client *AAPClient
+}
+
+// Metadata returns the data source type name.
+func (d *usersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+// Schema defines the schema for the data source.
+func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Lists the automation controller users visible to the provider credentials, ordered by username, " +
+			"e.g. to grant roles to existing users with for_each.",
+		Attributes: map[string]schema.Attribute{
+			"username_prefix": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the users whose username starts with this prefix.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"search": schema.StringAttribute{
+				Optional:    true,
+				Description: "Only list the users matching this search term of the AAP API, looked up in usernames, names and emails.",
+				Validators:  []validator.String{stringvalidator.LengthAtLeast(1)},
+			},
+			"is_superuser": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list the superusers when true, or the other users when false.",
+			},
+			"ldap_sourced": schema.BoolAttribute{
+				Optional:    true,
+				Description: "Only list the users created from LDAP when true, or the other users when false.",
+			},
+			"page_size": schema.Int64Attribute{
+				Optional:    true,
+				Description: fmt.Sprintf("Number of users requested at once, from 1 to %d (the default).", listPageSize),
+				Validators:  []validator.Int64{int64validator.Between(1, listPageSize)},
+			},
+			"max_results": schema.Int64Attribute{
+				Optional:    true,
+				Description: "Maximum number of users listed; all of them are listed when omitted.",
+				Validators:  []validator.Int64{int64validator.AtLeast(1)},
+			},
+			"ids": schema.ListAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Ids of the users, in the order of users.",
+			},
+			"users": schema.ListNestedAttribute{
+				Computed:    true,
+				Description: "Users, ordered by username.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Computed:    true,
+							Description: "Id of the user.",
+						},
+						"username": schema.StringAttribute{
+							Computed:    true,
+							Description: "Username of the user.",
+						},
+						"first_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "First name of the user.",
+						},
+						"last_name": schema.StringAttribute{
+							Computed:    true,
+							Description: "Last name of the user.",
+						},
+						"email": schema.StringAttribute{
+							Computed:    true,
+							Description: "Email address of the user.",
+						},
+						"is_superuser": schema.BoolAttribute{
+							Computed:    true,
+							Description: "Whether the user is a superuser.",
+						},
+						"ldap_dn": schema.StringAttribute{
+							Computed:    true,
+							Description: "Distinguished name of the user in LDAP, empty for users not created from LDAP.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// usersDataSourceModel maps the data source schema data.
+type usersDataSourceModel struct {
+	UsernamePrefix types.String `tfsdk:"username_prefix"`
+	Search         types.String `tfsdk:"search"`
+	IsSuperuser    types.Bool   `tfsdk:"is_superuser"`
+	LdapSourced    types.Bool   `tfsdk:"ldap_sourced"`
+	PageSize       types.Int64  `tfsdk:"page_size"`
+	MaxResults     types.Int64  `tfsdk:"max_results"`
+	Ids            []int64      `tfsdk:"ids"`
+	Users          []userModel  `tfsdk:"users"`
+}
+
+// userModel maps a user.
+type userModel struct {
+	Id          types.Int64  `tfsdk:"id"`
+	Username    types.String `tfsdk:"username"`
+	FirstName   types.String `tfsdk:"first_name"`
+	LastName    types.String `tfsdk:"last_name"`
+	Email       types.String `tfsdk:"email"`
+	IsSuperuser types.Bool   `tfsdk:"is_superuser"`
+	LdapDn      types.String `tfsdk:"ldap_dn"`
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	defer d.client.reportStats(ctx, "aap_users", "read", &resp.Diagnostics)
+	var state usersDataSourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	query := listQuery(state.PageSize, map[string]attr.Value{
+		"username__startswith": state.UsernamePrefix,
+		"search":               state.Search,
+		"is_superuser":         state.IsSuperuser,
+	})
+	query.Set("order_by", "username")
+	// users created from LDAP are the ones with a distinguished name
+	if !state.LdapSourced.IsNull() {
+		if state.LdapSourced.ValueBool() {
+			query.Set("not__ldap_dn", "")
+		} else {
+			query.Set("ldap_dn", "")
+		}
+	}
+	users, err := d.client.GetUsers(query, int(state.MaxResults.ValueInt64()))
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read users", err.Error())
+		return
+	}
+
+	state.Ids = make([]int64, len(users))
+	state.Users = make([]userModel, len(users))
+	for i, user := range users {
+		state.Ids[i] = user.Id
+		state.Users[i] = userModel{
+			Id:          types.Int64Value(user.Id),
+			Username:    types.StringValue(user.Username),
+			FirstName:   types.StringValue(user.FirstName),
+			LastName:    types.StringValue(user.LastName),
+			Email:       types.StringValue(user.Email),
+			IsSuperuser: types.BoolValue(user.IsSuperuser),
+			LdapDn:      types.StringValue(user.LdapDn),
+		}
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *usersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client.withStats()
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+)
+
+func TestUsersDataSource(t *testing.T) {
+	mock := newMockAAP(t)
+	admin := mock.addObject("api/v2/users", map[string]any{"username": "admin", "is_superuser": true})
+	jdoe := mock.addObject("api/v2/users", map[string]any{
+		"username": "jdoe", "first_name": "Jane", "last_name": "Doe", "email": "jane.doe@example.com",
+		"ldap_dn": "uid=jdoe,ou=people,dc=example,dc=com",
+	})
+	jsmith := mock.addObject("api/v2/users", map[string]any{"username": "jsmith", "first_name": "John", "email": "john.smith@example.com"})
+
+	p := newTestProvider(t, mock, nil)
+	state := p.readDataSource("aap_users", nil)
+	testExpect(t, state, map[string]any{"ids": []any{admin, jdoe, jsmith}})
+	if users, _ := state["users"].([]any); len(users) != 3 {
+		t.Fatalf("users = %v, expected every user", state["users"])
+	} else {
+		testExpect(t, users[0].(map[string]any), map[string]any{"username": "admin", "is_superuser": true, "ldap_dn": ""})
+		testExpect(t, users[1].(map[string]any), map[string]any{
+			"id": jdoe, "username": "jdoe", "first_name": "Jane", "last_name": "Doe", "email": "jane.doe@example.com",
+			"is_superuser": false, "ldap_dn": "uid=jdoe,ou=people,dc=example,dc=com",
+		})
+	}
+
+	testExpect(t, p.readDataSource("aap_users", map[string]any{"username_prefix": "js"}), map[string]any{"ids": []any{jsmith}})
+	testExpect(t, p.readDataSource("aap_users", map[string]any{"search": "example.com"}), map[string]any{"ids": []any{jdoe, jsmith}})
+	testExpect(t, p.readDataSource("aap_users", map[string]any{"is_superuser": true}), map[string]any{"ids": []any{admin}})
+	testExpect(t, p.readDataSource("aap_users", map[string]any{"is_superuser": false}), map[string]any{"ids": []any{jdoe, jsmith}})
+	testExpect(t, p.readDataSource("aap_users", map[string]any{"ldap_sourced": true}), map[string]any{"ids": []any{jdoe}})
+	testExpect(t, p.readDataSource("aap_users", map[string]any{"ldap_sourced": false}), map[string]any{"ids": []any{admin, jsmith}})
+	testExpect(t, p.readDataSource("aap_users", map[string]any{"page_size": 1, "max_results": 2}), map[string]any{"ids": []any{admin, jdoe}})
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+const (
+	// maxNameLength is the length of the name column of AAP objects.
+	maxNameLength = 512
+	// maxUsernameLength is the length of the username column of gateway users.
+	maxUsernameLength = 150
+)
+
+// scpLikeURL matches the scp-like syntax git accepts for ssh remotes, e.g. git@github.com:ansible/ansible.git.
+var scpLikeURL = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^/].*$`)
+
+// nameValidators validates the name of an AAP object.
+func nameValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.LengthBetween(1, maxNameLength),
+	}
+}
+
+// usernameValidators validates the username of a gateway user.
+func usernameValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.LengthBetween(1, maxUsernameLength),
+	}
+}
+
+// idValidators validates the numeric id of an AAP object.
+func idValidators() []validator.Int64 {
+	return []validator.Int64{
+		int64validator.AtLeast(1),
+	}
+}
+
+// hrefValidators validates a pulp href, the identifier of Automation Hub objects.
+func hrefValidators() []validator.String {
+	return []validator.String{
+		stringvalidator.RegexMatches(
+			regexp.MustCompile(`^/(.+/)?pulp/api/v3/.+/$`),
+			"must be a pulp href, e.g. /api/galaxy/pulp/api/v3/groups/1/",
+		),
+	}
+}
+
+// urlValidators validates an absolute URL with one of the given schemes.
+func urlValidators(schemes ...string) []validator.String {
+	return []validator.String{
+		urlValidator{schemes: schemes},
+	}
+}
+
+// scmURLValidators validates the URL of a source control repository, including scp-like ssh URLs.
+func scmURLValidators() []validator.String {
+	return []validator.String{
+		urlValidator{schemes: []string{"http", "https", "ssh", "git", "file"}, allowSCP: true},
+	}
+}
+
+// durationValidators validates a positive duration, e.g. 30m or 1h30m.
+func durationValidators() []validator.String {
+	return []validator.String{
+		durationValidator{},
+	}
+}
+
+// timestampValidators validates an RFC 3339 time, e.g. 2024-05-01T00:00:00Z.
+func timestampValidators() []validator.String {
+	return []validator.String{
+		timestampValidator{},
+	}
+}
+
+// regexpValidators validates a regular expression in the RE2 syntax of Go.
+func regexpValidators() []validator.String {
+	return []validator.String{
+		regexpValidator{},
+	}
+}
+
+// urlValidator checks that a string is an absolute URL with an allowed scheme.
+type urlValidator struct {
+	schemes  []string
+	allowSCP bool
+}
+
+var _ validator.String = urlValidator{}
+
+// Description describes the validation in plain text formatting.
+func (v urlValidator) Description(_ context.Context) string {
+	return fmt.Sprintf("value must be a URL with one of the schemes %q", v.schemes)
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v urlValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v urlValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if v.allowSCP && scpLikeURL.MatchString(value) {
+		return
+	}
+
+	u, err := url.Parse(value)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL", fmt.Sprintf("%q is not a valid URL: %s", value, err))
+		return
+	}
+	if !slices.Contains(v.schemes, u.Scheme) || (u.Host == "" && u.Scheme != "file") {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid URL",
+			fmt.Sprintf("%q must be an absolute URL with one of the schemes %q.", value, v.schemes))
+	}
+}
+
+// timestampValidator checks that a string is an RFC 3339 time.
+type timestampValidator struct{}
+
+var _ validator.String = timestampValidator{}
+
+// Description describes the validation in plain text formatting.
+func (v timestampValidator) Description(_ context.Context) string {
+	return "value must be an RFC 3339 time, e.g. 2024-05-01T00:00:00Z"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v timestampValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v timestampValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, err := time.Parse(time.RFC3339, value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid time",
+			fmt.Sprintf("%q must be an RFC 3339 time, e.g. 2024-05-01T00:00:00Z.", value))
+	}
+}
+
+// durationValidator checks that a string is a positive Go duration.
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+// Description describes the validation in plain text formatting.
+func (v durationValidator) Description(_ context.Context) string {
+	return "value must be a positive duration, e.g. 30m or 1h30m"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v durationValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if duration, err := time.ParseDuration(value); err != nil || duration <= 0 {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration",
+			fmt.Sprintf("%q must be a positive duration, e.g. 30m or 1h30m.", value))
+	}
+}
+
+// regexpValidator checks that a string is a valid regular expression.
+type regexpValidator struct{}
+
+var _ validator.String = regexpValidator{}
+
+// Description describes the validation in plain text formatting.
+func (v regexpValidator) Description(_ context.Context) string {
+	return "value must be a regular expression"
+}
+
+// MarkdownDescription describes the validation in Markdown formatting.
+func (v regexpValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+// ValidateString performs the validation.
+func (v regexpValidator) ValidateString(_ context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	value := req.ConfigValue.ValueString()
+	if _, err := regexp.Compile(value); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid regular expression",
+			fmt.Sprintf("%q is not a valid regular expression: %s", value, err))
+	}
+}
@@ -0,0 +1,27 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// optionalStringPointer returns the value sent to the API for an optional string attribute.
+// Empty strings are sent as null, which is how the API stores an unset value.
+func optionalStringPointer(value types.String) *string {
+	if value.ValueString() == "" {
+		return nil
+	}
+	return value.ValueStringPointer()
+}
+
+// optionalString returns the value of an optional string attribute read back from the API.
+// The API reports an unset value as null or as an empty string depending on the object, so
+// the prior value is kept when both mean unset and either spelling plans without a diff.
+func optionalString(value *string, prior types.String) types.String {
+	if value == nil || *value == "" {
+		if prior.ValueString() == "" && !prior.IsUnknown() {
+			return prior
+		}
+		return types.StringNull()
+	}
+	return types.StringValue(*value)
+}
@@ -0,0 +1,260 @@
+package provider
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// variablesSchemaKeywords are the JSON Schema keywords variables documents can be validated with. Annotations are
+// accepted and ignored; any other keyword is rejected rather than silently not enforced.
+var variablesSchemaKeywords = map[string]bool{
+	"type": true, "properties": true, "required": true, "additionalProperties": true, "items": true,
+	"enum": true, "const": true, "pattern": true, "minimum": true, "maximum": true,
+	"minLength": true, "maxLength": true, "minItems": true, "maxItems": true,
+	"$schema": false, "$id": false, "$comment": false, "title": false, "description": false, "default": false, "examples": false,
+}
+
+// variablesSchema is the subset of JSON Schema that variables documents are validated with at plan time.
+type variablesSchema struct {
+	Type                 variablesSchemaTypes        `json:"type"`
+	Properties           map[string]*variablesSchema `json:"properties"`
+	Required             []string                    `json:"required"`
+	AdditionalProperties *variablesSchema            `json:"additionalProperties"`
+	Items                *variablesSchema            `json:"items"`
+	Enum                 []json.RawMessage           `json:"enum"`
+	Const                json.RawMessage             `json:"const"`
+	Pattern              string                      `json:"pattern"`
+	Minimum              *float64                    `json:"minimum"`
+	Maximum              *float64                    `json:"maximum"`
+	MinLength            *int                        `json:"minLength"`
+	MaxLength            *int                        `json:"maxLength"`
+	MinItems             *int                        `json:"minItems"`
+	MaxItems             *int                        `json:"maxItems"`
+
+	pattern *regexp.Regexp
+	// never is set for the false schema, which no value matches
+	never bool
+}
+
+// variablesSchemaTypes holds the value of the type keyword, a type name or a list of them.
+type variablesSchemaTypes []string
+
+func (t *variablesSchemaTypes) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		var name string
+		if err := json.Unmarshal(data, &name); err != nil {
+			return fmt.Errorf("type must be a type name or a list of them")
+		}
+		names = []string{name}
+	}
+	for _, name := range names {
+		switch name {
+		case "string", "number", "integer", "boolean", "object", "array", "null":
+		default:
+			return fmt.Errorf("unknown type %q", name)
+		}
+	}
+	*t = names
+	return nil
+}
+
+func (s *variablesSchema) UnmarshalJSON(data []byte) error {
+	switch string(bytes.TrimSpace(data)) {
+	case "true":
+		*s = variablesSchema{}
+		return nil
+	case "false":
+		*s = variablesSchema{never: true}
+		return nil
+	}
+
+	var keywords map[string]json.RawMessage
+	if err := json.Unmarshal(data, &keywords); err != nil {
+		return fmt.Errorf("a schema must be a JSON object or a boolean")
+	}
+	for keyword := range keywords {
+		if _, ok := variablesSchemaKeywords[keyword]; !ok {
+			return fmt.Errorf("keyword %q is not supported", keyword)
+		}
+	}
+
+	// the alias has the fields of the schema without this method
+	type schema variablesSchema
+	var decoded schema
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*s = variablesSchema(decoded)
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern %q: %w", s.Pattern, err)
+		}
+		s.pattern = pattern
+	}
+	return nil
+}
+
+// parseVariablesSchema parses a JSON Schema document describing variables documents.
+func parseVariablesSchema(raw string) (*variablesSchema, error) {
+	var schema variablesSchema
+	if err := json.Unmarshal([]byte(raw), &schema); err != nil {
+		return nil, err
+	}
+	return &schema, nil
+}
+
+// validateDocument returns the violations of the schema by a JSON variables document, sorted by location.
+// Values are never quoted in violations as they may be secrets.
+func (s *variablesSchema) validateDocument(document string) ([]string, error) {
+	variables, err := decodeVariables(document)
+	if err != nil {
+		return nil, err
+	}
+	var violations []string
+	s.validate(variables, "", &violations)
+	sort.Strings(violations)
+	return violations, nil
+}
+
+// validate appends the violations of the schema by a value decoded with decodeVariables found at location.
+func (s *variablesSchema) validate(value any, location string, violations *[]string) {
+	report := func(format string, args ...any) {
+		at := location
+		if at == "" {
+			at = "variables"
+		}
+		*violations = append(*violations, at+": "+fmt.Sprintf(format, args...))
+	}
+
+	if s.never {
+		report("is not allowed")
+		return
+	}
+	if len(s.Type) > 0 && !matchesVariablesSchemaType(value, s.Type) {
+		report("must be of type %s", strings.Join(s.Type, " or "))
+		return
+	}
+	if len(s.Enum) > 0 {
+		found := false
+		for _, allowed := range s.Enum {
+			found = found || jsonValuesEqual(allowed, value)
+		}
+		if !found {
+			report("must be one of the values listed in enum")
+		}
+	}
+	if len(s.Const) > 0 && !jsonValuesEqual(s.Const, value) {
+		report("must be equal to const")
+	}
+
+	switch value := value.(type) {
+	case string:
+		length := utf8.RuneCountInString(value)
+		if s.MinLength != nil && length < *s.MinLength {
+			report("must be at least %d characters long", *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			report("must be at most %d characters long", *s.MaxLength)
+		}
+		if s.pattern != nil && !s.pattern.MatchString(value) {
+			report("must match pattern %q", s.Pattern)
+		}
+	case json.Number:
+		number, _ := value.Float64()
+		if s.Minimum != nil && number < *s.Minimum {
+			report("must be at least %v", *s.Minimum)
+		}
+		if s.Maximum != nil && number > *s.Maximum {
+			report("must be at most %v", *s.Maximum)
+		}
+	case []any:
+		if s.MinItems != nil && len(value) < *s.MinItems {
+			report("must have at least %d items", *s.MinItems)
+		}
+		if s.MaxItems != nil && len(value) > *s.MaxItems {
+			report("must have at most %d items", *s.MaxItems)
+		}
+		if s.Items != nil {
+			for i, item := range value {
+				s.Items.validate(item, fmt.Sprintf("%s[%d]", location, i), violations)
+			}
+		}
+	case map[string]any:
+		for _, key := range s.Required {
+			if _, ok := value[key]; !ok {
+				report("missing required key %q", key)
+			}
+		}
+		for _, key := range sortedKeys(value) {
+			at := key
+			if location != "" {
+				at = location + "." + key
+			}
+			if property, ok := s.Properties[key]; ok {
+				property.validate(value[key], at, violations)
+			} else if s.AdditionalProperties != nil {
+				if s.AdditionalProperties.never {
+					report("unexpected key %q", key)
+				} else {
+					s.AdditionalProperties.validate(value[key], at, violations)
+				}
+			}
+		}
+	}
+}
+
+// matchesVariablesSchemaType reports whether a value decoded with decodeVariables has one of the types.
+func matchesVariablesSchemaType(value any, names []string) bool {
+	for _, name := range names {
+		switch value := value.(type) {
+		case nil:
+			if name == "null" {
+				return true
+			}
+		case bool:
+			if name == "boolean" {
+				return true
+			}
+		case string:
+			if name == "string" {
+				return true
+			}
+		case json.Number:
+			number, err := value.Float64()
+			if name == "number" || (name == "integer" && err == nil && number == math.Trunc(number)) {
+				return true
+			}
+		case []any:
+			if name == "array" {
+				return true
+			}
+		case map[string]any:
+			if name == "object" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// jsonValuesEqual reports whether a JSON value of the schema equals a value decoded with decodeVariables.
+func jsonValuesEqual(raw json.RawMessage, value any) bool {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	var expected any
+	if err := decoder.Decode(&expected); err != nil {
+		return false
+	}
+	// maps are encoded with sorted keys, making the encodings comparable
+	a, errA := json.Marshal(expected)
+	b, errB := json.Marshal(value)
+	return errA == nil && errB == nil && bytes.Equal(a, b)
+}
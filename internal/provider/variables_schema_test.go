@@ -0,0 +1,110 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseVariablesSchema(t *testing.T) {
+	tests := []struct {
+		schema string
+		err    string
+	}{
+		{schema: `true`},
+		{schema: `{"$schema": "https://json-schema.org/draft/2020-12/schema", "title": "Hosts", "type": ["object", "null"]}`},
+		{schema: `[]`, err: "must be a JSON object or a boolean"},
+		{schema: `{"type": "text"}`, err: `unknown type "text"`},
+		{schema: `{"type": 1}`, err: "type must be a type name or a list of them"},
+		{schema: `{"properties": {"port": {"oneOf": []}}}`, err: `keyword "oneOf" is not supported`},
+		{schema: `{"pattern": "("}`, err: `invalid pattern "("`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.schema, func(t *testing.T) {
+			_, err := parseVariablesSchema(test.schema)
+			if test.err == "" && err != nil {
+				t.Fatal(err)
+			}
+			if test.err != "" && (err == nil || !strings.Contains(err.Error(), test.err)) {
+				t.Fatalf("error = %v, expected %q", err, test.err)
+			}
+		})
+	}
+}
+
+func TestVariablesSchemaValidateDocument(t *testing.T) {
+	schema, err := parseVariablesSchema(`{
+		"type": "object",
+		"required": ["ansible_user", "http_port"],
+		"properties": {
+			"ansible_user": {"type": "string", "minLength": 2, "maxLength": 8, "pattern": "^[a-z]+$"},
+			"http_port": {"type": "integer", "minimum": 1, "maximum": 65535},
+			"env": {"enum": ["dev", "prod"]},
+			"managed": {"const": true},
+			"tags": {"type": "array", "minItems": 1, "maxItems": 2, "items": {"type": "string"}},
+			"db": {"type": "object", "properties": {"password": false}, "additionalProperties": {"type": "string"}}
+		},
+		"additionalProperties": false
+	}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		document   string
+		violations []string
+	}{
+		{
+			name:     "valid",
+			document: `{"ansible_user": "rhel", "http_port": 8080, "env": "prod", "managed": true, "tags": ["web"], "db": {"host": "db1"}}`,
+		},
+		{
+			name:       "empty document",
+			document:   "",
+			violations: []string{`variables: missing required key "ansible_user"`, `variables: missing required key "http_port"`},
+		},
+		{
+			name:     "violations sorted by location",
+			document: `{"ansible_user": "R", "http_port": 80.5, "env": "test", "managed": false, "tags": [], "extra": 1, "db": {"password": "s3cret", "port": 5432}}`,
+			violations: []string{
+				"ansible_user: must be at least 2 characters long",
+				`ansible_user: must match pattern "^[a-z]+$"`,
+				"db.password: is not allowed",
+				"db.port: must be of type string",
+				"env: must be one of the values listed in enum",
+				"http_port: must be of type integer",
+				"managed: must be equal to const",
+				"tags: must have at least 1 items",
+				`variables: unexpected key "extra"`,
+			},
+		},
+		{
+			name:       "bounds",
+			document:   `{"ansible_user": "administrator", "http_port": 70000, "tags": ["a", 2, "c"]}`,
+			violations: []string{"ansible_user: must be at most 8 characters long", "http_port: must be at most 65535", "tags: must have at most 2 items", "tags[1]: must be of type string"},
+		},
+		{
+			name:       "integral numbers are integers",
+			document:   `{"ansible_user": "rhel", "http_port": 0.0}`,
+			violations: []string{"http_port: must be at least 1"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			violations, err := schema.validateDocument(test.document)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(violations, test.violations) {
+				t.Errorf("violations = %q, expected %q", violations, test.violations)
+			}
+		})
+	}
+
+	if _, err := schema.validateDocument("not json"); err == nil {
+		t.Error("validating a document that is not JSON did not fail")
+	}
+}
@@ -0,0 +1,374 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &workflowNodeLinksResource{}
+	_ resource.ResourceWithConfigure      = &workflowNodeLinksResource{}
+	_ resource.ResourceWithValidateConfig = &workflowNodeLinksResource{}
+)
+
+// workflowLinkTypes are the conditions on which a workflow node runs the nodes linked after it.
+var workflowLinkTypes = []string{"success", "failure", "always"}
+
+// NewWorkflowNodeLinksResource is a helper function to simplify the provider implementation.
+func NewWorkflowNodeLinksResource() resource.Resource {
+	return &workflowNodeLinksResource{}
+}
+
+// workflowNodeLinksResource manages the links between the nodes of a workflow job template.
+type workflowNodeLinksResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *workflowNodeLinksResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_node_links"
+}
+
+// Schema defines the schema for the resource.
+func (r *workflowNodeLinksResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages every link between the nodes of a workflow job template. " +
+			"Links created outside of this resource are removed, and links forming a cycle are rejected when planning.",
+		Attributes: map[string]schema.Attribute{
+			"workflow_job_template_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the workflow job template.",
+				Validators:  idValidators(),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"links": schema.SetNestedAttribute{
+				Required:    true,
+				Description: "Links between the workflow nodes.",
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"parent_node_id": schema.Int64Attribute{
+							Required:    true,
+							Description: "Id of the node running first.",
+							Validators:  idValidators(),
+						},
+						"child_node_id": schema.Int64Attribute{
+							Required:    true,
+							Description: "Id of the node running after the parent node.",
+							Validators:  idValidators(),
+						},
+						"type": schema.StringAttribute{
+							Required:    true,
+							Description: "When the child node runs: success, failure or always.",
+							Validators:  []validator.String{stringvalidator.OneOf(workflowLinkTypes...)},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// workflowNodeLinksResourceModel maps the resource schema data.
+type workflowNodeLinksResourceModel struct {
+	WorkflowJobTemplateId types.Int64         `tfsdk:"workflow_job_template_id"`
+	Links                 []workflowLinkModel `tfsdk:"links"`
+}
+
+// workflowLinkModel is a link between two workflow nodes.
+type workflowLinkModel struct {
+	ParentNodeId types.Int64  `tfsdk:"parent_node_id"`
+	ChildNodeId  types.Int64  `tfsdk:"child_node_id"`
+	Type         types.String `tfsdk:"type"`
+}
+
+// workflowLink is a known link between two workflow nodes.
+type workflowLink struct {
+	parent   int64
+	child    int64
+	linkType string
+}
+
+func (l workflowLink) model() workflowLinkModel {
+	return workflowLinkModel{
+		ParentNodeId: types.Int64Value(l.parent),
+		ChildNodeId:  types.Int64Value(l.child),
+		Type:         types.StringValue(l.linkType),
+	}
+}
+
+// knownLinks returns the links whose attributes are all known.
+func knownLinks(models []workflowLinkModel) []workflowLink {
+	var links []workflowLink
+	for _, m := range models {
+		if m.ParentNodeId.IsUnknown() || m.ChildNodeId.IsUnknown() || m.Type.IsUnknown() {
+			continue
+		}
+		links = append(links, workflowLink{m.ParentNodeId.ValueInt64(), m.ChildNodeId.ValueInt64(), m.Type.ValueString()})
+	}
+	return links
+}
+
+// workflowCycle returns the nodes of a cycle formed by the links, or nil if there is none.
+func workflowCycle(links []workflowLink) []int64 {
+	next := make(map[int64][]int64)
+	for _, link := range links {
+		next[link.parent] = append(next[link.parent], link.child)
+	}
+	nodes := make([]int64, 0, len(next))
+	for node := range next {
+		nodes = append(nodes, node)
+	}
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i] < nodes[j] })
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	status := make(map[int64]int)
+	var stack []int64
+	var visit func(node int64) []int64
+	visit = func(node int64) []int64 {
+		status[node] = visiting
+		stack = append(stack, node)
+		for _, child := range next[node] {
+			switch status[child] {
+			case visiting:
+				for i, n := range stack {
+					if n == child {
+						return append(append([]int64{}, stack[i:]...), child)
+					}
+				}
+			case unvisited:
+				if cycle := visit(child); cycle != nil {
+					return cycle
+				}
+			}
+		}
+		stack = stack[:len(stack)-1]
+		status[node] = visited
+		return nil
+	}
+
+	for _, node := range nodes {
+		if status[node] == unvisited {
+			if cycle := visit(node); cycle != nil {
+				return cycle
+			}
+		}
+	}
+	return nil
+}
+
+// ValidateConfig rejects links forming a cycle, which AAP refuses.
+func (r *workflowNodeLinksResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var links types.Set
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("links"), &links)...)
+	if resp.Diagnostics.HasError() || links.IsNull() || links.IsUnknown() {
+		return
+	}
+	var models []workflowLinkModel
+	resp.Diagnostics.Append(links.ElementsAs(ctx, &models, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if cycle := workflowCycle(knownLinks(models)); cycle != nil {
+		nodes := make([]string, len(cycle))
+		for i, node := range cycle {
+			nodes[i] = strconv.FormatInt(node, 10)
+		}
+		resp.Diagnostics.AddAttributeError(
+			path.Root("links"),
+			"Workflow links form a cycle",
+			"The workflow nodes "+strings.Join(nodes, " -> ")+" are linked in a cycle.",
+		)
+	}
+}
+
+// currentLinks returns the links between the nodes of the workflow.
+func (r *workflowNodeLinksResource) currentLinks(workflowId int64) ([]workflowLink, error) {
+	nodes, err := r.client.GetWorkflowNodes(workflowId)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []workflowLink
+	for _, node := range nodes {
+		for linkType, children := range map[string][]int64{
+			"success": node.SuccessNodes,
+			"failure": node.FailureNodes,
+			"always":  node.AlwaysNodes,
+		} {
+			for _, child := range children {
+				links = append(links, workflowLink{node.Id, child, linkType})
+			}
+		}
+	}
+	sort.Slice(links, func(i, j int) bool {
+		a, b := links[i], links[j]
+		if a.parent != b.parent {
+			return a.parent < b.parent
+		}
+		if a.child != b.child {
+			return a.child < b.child
+		}
+		return a.linkType < b.linkType
+	})
+	return links, nil
+}
+
+// setLinks removes the links of the workflow that are not desired, then adds the missing ones.
+// Removing first avoids AAP rejecting a new link for forming a cycle with one being removed.
+func (r *workflowNodeLinksResource) setLinks(workflowId int64, desired []workflowLink) error {
+	current, err := r.currentLinks(workflowId)
+	if err != nil {
+		return err
+	}
+
+	want := make(map[workflowLink]bool, len(desired))
+	for _, link := range desired {
+		want[link] = true
+	}
+	have := make(map[workflowLink]bool, len(current))
+	for _, link := range current {
+		have[link] = true
+		if !want[link] {
+			if err := r.client.DisassociateWorkflowNode(link.parent, link.linkType, link.child); err != nil {
+				return err
+			}
+		}
+	}
+	for _, link := range desired {
+		if !have[link] {
+			if err := r.client.AssociateWorkflowNode(link.parent, link.linkType, link.child); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Create links the nodes.
+func (r *workflowNodeLinksResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_workflow_node_links", "create", &resp.Diagnostics)
+	var plan workflowNodeLinksResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setLinks(plan.WorkflowJobTemplateId.ValueInt64(), knownLinks(plan.Links)); err != nil {
+		resp.Diagnostics.AddError("Unable to link workflow nodes", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the links between the nodes of the workflow.
+func (r *workflowNodeLinksResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_workflow_node_links", "read", &resp.Diagnostics)
+	var state workflowNodeLinksResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workflow, err := r.client.GetWorkflowJobTemplate(state.WorkflowJobTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read workflow job template", err.Error())
+		return
+	}
+	if workflow == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	links, err := r.currentLinks(state.WorkflowJobTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read workflow nodes", err.Error())
+		return
+	}
+	state.Links = make([]workflowLinkModel, len(links))
+	for i, link := range links {
+		state.Links[i] = link.model()
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update adds and removes links.
+func (r *workflowNodeLinksResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_workflow_node_links", "update", &resp.Diagnostics)
+	var plan workflowNodeLinksResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if err := r.setLinks(plan.WorkflowJobTemplateId.ValueInt64(), knownLinks(plan.Links)); err != nil {
+		resp.Diagnostics.AddError("Unable to link workflow nodes", err.Error())
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes every link between the nodes of the workflow.
+func (r *workflowNodeLinksResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_workflow_node_links", "delete", &resp.Diagnostics)
+	var state workflowNodeLinksResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the links are gone along with the workflow
+	workflow, err := r.client.GetWorkflowJobTemplate(state.WorkflowJobTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read workflow job template", err.Error())
+		return
+	}
+	if workflow == nil {
+		return
+	}
+
+	if err := r.setLinks(state.WorkflowJobTemplateId.ValueInt64(), nil); err != nil {
+		resp.Diagnostics.AddError("Unable to unlink workflow nodes", err.Error())
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *workflowNodeLinksResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
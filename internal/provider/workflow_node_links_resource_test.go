@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWorkflowNodeLinksResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	workflow := mock.addObject("api/v2/workflow_job_templates", map[string]any{"name": "Release", "organization": organization})
+	build := mock.addObject("api/v2/workflow_job_template_nodes", map[string]any{"workflow_job_template": workflow, "identifier": "build"})
+	deploy := mock.addObject("api/v2/workflow_job_template_nodes", map[string]any{"workflow_job_template": workflow, "identifier": "deploy"})
+	notify := mock.addObject("api/v2/workflow_job_template_nodes", map[string]any{"workflow_job_template": workflow, "identifier": "notify"})
+	link := func(parent int64, child int64, linkType string) map[string]any {
+		return map[string]any{"parent_node_id": parent, "child_node_id": child, "type": linkType}
+	}
+
+	p := newTestProvider(t, mock, nil)
+	links := p.resource("aap_workflow_node_links")
+	message := links.tryApply(map[string]any{"workflow_job_template_id": workflow, "links": []any{
+		link(build, deploy, "success"), link(deploy, build, "failure"),
+	}})
+	if !strings.Contains(message, "Workflow links form a cycle") {
+		t.Errorf("applying cyclic links gives %q", message)
+	}
+
+	// a link made outside of the resource is removed
+	mock.members[fmt.Sprintf("api/v2/workflow_job_template_nodes/%d/always_nodes", deploy)] = []int64{build}
+	config := map[string]any{"workflow_job_template_id": workflow, "links": []any{
+		link(build, deploy, "success"), link(build, notify, "failure"), link(deploy, notify, "always"),
+	}}
+	links.apply(config)
+	for _, expected := range []struct {
+		node     int64
+		linkType string
+		children []int64
+	}{
+		{build, "success", []int64{deploy}},
+		{build, "failure", []int64{notify}},
+		{deploy, "always", []int64{notify}},
+		{deploy, "success", nil},
+	} {
+		if children := mock.related("api/v2/workflow_job_template_nodes", expected.node, expected.linkType+"_nodes"); !slices.Equal(children, expected.children) {
+			t.Errorf("node %d runs %v on %s, expected %v", expected.node, children, expected.linkType, expected.children)
+		}
+	}
+
+	links.read()
+	if changes := links.planChanges(config); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	links.destroy()
+	for _, node := range []int64{build, deploy, notify} {
+		for _, linkType := range workflowLinkTypes {
+			if children := mock.related("api/v2/workflow_job_template_nodes", node, linkType+"_nodes"); len(children) > 0 {
+				t.Errorf("node %d still runs %v on %s after destroy", node, children, linkType)
+			}
+		}
+	}
+}
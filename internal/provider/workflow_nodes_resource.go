@@ -0,0 +1,424 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &workflowNodesResource{}
+	_ resource.ResourceWithConfigure      = &workflowNodesResource{}
+	_ resource.ResourceWithValidateConfig = &workflowNodesResource{}
+	_ resource.ResourceWithModifyPlan     = &workflowNodesResource{}
+)
+
+// NewWorkflowNodesResource is a helper function to simplify the provider implementation.
+func NewWorkflowNodesResource() resource.Resource {
+	return &workflowNodesResource{}
+}
+
+// workflowNodesResource manages every node of a workflow job template and the links between them from a single document.
+type workflowNodesResource struct {
+	client *AAPClient
+}
+
+// Metadata returns the resource type name.
+func (r *workflowNodesResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_workflow_nodes"
+}
+
+// Schema defines the schema for the resource.
+func (r *workflowNodesResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Manages every node of a workflow job template and the links between them from a single JSON or YAML document, " +
+			"such as the workflow_nodes exported by awx, e.g. to migrate existing workflows. " +
+			"Nodes are matched by identifier; nodes created outside of this resource are removed, " +
+			"so it must not be combined with aap_workflow_node_links on the same workflow.",
+		Attributes: map[string]schema.Attribute{
+			"workflow_job_template_id": schema.Int64Attribute{
+				Required:    true,
+				Description: "Id of the workflow job template.",
+				Validators:  idValidators(),
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"spec": schema.StringAttribute{
+				Required: true,
+				Description: "Workflow specification: a mapping with a workflow_nodes list, or an awx export holding a single workflow job template. " +
+					"Each node has a unique identifier, the unified_job_template it runs given by id or by natural key " +
+					"({name, type, organization: {name}}), and the identifiers of the nodes running after it in success_nodes, " +
+					"failure_nodes and always_nodes. Nodes may also set inventory, extra_data, limit, scm_branch, job_type, job_tags, " +
+					"skip_tags, verbosity, diff_mode and all_parents_must_converge; other exported fields must be empty. " +
+					"Changes to the nodes made outside of Terraform are only detected when they add, remove or relink nodes.",
+			},
+			"node_ids": schema.MapAttribute{
+				ElementType: types.Int64Type,
+				Computed:    true,
+				Description: "Ids of the workflow nodes by identifier.",
+				PlanModifiers: []planmodifier.Map{
+					mapplanmodifier.UseStateForUnknown(),
+				},
+			},
+		},
+	}
+}
+
+// workflowNodesResourceModel maps the resource schema data.
+type workflowNodesResourceModel struct {
+	WorkflowJobTemplateId types.Int64  `tfsdk:"workflow_job_template_id"`
+	Spec                  types.String `tfsdk:"spec"`
+	NodeIds               types.Map    `tfsdk:"node_ids"`
+}
+
+// setNodeIds sets the ids of the workflow nodes by identifier.
+func (m *workflowNodesResourceModel) setNodeIds(ctx context.Context, ids map[string]int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	m.NodeIds, diags = types.MapValueFrom(ctx, types.Int64Type, ids)
+	return diags
+}
+
+// ValidateConfig ensures the specification can be parsed.
+func (r *workflowNodesResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var spec types.String
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("spec"), &spec)...)
+	if resp.Diagnostics.HasError() || spec.IsNull() || spec.IsUnknown() {
+		return
+	}
+
+	if _, err := parseWorkflowSpec(spec.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("spec"), "Invalid workflow specification", err.Error())
+	}
+}
+
+// ModifyPlan plans new node ids when the specification changes, as nodes may be added; the ids of the nodes are
+// kept otherwise.
+func (r *workflowNodesResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || req.State.Raw.IsNull() {
+		return
+	}
+
+	var plan, state workflowNodesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() || plan.Spec.Equal(state.Spec) {
+		return
+	}
+
+	plan.NodeIds = types.MapUnknown(types.Int64Type)
+	resp.Diagnostics.Append(resp.Plan.Set(ctx, &plan)...)
+}
+
+// resolve returns the id of the object a specification refers to.
+func (r *workflowNodesResource) resolve(ref workflowSpecRef, resolved map[workflowSpecRef]int64) (int64, error) {
+	if ref.name == "" {
+		return ref.id, nil
+	}
+	if id, ok := resolved[ref]; ok {
+		return id, nil
+	}
+
+	var object *AAPObjectRef
+	var err error
+	if ref.kind == "inventory" {
+		object, err = r.client.FindInventory(ref.name, ref.organization)
+	} else {
+		object, err = r.client.FindUnifiedJobTemplate(ref.kind, ref.name, ref.organization, ref.inventory)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if object == nil {
+		return 0, fmt.Errorf("%s not found", ref)
+	}
+	resolved[ref] = object.Id
+	return object.Id, nil
+}
+
+// fields returns the fields of the workflow node, every managed prompt being reset when the specification leaves it out.
+func (r *workflowNodesResource) fields(node workflowSpecNode, resolved map[workflowSpecRef]int64) (map[string]any, error) {
+	fields := map[string]any{"identifier": node.identifier, "inventory": nil}
+	for key, value := range workflowSpecPromptFields {
+		fields[key] = value
+	}
+	for key, value := range node.prompts {
+		fields[key] = value
+	}
+
+	template, err := r.resolve(node.template, resolved)
+	if err != nil {
+		return nil, fmt.Errorf("node %q: %w", node.identifier, err)
+	}
+	fields["unified_job_template"] = template
+	if node.inventory != nil {
+		inventory, err := r.resolve(*node.inventory, resolved)
+		if err != nil {
+			return nil, fmt.Errorf("node %q: %w", node.identifier, err)
+		}
+		fields["inventory"] = inventory
+	}
+	return fields, nil
+}
+
+// setNodes makes the nodes of the workflow match the specification, returning their ids by identifier. The nodes
+// left out are removed first, then the others are created or updated and finally linked.
+func (r *workflowNodesResource) setNodes(workflowId int64, spec *workflowSpec) (map[string]int64, error) {
+	resolved := make(map[workflowSpecRef]int64)
+	fields := make([]map[string]any, len(spec.nodes))
+	for i, node := range spec.nodes {
+		var err error
+		if fields[i], err = r.fields(node, resolved); err != nil {
+			return nil, err
+		}
+	}
+
+	current, err := r.client.GetWorkflowNodes(workflowId)
+	if err != nil {
+		return nil, err
+	}
+	desired := make(map[string]bool, len(spec.nodes))
+	for _, node := range spec.nodes {
+		desired[node.identifier] = true
+	}
+	ids := make(map[string]int64, len(spec.nodes))
+	for _, node := range current {
+		if desired[node.Identifier] {
+			ids[node.Identifier] = node.Id
+		} else if err := r.client.DeleteWorkflowNode(node.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, node := range spec.nodes {
+		if id, ok := ids[node.identifier]; ok {
+			if _, err := r.client.UpdateWorkflowNode(id, fields[i]); err != nil {
+				return nil, fmt.Errorf("node %q: %w", node.identifier, err)
+			}
+			continue
+		}
+		created, err := r.client.CreateWorkflowNode(workflowId, fields[i])
+		if err != nil {
+			return nil, fmt.Errorf("node %q: %w", node.identifier, err)
+		}
+		ids[node.identifier] = created.Id
+	}
+
+	var links []workflowLink
+	for _, link := range spec.identifierLinks() {
+		links = append(links, workflowLink{ids[link[0]], ids[link[1]], link[2]})
+	}
+	linker := workflowNodeLinksResource{client: r.client}
+	if err := linker.setLinks(workflowId, links); err != nil {
+		return nil, err
+	}
+	return ids, nil
+}
+
+// currentSpec returns the nodes of the workflow and the links between them as a specification, the ids of the
+// templates standing for their natural keys, along with the ids of the nodes by identifier.
+func (r *workflowNodesResource) currentSpec(workflowId int64) (*workflowSpec, map[string]int64, error) {
+	nodes, err := r.client.GetWorkflowNodes(workflowId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	identifiers := make(map[int64]string, len(nodes))
+	ids := make(map[string]int64, len(nodes))
+	for _, node := range nodes {
+		identifiers[node.Id] = node.Identifier
+		ids[node.Identifier] = node.Id
+	}
+	spec := &workflowSpec{}
+	for _, node := range nodes {
+		specNode := workflowSpecNode{identifier: node.Identifier, links: make(map[string][]string)}
+		if node.UnifiedJobTemplate != nil {
+			specNode.template = workflowSpecRef{id: *node.UnifiedJobTemplate}
+		}
+		for linkType, children := range map[string][]int64{
+			"success": node.SuccessNodes,
+			"failure": node.FailureNodes,
+			"always":  node.AlwaysNodes,
+		} {
+			for _, child := range children {
+				specNode.links[linkType] = append(specNode.links[linkType], identifiers[child])
+			}
+			sort.Strings(specNode.links[linkType])
+		}
+		spec.nodes = append(spec.nodes, specNode)
+	}
+	sort.Slice(spec.nodes, func(i, j int) bool { return spec.nodes[i].identifier < spec.nodes[j].identifier })
+	return spec, ids, nil
+}
+
+// identifiers returns the sorted identifiers of the nodes of the specification.
+func (s *workflowSpec) identifiers() []string {
+	identifiers := make([]string, len(s.nodes))
+	for i, node := range s.nodes {
+		identifiers[i] = node.identifier
+	}
+	sort.Strings(identifiers)
+	return identifiers
+}
+
+// render returns the JSON document of the specification, with the templates of the nodes given by id.
+func (s *workflowSpec) render() string {
+	nodes := make([]map[string]any, len(s.nodes))
+	for i, node := range s.nodes {
+		nodes[i] = map[string]any{
+			"identifier":           node.identifier,
+			"unified_job_template": node.template.id,
+		}
+		for _, linkType := range workflowLinkTypes {
+			nodes[i][linkType+"_nodes"] = append([]string{}, node.links[linkType]...)
+		}
+	}
+	document, _ := json.MarshalIndent(map[string]any{"workflow_nodes": nodes}, "", "  ")
+	return string(document)
+}
+
+// Create creates the nodes of the workflow and links them.
+func (r *workflowNodesResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	defer r.client.reportStats(ctx, "aap_workflow_nodes", "create", &resp.Diagnostics)
+	var plan workflowNodesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, err := parseWorkflowSpec(plan.Spec.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("spec"), "Invalid workflow specification", err.Error())
+		return
+	}
+	ids, err := r.setNodes(plan.WorkflowJobTemplateId.ValueInt64(), spec)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create workflow nodes", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(plan.setNodeIds(ctx, ids)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Read refreshes the nodes of the workflow. The specification is kept as written unless nodes were added, removed
+// or relinked, in which case it is replaced by the current nodes so that the difference shows in the plan.
+func (r *workflowNodesResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	defer r.client.reportStats(ctx, "aap_workflow_nodes", "read", &resp.Diagnostics)
+	var state workflowNodesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	workflow, err := r.client.GetWorkflowJobTemplate(state.WorkflowJobTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read workflow job template", err.Error())
+		return
+	}
+	if workflow == nil {
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	current, ids, err := r.currentSpec(state.WorkflowJobTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read workflow nodes", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(state.setNodeIds(ctx, ids)...)
+	spec, err := parseWorkflowSpec(state.Spec.ValueString())
+	if err != nil || !reflect.DeepEqual(spec.identifiers(), current.identifiers()) ||
+		!reflect.DeepEqual(spec.identifierLinks(), current.identifierLinks()) {
+		state.Spec = types.StringValue(current.render())
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &state)...)
+}
+
+// Update creates, updates, removes and relinks the nodes of the workflow.
+func (r *workflowNodesResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	defer r.client.reportStats(ctx, "aap_workflow_nodes", "update", &resp.Diagnostics)
+	var plan workflowNodesResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	spec, err := parseWorkflowSpec(plan.Spec.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("spec"), "Invalid workflow specification", err.Error())
+		return
+	}
+	ids, err := r.setNodes(plan.WorkflowJobTemplateId.ValueInt64(), spec)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to update workflow nodes", err.Error())
+		return
+	}
+	resp.Diagnostics.Append(plan.setNodeIds(ctx, ids)...)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &plan)...)
+}
+
+// Delete removes every node of the workflow.
+func (r *workflowNodesResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	defer r.client.reportStats(ctx, "aap_workflow_nodes", "delete", &resp.Diagnostics)
+	var state workflowNodesResourceModel
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// the nodes are gone along with the workflow
+	workflow, err := r.client.GetWorkflowJobTemplate(state.WorkflowJobTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read workflow job template", err.Error())
+		return
+	}
+	if workflow == nil {
+		return
+	}
+
+	nodes, err := r.client.GetWorkflowNodes(state.WorkflowJobTemplateId.ValueInt64())
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to read workflow nodes", err.Error())
+		return
+	}
+	for _, node := range nodes {
+		if err := r.client.DeleteWorkflowNode(node.Id); err != nil {
+			resp.Diagnostics.AddError("Unable to delete workflow node", err.Error())
+			return
+		}
+	}
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *workflowNodesResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*AAPClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *AAPClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client.withStats()
+}
@@ -0,0 +1,70 @@
+package provider
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestWorkflowNodesResource(t *testing.T) {
+	mock := newMockAAP(t)
+	organization := mock.addObject("api/v2/organizations", map[string]any{"name": "Default"})
+	deploy := mock.addObject("api/v2/job_templates", map[string]any{"name": "Deploy", "organization": organization})
+	rollback := mock.addObject("api/v2/job_templates", map[string]any{"name": "Rollback", "organization": organization})
+	workflow := mock.addObject("api/v2/workflow_job_templates", map[string]any{"name": "Release", "organization": organization})
+	stray := mock.addObject("api/v2/workflow_job_template_nodes", map[string]any{"workflow_job_template": workflow, "identifier": "stray"})
+
+	p := newTestProvider(t, mock, nil)
+	nodes := p.resource("aap_workflow_nodes")
+	state := nodes.apply(map[string]any{
+		"workflow_job_template_id": workflow,
+		"spec": `
+workflow_nodes:
+  - identifier: deploy
+    unified_job_template: {name: Deploy, type: job_template, organization: {name: Default}}
+    limit: web
+    failure_nodes: [rollback]
+  - identifier: rollback
+    unified_job_template: ` + fmt.Sprint(rollback) + `
+`,
+	})
+
+	ids, _ := state["node_ids"].(map[string]any)
+	if len(ids) != 2 {
+		t.Fatalf("node_ids = %v, expected the ids of deploy and rollback", state["node_ids"])
+	}
+	deployNode := mock.object("api/v2/workflow_job_template_nodes", ids["deploy"].(int64))
+	if deployNode == nil || mockId(deployNode["unified_job_template"]) != deploy || deployNode["limit"] != "web" {
+		t.Errorf("deploy node = %v", deployNode)
+	}
+	if failure := mock.related("api/v2/workflow_job_template_nodes", ids["deploy"].(int64), "failure_nodes"); !slices.Equal(failure, []int64{ids["rollback"].(int64)}) {
+		t.Errorf("deploy fails over to %v, expected the rollback node", failure)
+	}
+	if mock.object("api/v2/workflow_job_template_nodes", stray) != nil {
+		t.Error("the node left out of the specification was not removed")
+	}
+
+	spec := state["spec"]
+	nodes.read()
+	testExpect(t, nodes.State(), map[string]any{"spec": spec, "node_ids": ids})
+	if changes := nodes.planChanges(map[string]any{"workflow_job_template_id": workflow, "spec": spec}); len(changes) > 0 {
+		t.Errorf("plan after refresh changes %v", changes)
+	}
+
+	state = nodes.apply(map[string]any{
+		"workflow_job_template_id": workflow,
+		"spec": fmt.Sprintf(`{"workflow_nodes": [
+			{"identifier": "deploy", "unified_job_template": %d, "always_nodes": ["notify"]},
+			{"identifier": "notify", "unified_job_template": %d}
+		]}`, deploy, rollback),
+	})
+	updated, _ := state["node_ids"].(map[string]any)
+	if updated["deploy"] != ids["deploy"] || updated["notify"] == nil || updated["rollback"] != nil {
+		t.Errorf("node_ids = %v after replacing rollback by notify", updated)
+	}
+
+	nodes.destroy()
+	if remaining := mock.filterObjects("api/v2/workflow_job_template_nodes", nil, nil); len(remaining) > 0 {
+		t.Errorf("%d workflow nodes left after destroy", len(remaining))
+	}
+}
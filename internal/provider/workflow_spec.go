@@ -0,0 +1,304 @@
+package provider
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// workflowSpecPromptFields are the fields of a workflow node answering the launch prompts of its template that a
+// workflow specification sets, with the value AAP uses when they are left out.
+var workflowSpecPromptFields = map[string]any{
+	"extra_data":                map[string]any{},
+	"limit":                     nil,
+	"scm_branch":                nil,
+	"job_type":                  nil,
+	"job_tags":                  nil,
+	"skip_tags":                 nil,
+	"verbosity":                 nil,
+	"diff_mode":                 nil,
+	"all_parents_must_converge": false,
+}
+
+// workflowSpecIgnoredFields are the fields of the nodes exported by awx that do not describe the node itself.
+var workflowSpecIgnoredFields = map[string]bool{
+	"id": true, "type": true, "url": true, "related": true, "summary_fields": true, "natural_key": true,
+	"workflow_job_template": true, "created": true, "modified": true,
+}
+
+// workflowSpec is a parsed workflow specification: the nodes of a workflow and the links between them.
+type workflowSpec struct {
+	nodes []workflowSpecNode
+}
+
+// workflowSpecNode is a node of a workflow specification.
+type workflowSpecNode struct {
+	identifier string
+	template   workflowSpecRef
+	inventory  *workflowSpecRef
+	prompts    map[string]any
+	// identifiers of the nodes linked after this one, by link type
+	links map[string][]string
+}
+
+// workflowSpecRef refers to an AAP object by id, or by name as awx exports do.
+type workflowSpecRef struct {
+	id           int64
+	kind         string
+	name         string
+	organization string
+	inventory    string
+}
+
+func (r workflowSpecRef) String() string {
+	if r.name == "" {
+		return fmt.Sprintf("%d", r.id)
+	}
+	description := fmt.Sprintf("%s %q", strings.ReplaceAll(r.kind, "_", " "), r.name)
+	if r.inventory != "" {
+		description += fmt.Sprintf(" of inventory %q", r.inventory)
+	}
+	if r.organization != "" {
+		description += fmt.Sprintf(" in organization %q", r.organization)
+	}
+	return description
+}
+
+// parseWorkflowSpec parses a JSON or YAML workflow specification: a mapping with the list of workflow_nodes, or an
+// awx export holding a single workflow job template. Nodes are identified by their identifier and list the
+// identifiers of the nodes linked after them in success_nodes, failure_nodes and always_nodes.
+func parseWorkflowSpec(document string) (*workflowSpec, error) {
+	var root map[string]any
+	if err := yaml.Unmarshal([]byte(document), &root); err != nil {
+		return nil, fmt.Errorf("the specification must be a JSON or YAML mapping: %w", err)
+	}
+
+	if exported, ok := root["workflow_job_templates"]; ok {
+		workflows, ok := exported.([]any)
+		if !ok || len(workflows) != 1 {
+			return nil, fmt.Errorf("workflow_job_templates must hold exactly one workflow job template")
+		}
+		if root, ok = workflows[0].(map[string]any); !ok {
+			return nil, fmt.Errorf("workflow_job_templates[0] is a %T, expected a mapping", workflows[0])
+		}
+	}
+	rawNodes, ok := root["workflow_nodes"].([]any)
+	if !ok {
+		return nil, fmt.Errorf("the specification must have a workflow_nodes list")
+	}
+
+	spec := &workflowSpec{}
+	seen := make(map[string]bool)
+	for i, rawNode := range rawNodes {
+		node, err := parseWorkflowSpecNode(rawNode)
+		if err != nil {
+			return nil, fmt.Errorf("workflow_nodes[%d]: %w", i, err)
+		}
+		if seen[node.identifier] {
+			return nil, fmt.Errorf("workflow_nodes[%d]: identifier %q is used by several nodes", i, node.identifier)
+		}
+		seen[node.identifier] = true
+		spec.nodes = append(spec.nodes, node)
+	}
+	for i, node := range spec.nodes {
+		for _, linkType := range workflowLinkTypes {
+			for _, child := range node.links[linkType] {
+				if !seen[child] {
+					return nil, fmt.Errorf("workflow_nodes[%d]: %s_nodes refers to the unknown node %q", i, linkType, child)
+				}
+			}
+		}
+	}
+
+	if cycle := spec.cycle(); cycle != nil {
+		return nil, fmt.Errorf("the workflow nodes %s are linked in a cycle", strings.Join(cycle, " -> "))
+	}
+	return spec, nil
+}
+
+func parseWorkflowSpecNode(raw any) (workflowSpecNode, error) {
+	fields, ok := raw.(map[string]any)
+	if !ok {
+		return workflowSpecNode{}, fmt.Errorf("a node is a %T, expected a mapping", raw)
+	}
+
+	node := workflowSpecNode{prompts: make(map[string]any), links: make(map[string][]string)}
+	for _, key := range sortedKeys(fields) {
+		value := fields[key]
+		var err error
+		switch {
+		case key == "identifier":
+			identifier, ok := value.(string)
+			if !ok || identifier == "" {
+				return node, fmt.Errorf("identifier must be a non-empty string")
+			}
+			node.identifier = identifier
+		case key == "unified_job_template":
+			node.template, err = parseWorkflowSpecRef(value, "job_template")
+		case key == "inventory":
+			if value != nil {
+				var inventory workflowSpecRef
+				inventory, err = parseWorkflowSpecRef(value, "inventory")
+				node.inventory = &inventory
+			}
+		case strings.HasSuffix(key, "_nodes") && workflowLinkType(strings.TrimSuffix(key, "_nodes")):
+			node.links[strings.TrimSuffix(key, "_nodes")], err = parseWorkflowSpecLinks(value)
+		default:
+			if _, ok := workflowSpecPromptFields[key]; ok {
+				node.prompts[key] = value
+			} else if !workflowSpecIgnoredFields[key] && !emptySpecValue(value) {
+				return node, fmt.Errorf("%s is not supported", key)
+			}
+		}
+		if err != nil {
+			return node, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	if node.identifier == "" {
+		return node, fmt.Errorf("identifier is required")
+	}
+	if node.template.id == 0 && node.template.name == "" {
+		return node, fmt.Errorf("unified_job_template is required")
+	}
+	return node, nil
+}
+
+// workflowLinkType reports whether name is a type of link between workflow nodes.
+func workflowLinkType(name string) bool {
+	for _, linkType := range workflowLinkTypes {
+		if name == linkType {
+			return true
+		}
+	}
+	return false
+}
+
+// emptySpecValue reports whether an exported field holds no value, as unsupported prompts do in awx exports.
+func emptySpecValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	}
+	return false
+}
+
+// parseWorkflowSpecRef parses an id, or a natural key with the name, the type when it is not defaultKind and the
+// organization of the object, e.g. {name: Deploy, type: job_template, organization: {name: Default}}.
+func parseWorkflowSpecRef(value any, defaultKind string) (workflowSpecRef, error) {
+	switch v := value.(type) {
+	case int:
+		if v < 1 {
+			return workflowSpecRef{}, fmt.Errorf("ids must be positive")
+		}
+		return workflowSpecRef{id: int64(v), kind: defaultKind}, nil
+	case map[string]any:
+		ref := workflowSpecRef{kind: defaultKind}
+		var ok bool
+		if ref.name, ok = v["name"].(string); !ok || ref.name == "" {
+			return ref, fmt.Errorf("name must be a non-empty string")
+		}
+		if kind, ok := v["type"].(string); ok && defaultKind != "inventory" {
+			if _, supported := unifiedJobTemplateEndpoints[kind]; !supported {
+				return ref, fmt.Errorf("type %q is not supported, expected one of %s", kind, strings.Join(sortedKeys(unifiedJobTemplateEndpoints), ", "))
+			}
+			ref.kind = kind
+		}
+		ref.organization = naturalKeyName(v["organization"])
+		if inventory, ok := v["inventory"].(map[string]any); ok {
+			ref.inventory = naturalKeyName(inventory)
+			if ref.organization == "" {
+				ref.organization = naturalKeyName(inventory["organization"])
+			}
+		}
+		return ref, nil
+	}
+	return workflowSpecRef{}, fmt.Errorf("expected an id or a mapping with a name, got a %T", value)
+}
+
+// naturalKeyName returns the name of an object referred to by its natural key or by name, empty if there is none.
+func naturalKeyName(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		name, _ := v["name"].(string)
+		return name
+	}
+	return ""
+}
+
+// parseWorkflowSpecLinks parses the identifiers of the nodes linked after a node, given as strings or as the
+// natural keys awx exports.
+func parseWorkflowSpecLinks(value any) ([]string, error) {
+	if value == nil {
+		return nil, nil
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected a list of node identifiers, got a %T", value)
+	}
+	identifiers := make([]string, len(items))
+	for i, item := range items {
+		identifier := naturalKeyIdentifier(item)
+		if identifier == "" {
+			return nil, fmt.Errorf("item %d is not a node identifier", i)
+		}
+		identifiers[i] = identifier
+	}
+	return identifiers, nil
+}
+
+func naturalKeyIdentifier(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]any:
+		identifier, _ := v["identifier"].(string)
+		return identifier
+	}
+	return ""
+}
+
+// identifierLinks returns the links between the nodes of the specification as parent, child and type.
+func (s *workflowSpec) identifierLinks() [][3]string {
+	var links [][3]string
+	for _, node := range s.nodes {
+		for _, linkType := range workflowLinkTypes {
+			for _, child := range node.links[linkType] {
+				links = append(links, [3]string{node.identifier, child, linkType})
+			}
+		}
+	}
+	sort.Slice(links, func(i, j int) bool {
+		return strings.Join(links[i][:], "\x00") < strings.Join(links[j][:], "\x00")
+	})
+	return links
+}
+
+// cycle returns the identifiers of nodes linked in a cycle, or nil if there is none.
+func (s *workflowSpec) cycle() []string {
+	numbers := make(map[string]int64, len(s.nodes))
+	for i, node := range s.nodes {
+		numbers[node.identifier] = int64(i + 1)
+	}
+	var links []workflowLink
+	for _, link := range s.identifierLinks() {
+		links = append(links, workflowLink{numbers[link[0]], numbers[link[1]], link[2]})
+	}
+	cycle := workflowCycle(links)
+	if cycle == nil {
+		return nil
+	}
+	identifiers := make([]string, len(cycle))
+	for i, number := range cycle {
+		identifiers[i] = s.nodes[number-1].identifier
+	}
+	return identifiers
+}
@@ -0,0 +1,174 @@
+package provider
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseWorkflowSpec(t *testing.T) {
+	tests := []struct {
+		name     string
+		document string
+		// expected are the identifiers of the nodes and the links between them
+		identifiers []string
+		links       [][3]string
+		err         string
+	}{
+		{
+			name: "yaml with ids",
+			document: `
+workflow_nodes:
+  - identifier: build
+    unified_job_template: 7
+    success_nodes: [deploy]
+    failure_nodes: [notify]
+  - identifier: deploy
+    unified_job_template: 8
+    always_nodes: [notify]
+  - identifier: notify
+    unified_job_template: 9
+`,
+			identifiers: []string{"build", "deploy", "notify"},
+			links:       [][3]string{{"build", "deploy", "success"}, {"build", "notify", "failure"}, {"deploy", "notify", "always"}},
+		},
+		{
+			name: "awx export with natural keys",
+			document: `{"workflow_job_templates": [{"name": "Release", "workflow_nodes": [
+				{"identifier": "a", "unified_job_template": {"name": "Deploy", "type": "job_template", "organization": {"name": "Default"}},
+				 "success_nodes": [{"identifier": "b", "workflow_job_template": {"name": "Release"}}], "related": {}, "credentials": []},
+				{"identifier": "b", "unified_job_template": {"name": "Sync", "type": "inventory_source", "inventory": {"name": "Servers", "organization": {"name": "Default"}}}}
+			]}]}`,
+			identifiers: []string{"a", "b"},
+			links:       [][3]string{{"a", "b", "success"}},
+		},
+		{
+			name:     "not a mapping",
+			document: `- a`,
+			err:      "must be a JSON or YAML mapping",
+		},
+		{
+			name:     "no nodes",
+			document: `{"name": "Release"}`,
+			err:      "must have a workflow_nodes list",
+		},
+		{
+			name:     "several exported workflows",
+			document: `{"workflow_job_templates": [{"workflow_nodes": []}, {"workflow_nodes": []}]}`,
+			err:      "exactly one workflow job template",
+		},
+		{
+			name:     "missing identifier",
+			document: `{"workflow_nodes": [{"unified_job_template": 7}]}`,
+			err:      "workflow_nodes[0]: identifier is required",
+		},
+		{
+			name:     "empty identifier",
+			document: `{"workflow_nodes": [{"identifier": "", "unified_job_template": 7}]}`,
+			err:      "identifier must be a non-empty string",
+		},
+		{
+			name:     "duplicate identifier",
+			document: `{"workflow_nodes": [{"identifier": "a", "unified_job_template": 7}, {"identifier": "a", "unified_job_template": 8}]}`,
+			err:      `workflow_nodes[1]: identifier "a" is used by several nodes`,
+		},
+		{
+			name:     "missing template",
+			document: `{"workflow_nodes": [{"identifier": "a"}]}`,
+			err:      "unified_job_template is required",
+		},
+		{
+			name:     "negative template id",
+			document: `{"workflow_nodes": [{"identifier": "a", "unified_job_template": -1}]}`,
+			err:      "ids must be positive",
+		},
+		{
+			name:     "natural key without name",
+			document: `{"workflow_nodes": [{"identifier": "a", "unified_job_template": {"type": "job_template"}}]}`,
+			err:      "name must be a non-empty string",
+		},
+		{
+			name:     "unsupported template type",
+			document: `{"workflow_nodes": [{"identifier": "a", "unified_job_template": {"name": "x", "type": "ad_hoc_command"}}]}`,
+			err:      `type "ad_hoc_command" is not supported`,
+		},
+		{
+			name:     "unknown linked node",
+			document: `{"workflow_nodes": [{"identifier": "a", "unified_job_template": 7, "success_nodes": ["b"]}]}`,
+			err:      `success_nodes refers to the unknown node "b"`,
+		},
+		{
+			name:     "unsupported field",
+			document: `{"workflow_nodes": [{"identifier": "a", "unified_job_template": 7, "timeout": 30}]}`,
+			err:      "timeout is not supported",
+		},
+		{
+			name:     "self link",
+			document: `{"workflow_nodes": [{"identifier": "a", "unified_job_template": 7, "always_nodes": ["a"]}]}`,
+			err:      "linked in a cycle",
+		},
+		{
+			name: "cycle",
+			document: `{"workflow_nodes": [
+				{"identifier": "a", "unified_job_template": 7, "success_nodes": ["b"]},
+				{"identifier": "b", "unified_job_template": 7, "failure_nodes": ["c"]},
+				{"identifier": "c", "unified_job_template": 7, "always_nodes": ["a"]}
+			]}`,
+			err: "linked in a cycle",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			spec, err := parseWorkflowSpec(test.document)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("error = %v, expected %q", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if identifiers := spec.identifiers(); !reflect.DeepEqual(identifiers, test.identifiers) {
+				t.Errorf("identifiers = %v, expected %v", identifiers, test.identifiers)
+			}
+			if links := spec.identifierLinks(); !reflect.DeepEqual(links, test.links) {
+				t.Errorf("links = %v, expected %v", links, test.links)
+			}
+		})
+	}
+}
+
+func TestParseWorkflowSpecRefs(t *testing.T) {
+	spec, err := parseWorkflowSpec(`
+workflow_nodes:
+  - identifier: deploy
+    unified_job_template: {name: Deploy, organization: {name: Default}}
+    inventory: {name: Servers, organization: {name: Ops}}
+    limit: web
+  - identifier: sync
+    unified_job_template: {name: Cloud, type: inventory_source, inventory: {name: Servers, organization: {name: Ops}}}
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deploy, sync := spec.nodes[0], spec.nodes[1]
+	if expected := (workflowSpecRef{kind: "job_template", name: "Deploy", organization: "Default"}); deploy.template != expected {
+		t.Errorf("deploy runs %+v, expected %+v", deploy.template, expected)
+	}
+	if expected := (workflowSpecRef{kind: "inventory", name: "Servers", organization: "Ops"}); deploy.inventory == nil || *deploy.inventory != expected {
+		t.Errorf("deploy uses inventory %+v, expected %+v", deploy.inventory, expected)
+	}
+	if deploy.prompts["limit"] != "web" {
+		t.Errorf("deploy prompts = %v", deploy.prompts)
+	}
+	// inventory sources are in the organization of their inventory
+	if expected := (workflowSpecRef{kind: "inventory_source", name: "Cloud", organization: "Ops", inventory: "Servers"}); sync.template != expected {
+		t.Errorf("sync runs %+v, expected %+v", sync.template, expected)
+	}
+	if description := sync.template.String(); description != `inventory source "Cloud" of inventory "Servers" in organization "Ops"` {
+		t.Errorf("description = %s", description)
+	}
+}